@@ -0,0 +1,207 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strings"
+
+	// SDK
+	//
+	// A high-level software development toolkit based on the Flash Framework
+	// for building highly efficient and fault-tolerant applications.
+
+	"github.com/flash-go/sdk/config"
+	"github.com/flash-go/sdk/infra"
+	"github.com/flash-go/sdk/state"
+
+	// Ports
+
+	//// Services
+	emailsServicePort "github.com/flash-go/notifications-service/internal/port/service/emails"
+
+	// Implementations
+
+	//// Repository
+	emailsRepositoryAdapterImpl "github.com/flash-go/notifications-service/internal/adapter/repository/emails"
+
+	//// Services
+	emailsServiceImpl "github.com/flash-go/notifications-service/internal/service/emails"
+
+	// Other
+	_ "github.com/joho/godotenv/autoload"
+)
+
+const usage = `Usage:
+  bundle export [-folder ID] [-format json|zip] [-out FILE]
+  bundle import [-parent ID] [-strategy skip|overwrite|rename] [-prune] [-apply] FILE`
+
+func main() {
+	if len(os.Args) < 2 {
+		log.Fatal(usage)
+	}
+
+	// Create state service
+	stateService := state.NewWithSecureAuth(
+		&state.SecureAuthConfig{
+			Address:            config.GetEnvStr("CONSUL_ADDR"),
+			CAPem:              config.GetEnvBase64("CONSUL_CA_CRT"),
+			CertPEM:            config.GetEnvBase64("CONSUL_CLIENT_CRT"),
+			KeyPEM:             config.GetEnvBase64("CONSUL_CLIENT_KEY"),
+			InsecureSkipVerify: config.GetEnvBool("CONSUL_INSECURE_SKIP_VERIFY"),
+			Token:              config.GetEnvStr("CONSUL_TOKEN"),
+		},
+	)
+
+	// Create config
+	cfg := config.New(
+		stateService,
+		config.GetEnvStr("SERVICE_NAME"),
+	)
+
+	// Create postgres client without migrations
+	postgresClient := infra.NewPostgresClient(
+		&infra.PostgresClientConfig{
+			Cfg:        cfg,
+			Telemetry:  nil,
+			Migrations: nil,
+		},
+	)
+
+	// Create repository
+	emailsRepository := emailsRepositoryAdapterImpl.New(
+		&emailsRepositoryAdapterImpl.Config{
+			PostgresClient: postgresClient,
+		},
+	)
+
+	// Create services
+	emailsService := emailsServiceImpl.New(
+		&emailsServiceImpl.Config{
+			EmailsRepository: emailsRepository,
+		},
+	)
+
+	// Run command
+	switch os.Args[1] {
+	case "export":
+		exportBundle(emailsService, os.Args[2:])
+	case "import":
+		importBundle(emailsService, os.Args[2:])
+	default:
+		log.Fatal(usage)
+	}
+}
+
+func exportBundle(emailsService emailsServicePort.Interface, args []string) {
+	// Parse flags
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	folder := flags.Uint("folder", 0, "root folder id (whole tree if not set)")
+	format := flags.String("format", emailsServicePort.BundleFormatJson, "bundle format: json or zip")
+	out := flags.String("out", "", "output file (stdout if not set)")
+	flags.Parse(args)
+	if *format != emailsServicePort.BundleFormatJson && *format != emailsServicePort.BundleFormatZip {
+		log.Fatal(usage)
+	}
+
+	// Set root folder
+	var folderId *uint
+	if *folder > 0 {
+		folderId = folder
+	}
+
+	// Export bundle
+	bundle, err := emailsService.ExportBundle(
+		context.Background(),
+		emailsServicePort.ExportBundleData{
+			FolderId: folderId,
+			Format:   *format,
+		},
+	)
+	if err != nil {
+		log.Fatalf("failed to export bundle: %v", err)
+	}
+
+	// Write bundle
+	if *out == "" {
+		os.Stdout.Write(bundle.Data)
+		return
+	}
+	if err := os.WriteFile(*out, bundle.Data, 0o644); err != nil {
+		log.Fatalf("failed to write bundle: %v", err)
+	}
+}
+
+func importBundle(emailsService emailsServicePort.Interface, args []string) {
+	// Parse flags
+	flags := flag.NewFlagSet("import", flag.ExitOnError)
+	parent := flags.Uint("parent", 0, "target parent folder id (root if not set)")
+	strategy := flags.String("strategy", emailsServicePort.ImportStrategySkip, "conflict strategy: skip, overwrite or rename")
+	prune := flags.Bool("prune", false, "delete entities missing from the bundle (overwrite strategy only)")
+	apply := flags.Bool("apply", false, "apply the plan (dry run if not set)")
+	flags.Parse(args)
+	if *prune && *strategy != emailsServicePort.ImportStrategyOverwrite {
+		log.Fatal(usage)
+	}
+	if flags.NArg() != 1 || !slices.Contains(
+		[]string{
+			emailsServicePort.ImportStrategySkip,
+			emailsServicePort.ImportStrategyOverwrite,
+			emailsServicePort.ImportStrategyRename,
+		},
+		*strategy,
+	) {
+		log.Fatal(usage)
+	}
+
+	// Read bundle
+	data, err := os.ReadFile(flags.Arg(0))
+	if err != nil {
+		log.Fatalf("failed to read bundle: %v", err)
+	}
+	format := emailsServicePort.BundleFormatJson
+	if strings.HasSuffix(strings.ToLower(flags.Arg(0)), ".zip") {
+		format = emailsServicePort.BundleFormatZip
+	}
+
+	// Set target parent folder
+	var parentId *uint
+	if *parent > 0 {
+		parentId = parent
+	}
+
+	// Import bundle
+	actions, err := emailsService.ImportBundle(
+		// Command line operators may edit system templates
+		emailsServicePort.WithSystemAccess(context.Background()),
+		emailsServicePort.ImportBundleData{
+			ParentId: parentId,
+			Strategy: *strategy,
+			Prune:    *prune,
+			DryRun:   !*apply,
+			Format:   format,
+			Bundle:   data,
+		},
+	)
+	if err != nil {
+		log.Fatalf("failed to import bundle: %v", err)
+	}
+
+	// Print plan
+	for _, action := range *actions {
+		line := fmt.Sprintf("%-9s %-6s %s", action.Action, action.Entity, action.Key)
+		if action.Target != nil {
+			line += " -> " + *action.Target
+		}
+		if len(action.Changes) > 0 {
+			line += " (" + strings.Join(action.Changes, ", ") + ")"
+		}
+		fmt.Println(line)
+	}
+	if !*apply {
+		fmt.Println("Dry run, nothing applied. Use -apply to apply the plan.")
+	}
+}
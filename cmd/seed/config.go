@@ -7,16 +7,49 @@ import (
 )
 
 var envMap = map[string]string{
-	"OTEL_COLLECTOR_GRPC":       telemetry.OtelCollectorGrpcOptKey,
-	"OTEL_COLLECTOR_CA_CRT":     telemetry.OtelCollectorCaCrtOptKey,
-	"OTEL_COLLECTOR_CLIENT_CRT": telemetry.OtelCollectorClientCrtOptKey,
-	"OTEL_COLLECTOR_CLIENT_KEY": telemetry.OtelCollectorClientKeyOptKey,
-	"POSTGRES_HOST":             infra.PostgresHostOptKey,
-	"POSTGRES_PORT":             infra.PostgresPortOptKey,
-	"POSTGRES_USER":             infra.PostgresUserOptKey,
-	"POSTGRES_PASSWORD":         infra.PostgresPasswordOptKey,
-	"POSTGRES_DB":               infra.PostgresDbOptKey,
-	"USERS_SERVICE_NAME":        internalConfig.UsersServiceNameOptKey,
-	"USERS_ADMIN_ROLE":          internalConfig.UsersAdminRoleOptKey,
-	"EMAIL_SMTP_BZ_API_KEY":     internalConfig.ProvidersEmailSmtpBzApiKeyOptKey,
+	"OTEL_COLLECTOR_GRPC":            telemetry.OtelCollectorGrpcOptKey,
+	"OTEL_COLLECTOR_CA_CRT":          telemetry.OtelCollectorCaCrtOptKey,
+	"OTEL_COLLECTOR_CLIENT_CRT":      telemetry.OtelCollectorClientCrtOptKey,
+	"OTEL_COLLECTOR_CLIENT_KEY":      telemetry.OtelCollectorClientKeyOptKey,
+	"POSTGRES_HOST":                  infra.PostgresHostOptKey,
+	"POSTGRES_PORT":                  infra.PostgresPortOptKey,
+	"POSTGRES_USER":                  infra.PostgresUserOptKey,
+	"POSTGRES_PASSWORD":              infra.PostgresPasswordOptKey,
+	"POSTGRES_DB":                    infra.PostgresDbOptKey,
+	"USERS_SERVICE_NAME":             internalConfig.UsersServiceNameOptKey,
+	"USERS_ADMIN_ROLE":               internalConfig.UsersAdminRoleOptKey,
+	"USERS_SYSTEM_ROLE":              internalConfig.UsersSystemRoleOptKey,
+	"EMAIL_PROVIDER":                 internalConfig.ProvidersEmailProviderOptKey,
+	"EMAIL_SMTP_ADDR":                internalConfig.ProvidersEmailSmtpAddrOptKey,
+	"EMAIL_SMTP_USERNAME":            internalConfig.ProvidersEmailSmtpUsernameOptKey,
+	"EMAIL_SMTP_PASSWORD":            internalConfig.ProvidersEmailSmtpPasswordOptKey,
+	"EMAIL_SMTP_BZ_API_KEY":          internalConfig.ProvidersEmailSmtpBzApiKeyOptKey,
+	"EMAIL_SMTP_BZ_API_URL":          internalConfig.ProvidersEmailSmtpBzApiUrlOptKey,
+	"EMAIL_TRASH_RETENTION_DAYS":     internalConfig.EmailsTrashRetentionDaysOptKey,
+	"EMAIL_DNS_RESOLVER":             internalConfig.EmailsDnsResolverOptKey,
+	"EMAIL_DKIM_SELECTOR":            internalConfig.EmailsDkimSelectorOptKey,
+	"EMAIL_SPF_INCLUDE":              internalConfig.EmailsSpfIncludeOptKey,
+	"EMAIL_UNVERIFIED_DOMAIN_POLICY": internalConfig.EmailsUnverifiedDomainPolicyOptKey,
+	"EMAIL_MAX_RECIPIENTS":           internalConfig.EmailsMaxRecipientsOptKey,
+	"EMAIL_RECIPIENT_MODE":           internalConfig.EmailsRecipientModeOptKey,
+	"EMAIL_ATTACHMENTS_MAX_SIZE":     internalConfig.EmailsAttachmentsMaxSizeOptKey,
+	"EMAIL_ATTACHMENT_TYPES":         internalConfig.EmailsAttachmentTypesOptKey,
+	"EMAIL_ASSETS_BASE_URL":          internalConfig.EmailsAssetsBaseUrlOptKey,
+	"EMAIL_ASSETS_MAX_SIZE":          internalConfig.EmailsAssetsMaxSizeOptKey,
+	"EMAIL_UNSUBSCRIBE_BASE_URL":     internalConfig.EmailsUnsubscribeBaseUrlOptKey,
+	"EMAIL_UNSUBSCRIBE_SECRET":       internalConfig.EmailsUnsubscribeSecretOptKey,
+	"EMAIL_WEBHOOK_SECRET":           internalConfig.EmailsWebhookSecretOptKey,
+	"EMAIL_RECONCILE_INTERVAL":       internalConfig.EmailsReconcileIntervalOptKey,
+	"EMAIL_RECONCILE_WINDOW":         internalConfig.EmailsReconcileWindowOptKey,
+	"EMAIL_RECONCILE_BATCH_SIZE":     internalConfig.EmailsReconcileBatchSizeOptKey,
+	"EMAIL_RECONCILE_RATE":           internalConfig.EmailsReconcileRateOptKey,
+	"EMAIL_TRACKING_BASE_URL":        internalConfig.EmailsTrackingBaseUrlOptKey,
+	"EMAIL_TRACKING_SECRET":          internalConfig.EmailsTrackingSecretOptKey,
+	"BLOB_STORAGE":                   internalConfig.StorageBlobsBackendOptKey,
+	"BLOB_STORAGE_DIR":               internalConfig.StorageBlobsDirOptKey,
+	"BLOB_STORAGE_S3_ENDPOINT":       internalConfig.StorageBlobsS3EndpointOptKey,
+	"BLOB_STORAGE_S3_REGION":         internalConfig.StorageBlobsS3RegionOptKey,
+	"BLOB_STORAGE_S3_BUCKET":         internalConfig.StorageBlobsS3BucketOptKey,
+	"BLOB_STORAGE_S3_ACCESS_KEY":     internalConfig.StorageBlobsS3AccessKeyOptKey,
+	"BLOB_STORAGE_S3_SECRET_KEY":     internalConfig.StorageBlobsS3SecretKeyOptKey,
 }
@@ -9,6 +9,9 @@ package main
 // @name Authorization
 
 import (
+	"context"
+	"time"
+
 	// Framework
 	//
 	// Core of the Flash Framework. Contains the fundamental components of
@@ -37,6 +40,9 @@ import (
 	//// Handlers
 	httpEmailsHandlerAdapterPort "github.com/flash-go/notifications-service/internal/port/adapter/handler/emails/http"
 
+	//// Storage
+	blobsStorageAdapterPort "github.com/flash-go/notifications-service/internal/port/adapter/storage/blobs"
+
 	// Implementations
 
 	//// Handlers
@@ -45,6 +51,13 @@ import (
 	//// Repository
 	emailsRepositoryAdapterImpl "github.com/flash-go/notifications-service/internal/adapter/repository/emails"
 
+	//// Renderers
+	pdfRendererAdapterImpl "github.com/flash-go/notifications-service/internal/adapter/renderer/pdf"
+
+	//// Storage
+	fsBlobsStorageAdapterImpl "github.com/flash-go/notifications-service/internal/adapter/storage/blobs/fs"
+	s3BlobsStorageAdapterImpl "github.com/flash-go/notifications-service/internal/adapter/storage/blobs/s3"
+
 	//// Services
 	emailsServiceImpl "github.com/flash-go/notifications-service/internal/service/emails"
 
@@ -56,6 +69,14 @@ import (
 	_ "github.com/joho/godotenv/autoload"
 )
 
+const (
+	trashPurgeInterval   = time.Hour
+	domainVerifyInterval = time.Hour
+
+	// Request body size limit without attachments
+	defaultMaxRequestBodySize = 4 << 20
+)
+
 func main() {
 	// Create state service
 	stateService := state.NewWithSecureAuth(
@@ -120,6 +141,15 @@ func main() {
 	// Use Swagger
 	httpServer.UseSwagger()
 
+	// Get attachments and assets max size
+	attachmentsMaxSize := cfg.GetInt(internalConfig.EmailsAttachmentsMaxSizeOptKey)
+	assetsMaxSize := cfg.GetInt(internalConfig.EmailsAssetsMaxSizeOptKey)
+
+	// Allow request bodies with base64 encoded attachments and assets
+	httpServer.SetServerMaxRequestBodySize(
+		max(attachmentsMaxSize, assetsMaxSize)/3*4 + defaultMaxRequestBodySize,
+	)
+
 	// Set error response status map
 	httpServer.SetErrorResponseStatusMap(
 		&server.ErrorResponseStatusMap{
@@ -135,21 +165,79 @@ func main() {
 		&emailsRepositoryAdapterImpl.Config{
 			PostgresClient: postgresClient,
 			HttpClient:     httpClient,
+			Provider:       cfg.Get(internalConfig.ProvidersEmailProviderOptKey),
 			SmtpBzApiKey:   cfg.Get(internalConfig.ProvidersEmailSmtpBzApiKeyOptKey),
+			SmtpBzApiUrl:   cfg.Get(internalConfig.ProvidersEmailSmtpBzApiUrlOptKey),
+			SmtpAddr:       cfg.Get(internalConfig.ProvidersEmailSmtpAddrOptKey),
+			SmtpUsername:   cfg.Get(internalConfig.ProvidersEmailSmtpUsernameOptKey),
+			SmtpPassword:   cfg.Get(internalConfig.ProvidersEmailSmtpPasswordOptKey),
+			DnsResolver:    cfg.Get(internalConfig.EmailsDnsResolverOptKey),
 		},
 	)
 
+	// Create blob storage
+	var blobStorage blobsStorageAdapterPort.Interface
+	switch cfg.Get(internalConfig.StorageBlobsBackendOptKey) {
+	case "s3":
+		blobStorage = s3BlobsStorageAdapterImpl.New(
+			&s3BlobsStorageAdapterImpl.Config{
+				HttpClient: httpClient,
+				Endpoint:   cfg.Get(internalConfig.StorageBlobsS3EndpointOptKey),
+				Region:     cfg.Get(internalConfig.StorageBlobsS3RegionOptKey),
+				Bucket:     cfg.Get(internalConfig.StorageBlobsS3BucketOptKey),
+				AccessKey:  cfg.Get(internalConfig.StorageBlobsS3AccessKeyOptKey),
+				SecretKey:  cfg.Get(internalConfig.StorageBlobsS3SecretKeyOptKey),
+			},
+		)
+	default:
+		blobStorage = fsBlobsStorageAdapterImpl.New(
+			&fsBlobsStorageAdapterImpl.Config{
+				Dir: cfg.Get(internalConfig.StorageBlobsDirOptKey),
+			},
+		)
+	}
+
 	// Create services
 	emailsService := emailsServiceImpl.New(
 		&emailsServiceImpl.Config{
-			EmailsRepository: emailsRepository,
+			EmailsRepository:       emailsRepository,
+			BlobStorage:            blobStorage,
+			PdfRenderer:            pdfRendererAdapterImpl.New(),
+			TrashRetention:         time.Duration(cfg.GetInt(internalConfig.EmailsTrashRetentionDaysOptKey)) * 24 * time.Hour,
+			DkimSelector:           cfg.Get(internalConfig.EmailsDkimSelectorOptKey),
+			SpfInclude:             cfg.Get(internalConfig.EmailsSpfIncludeOptKey),
+			UnverifiedDomainPolicy: cfg.Get(internalConfig.EmailsUnverifiedDomainPolicyOptKey),
+			MaxRecipients:          cfg.GetInt(internalConfig.EmailsMaxRecipientsOptKey),
+			RecipientMode:          cfg.Get(internalConfig.EmailsRecipientModeOptKey),
+			AttachmentsMaxSize:     attachmentsMaxSize,
+			AttachmentTypes:        cfg.Get(internalConfig.EmailsAttachmentTypesOptKey),
+			AssetsBaseUrl:          cfg.Get(internalConfig.EmailsAssetsBaseUrlOptKey),
+			AssetsMaxSize:          assetsMaxSize,
+			UnsubscribeBaseUrl:     cfg.Get(internalConfig.EmailsUnsubscribeBaseUrlOptKey),
+			UnsubscribeSecret:      cfg.Get(internalConfig.EmailsUnsubscribeSecretOptKey),
+			WebhookSecret:          cfg.Get(internalConfig.EmailsWebhookSecretOptKey),
+			TrackingBaseUrl:        cfg.Get(internalConfig.EmailsTrackingBaseUrlOptKey),
+			TrackingSecret:         cfg.Get(internalConfig.EmailsTrackingSecretOptKey),
+			ReconcileWindow:        time.Duration(cfg.GetInt(internalConfig.EmailsReconcileWindowOptKey)) * time.Hour,
+			ReconcileBatchSize:     cfg.GetInt(internalConfig.EmailsReconcileBatchSizeOptKey),
+			ReconcileRate:          cfg.GetInt(internalConfig.EmailsReconcileRateOptKey),
+			Logger:                 loggerService,
 		},
 	)
 
+	// Warn that emails with a category cannot be sent with unsubscribe headers through smtp.bz
+	if cfg.Get(internalConfig.ProvidersEmailProviderOptKey) != emailsRepositoryAdapterImpl.ProviderSmtp && cfg.Get(internalConfig.EmailsUnsubscribeBaseUrlOptKey) != "" && cfg.Get(internalConfig.EmailsUnsubscribeSecretOptKey) != "" {
+		loggerService.Log().Warn().Msg("smtp.bz provider does not support List-Unsubscribe headers, emails with a category will fail with headers_not_supported")
+	}
+
+	// Get system role
+	systemRole := cfg.Get(internalConfig.UsersSystemRoleOptKey)
+
 	// Create handlers
 	emailsHandler := httpEmailsHandlerAdapterImpl.New(
 		&httpEmailsHandlerAdapterImpl.Config{
 			EmailsService: emailsService,
+			SystemRole:    systemRole,
 		},
 	)
 
@@ -168,6 +256,241 @@ func main() {
 	httpServer.
 		// Emails
 
+		// Create email sender (admin)
+		AddRoute(
+			http.MethodPost,
+			"/admin/notifications/emails/senders",
+			emailsHandler.AdminCreateSender,
+			middleware.ParseJsonBody[*httpEmailsHandlerAdapterPort.CreateSenderData](),
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+		// Filter email senders (admin)
+		AddRoute(
+			http.MethodPost,
+			"/admin/notifications/emails/senders/filter",
+			emailsHandler.AdminFilterSenders,
+			middleware.ParseJsonBody[*httpEmailsHandlerAdapterPort.FilterSendersData](),
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+		// Delete email sender (admin)
+		AddRoute(
+			http.MethodDelete,
+			"/admin/notifications/emails/senders/{id}",
+			emailsHandler.AdminDeleteSender,
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+		// Update email sender (admin)
+		AddRoute(
+			http.MethodPatch,
+			"/admin/notifications/emails/senders/{id}",
+			emailsHandler.AdminUpdateSender,
+			middleware.ParseJsonBody[*httpEmailsHandlerAdapterPort.UpdateSenderData](),
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+
+		// Create sending domain (admin)
+		AddRoute(
+			http.MethodPost,
+			"/admin/notifications/emails/domains",
+			emailsHandler.AdminCreateDomain,
+			middleware.ParseJsonBody[*httpEmailsHandlerAdapterPort.CreateDomainData](),
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+		// Filter sending domains (admin)
+		AddRoute(
+			http.MethodPost,
+			"/admin/notifications/emails/domains/filter",
+			emailsHandler.AdminFilterDomains,
+			middleware.ParseJsonBody[*httpEmailsHandlerAdapterPort.FilterDomainsData](),
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+		// Delete sending domain (admin)
+		AddRoute(
+			http.MethodDelete,
+			"/admin/notifications/emails/domains/{id}",
+			emailsHandler.AdminDeleteDomain,
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+		// Verify sending domain (admin)
+		AddRoute(
+			http.MethodPost,
+			"/admin/notifications/emails/domains/{id}/verify",
+			emailsHandler.AdminVerifyDomain,
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+		// Create DKIM key of sending domain (admin)
+		AddRoute(
+			http.MethodPost,
+			"/admin/notifications/emails/domains/{id}/keys",
+			emailsHandler.AdminCreateDomainKey,
+			middleware.ParseJsonBody[*httpEmailsHandlerAdapterPort.CreateDomainKeyData](),
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+		// Activate DKIM key of sending domain (admin)
+		AddRoute(
+			http.MethodPost,
+			"/admin/notifications/emails/domains/{id}/keys/{key_id}/activate",
+			emailsHandler.AdminActivateDomainKey,
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+		// Delete DKIM key of sending domain (admin)
+		AddRoute(
+			http.MethodDelete,
+			"/admin/notifications/emails/domains/{id}/keys/{key_id}",
+			emailsHandler.AdminDeleteDomainKey,
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+
+		// Upload file (admin)
+		AddRoute(
+			http.MethodPost,
+			"/admin/notifications/emails/files",
+			emailsHandler.AdminCreateFile,
+			middleware.ParseJsonBody[*httpEmailsHandlerAdapterPort.CreateFileData](),
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+		// Filter files (admin)
+		AddRoute(
+			http.MethodPost,
+			"/admin/notifications/emails/files/filter",
+			emailsHandler.AdminFilterFiles,
+			middleware.ParseJsonBody[*httpEmailsHandlerAdapterPort.FilterFilesData](),
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+		// Delete file (admin)
+		AddRoute(
+			http.MethodDelete,
+			"/admin/notifications/emails/files/{id}",
+			emailsHandler.AdminDeleteFile,
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+
+		// Upload asset (admin)
+		AddRoute(
+			http.MethodPost,
+			"/admin/notifications/emails/assets",
+			emailsHandler.AdminCreateAsset,
+			middleware.ParseJsonBody[*httpEmailsHandlerAdapterPort.CreateAssetData](),
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+		// Filter assets (admin)
+		AddRoute(
+			http.MethodPost,
+			"/admin/notifications/emails/assets/filter",
+			emailsHandler.AdminFilterAssets,
+			middleware.ParseJsonBody[*httpEmailsHandlerAdapterPort.FilterAssetsData](),
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+		// Delete asset (admin)
+		AddRoute(
+			http.MethodDelete,
+			"/admin/notifications/emails/assets/{id}",
+			emailsHandler.AdminDeleteAsset,
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+		// Get asset
+		AddRoute(
+			http.MethodGet,
+			"/notifications/emails/assets/{name}",
+			emailsHandler.GetAsset,
+		).
+		// Get unsubscribe page
+		AddRoute(
+			http.MethodGet,
+			"/notifications/emails/unsubscribe/{token}",
+			emailsHandler.GetUnsubscribe,
+		).
+		// Unsubscribe
+		AddRoute(
+			http.MethodPost,
+			"/notifications/emails/unsubscribe/{token}",
+			emailsHandler.Unsubscribe,
+		).
+
+		// Create suppression (admin)
+		AddRoute(
+			http.MethodPost,
+			"/admin/notifications/emails/suppressions",
+			emailsHandler.AdminCreateSuppression,
+			middleware.ParseJsonBody[*httpEmailsHandlerAdapterPort.CreateSuppressionData](),
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+		// Filter suppressions (admin)
+		AddRoute(
+			http.MethodPost,
+			"/admin/notifications/emails/suppressions/filter",
+			emailsHandler.AdminFilterSuppressions,
+			middleware.ParseJsonBody[*httpEmailsHandlerAdapterPort.FilterSuppressionsData](),
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+		// Import suppressions (admin)
+		AddRoute(
+			http.MethodPost,
+			"/admin/notifications/emails/suppressions/import",
+			emailsHandler.AdminImportSuppressions,
+			middleware.ParseJsonBody[*httpEmailsHandlerAdapterPort.ImportSuppressionsData](),
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+		// Delete suppression (admin)
+		AddRoute(
+			http.MethodDelete,
+			"/admin/notifications/emails/suppressions/{id}",
+			emailsHandler.AdminDeleteSuppression,
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+		// Update suppression (admin)
+		AddRoute(
+			http.MethodPatch,
+			"/admin/notifications/emails/suppressions/{id}",
+			emailsHandler.AdminUpdateSuppression,
+			middleware.ParseJsonBody[*httpEmailsHandlerAdapterPort.UpdateSuppressionData](),
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+
 		// Create email folder (admin)
 		AddRoute(
 			http.MethodPost,
@@ -175,7 +498,7 @@ func main() {
 			emailsHandler.AdminCreateFolder,
 			middleware.ParseJsonBody[*httpEmailsHandlerAdapterPort.CreateFolderData](),
 			usersMiddleware.Auth(
-				users.WithAuthRolesOption(adminRole),
+				users.WithAuthRolesOption(adminRole, systemRole),
 			),
 		).
 		// Filter email folders (admin)
@@ -185,7 +508,7 @@ func main() {
 			emailsHandler.AdminFilterFolders,
 			middleware.ParseJsonBody[*httpEmailsHandlerAdapterPort.FilterFoldersData](),
 			usersMiddleware.Auth(
-				users.WithAuthRolesOption(adminRole),
+				users.WithAuthRolesOption(adminRole, systemRole),
 			),
 		).
 		// Delete email folder (admin)
@@ -194,7 +517,7 @@ func main() {
 			"/admin/notifications/emails/folders/{id}",
 			emailsHandler.AdminDeleteFolder,
 			usersMiddleware.Auth(
-				users.WithAuthRolesOption(adminRole),
+				users.WithAuthRolesOption(adminRole, systemRole),
 			),
 		).
 		// Update email folder (admin)
@@ -204,7 +527,37 @@ func main() {
 			emailsHandler.AdminUpdateFolder,
 			middleware.ParseJsonBody[*httpEmailsHandlerAdapterPort.UpdateFolderData](),
 			usersMiddleware.Auth(
-				users.WithAuthRolesOption(adminRole),
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+		// Get email folders tree (admin)
+		AddRoute(
+			http.MethodGet,
+			"/admin/notifications/emails/folders/tree",
+			emailsHandler.AdminGetFolderTree,
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+		// Move email folder (admin)
+		AddRoute(
+			http.MethodPost,
+			"/admin/notifications/emails/folders/{id}/move",
+			emailsHandler.AdminMoveFolder,
+			middleware.ParseJsonBody[*httpEmailsHandlerAdapterPort.MoveFolderData](),
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+
+		// Clone email folder (admin)
+		AddRoute(
+			http.MethodPost,
+			"/admin/notifications/emails/folders/{id}/clone",
+			emailsHandler.AdminCloneFolder,
+			middleware.ParseJsonBody[*httpEmailsHandlerAdapterPort.CloneFolderData](),
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
 			),
 		).
 
@@ -215,7 +568,7 @@ func main() {
 			emailsHandler.AdminCreateEmail,
 			middleware.ParseJsonBody[*httpEmailsHandlerAdapterPort.CreateEmailData](),
 			usersMiddleware.Auth(
-				users.WithAuthRolesOption(adminRole),
+				users.WithAuthRolesOption(adminRole, systemRole),
 			),
 		).
 		// Filter emails (admin)
@@ -225,7 +578,17 @@ func main() {
 			emailsHandler.AdminFilterEmails,
 			middleware.ParseJsonBody[*httpEmailsHandlerAdapterPort.FilterEmailsData](),
 			usersMiddleware.Auth(
-				users.WithAuthRolesOption(adminRole),
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+		// Search emails (admin)
+		AddRoute(
+			http.MethodPost,
+			"/admin/notifications/emails/search",
+			emailsHandler.AdminSearchEmails,
+			middleware.ParseJsonBody[*httpEmailsHandlerAdapterPort.SearchEmailsData](),
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
 			),
 		).
 		// Delete email (admin)
@@ -234,7 +597,7 @@ func main() {
 			"/admin/notifications/emails/{id}",
 			emailsHandler.AdminDeleteEmail,
 			usersMiddleware.Auth(
-				users.WithAuthRolesOption(adminRole),
+				users.WithAuthRolesOption(adminRole, systemRole),
 			),
 		).
 		// Update email (admin)
@@ -244,7 +607,27 @@ func main() {
 			emailsHandler.AdminUpdateEmail,
 			middleware.ParseJsonBody[*httpEmailsHandlerAdapterPort.UpdateEmailData](),
 			usersMiddleware.Auth(
-				users.WithAuthRolesOption(adminRole),
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+		// Move emails (admin)
+		AddRoute(
+			http.MethodPost,
+			"/admin/notifications/emails/move",
+			emailsHandler.AdminMoveEmails,
+			middleware.ParseJsonBody[*httpEmailsHandlerAdapterPort.MoveEmailsData](),
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+		// Clone email (admin)
+		AddRoute(
+			http.MethodPost,
+			"/admin/notifications/emails/{id}/clone",
+			emailsHandler.AdminCloneEmail,
+			middleware.ParseJsonBody[*httpEmailsHandlerAdapterPort.CloneEmailData](),
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
 			),
 		).
 
@@ -255,7 +638,7 @@ func main() {
 			emailsHandler.SendCustom,
 			middleware.ParseJsonBody[*httpEmailsHandlerAdapterPort.SendCustomData](),
 			usersMiddleware.Auth(
-				users.WithAuthRolesOption(adminRole),
+				users.WithAuthRolesOption(adminRole, systemRole),
 			),
 		).
 		// Send email
@@ -265,6 +648,16 @@ func main() {
 			emailsHandler.Send,
 			middleware.ParseJsonBody[*httpEmailsHandlerAdapterPort.SendData](),
 		).
+		// Preview email (admin)
+		AddRoute(
+			http.MethodPost,
+			"/admin/notifications/emails/preview",
+			emailsHandler.AdminPreviewEmail,
+			middleware.ParseJsonBody[*httpEmailsHandlerAdapterPort.PreviewEmailData](),
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
 		// Filter email logs (admin)
 		AddRoute(
 			http.MethodPost,
@@ -272,7 +665,142 @@ func main() {
 			emailsHandler.AdminFilterEmailLogs,
 			middleware.ParseJsonBody[*httpEmailsHandlerAdapterPort.FilterEmailLogsData](),
 			usersMiddleware.Auth(
-				users.WithAuthRolesOption(adminRole),
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+
+		// Delivery status webhook of smtp.bz
+		AddRoute(
+			http.MethodPost,
+			"/notifications/emails/webhooks/smtp-bz/{token}",
+			emailsHandler.SmtpBzWebhook,
+		).
+
+		// Open tracking pixel
+		AddRoute(
+			http.MethodGet,
+			"/notifications/emails/opens/{token}",
+			emailsHandler.TrackOpen,
+		).
+		// Click redirect
+		AddRoute(
+			http.MethodGet,
+			"/notifications/emails/clicks/{token}",
+			emailsHandler.TrackClick,
+		).
+		// Get email clicks report (admin)
+		AddRoute(
+			http.MethodGet,
+			"/admin/notifications/emails/{id}/clicks/report",
+			emailsHandler.AdminGetClickReport,
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+
+		// Create email variant (admin)
+		AddRoute(
+			http.MethodPost,
+			"/admin/notifications/emails/variants",
+			emailsHandler.AdminCreateVariant,
+			middleware.ParseJsonBody[*httpEmailsHandlerAdapterPort.CreateVariantData](),
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+		// Filter email variants (admin)
+		AddRoute(
+			http.MethodPost,
+			"/admin/notifications/emails/variants/filter",
+			emailsHandler.AdminFilterVariants,
+			middleware.ParseJsonBody[*httpEmailsHandlerAdapterPort.FilterVariantsData](),
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+		// Delete email variant (admin)
+		AddRoute(
+			http.MethodDelete,
+			"/admin/notifications/emails/variants/{id}",
+			emailsHandler.AdminDeleteVariant,
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+		// Update email variant (admin)
+		AddRoute(
+			http.MethodPatch,
+			"/admin/notifications/emails/variants/{id}",
+			emailsHandler.AdminUpdateVariant,
+			middleware.ParseJsonBody[*httpEmailsHandlerAdapterPort.UpdateVariantData](),
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+		// Get email variants report (admin)
+		AddRoute(
+			http.MethodGet,
+			"/admin/notifications/emails/{id}/variants/report",
+			emailsHandler.AdminGetVariantReport,
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+		// End email experiment (admin)
+		AddRoute(
+			http.MethodPost,
+			"/admin/notifications/emails/{id}/variants/end",
+			emailsHandler.AdminEndExperiment,
+			middleware.ParseJsonBody[*httpEmailsHandlerAdapterPort.EndExperimentData](),
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+
+		// Export email templates bundle (admin)
+		AddRoute(
+			http.MethodGet,
+			"/admin/notifications/emails/bundles/export",
+			emailsHandler.AdminExportBundle,
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+		// Import email templates bundle (admin)
+		AddRoute(
+			http.MethodPost,
+			"/admin/notifications/emails/bundles/import",
+			emailsHandler.AdminImportBundle,
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+
+		// Filter trash (admin)
+		AddRoute(
+			http.MethodGet,
+			"/admin/notifications/emails/trash",
+			emailsHandler.AdminFilterTrash,
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+		// Restore email folder (admin)
+		AddRoute(
+			http.MethodPost,
+			"/admin/notifications/emails/folders/{id}/restore",
+			emailsHandler.AdminRestoreFolder,
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
+			),
+		).
+		// Restore email (admin)
+		AddRoute(
+			http.MethodPost,
+			"/admin/notifications/emails/{id}/restore",
+			emailsHandler.AdminRestoreEmail,
+			usersMiddleware.Auth(
+				users.WithAuthRolesOption(adminRole, systemRole),
 			),
 		)
 
@@ -285,6 +813,35 @@ func main() {
 		loggerService.Log().Err(err).Send()
 	}
 
+	// Purge expired trash in background
+	go func() {
+		for ; ; time.Sleep(trashPurgeInterval) {
+			if err := emailsService.PurgeTrash(context.Background()); err != nil {
+				loggerService.Log().Err(err).Send()
+			}
+		}
+	}()
+
+	// Verify sending domains in background
+	go func() {
+		for ; ; time.Sleep(domainVerifyInterval) {
+			if err := emailsService.VerifyDomains(context.Background()); err != nil {
+				loggerService.Log().Err(err).Send()
+			}
+		}
+	}()
+
+	// Poll provider for delivery status in background
+	if interval := cfg.GetInt(internalConfig.EmailsReconcileIntervalOptKey); interval > 0 {
+		go func() {
+			for ; ; time.Sleep(time.Duration(interval) * time.Minute) {
+				if err := emailsService.ReconcileDeliveries(context.Background()); err != nil {
+					loggerService.Log().Err(err).Send()
+				}
+			}
+		}()
+	}
+
 	// Listen http server
 	if err := <-httpServer.Listen(
 		config.GetEnvStr("SERVER_HOST"),
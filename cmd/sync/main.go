@@ -0,0 +1,168 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	// SDK
+	//
+	// A high-level software development toolkit based on the Flash Framework
+	// for building highly efficient and fault-tolerant applications.
+
+	"github.com/flash-go/sdk/config"
+	"github.com/flash-go/sdk/infra"
+	"github.com/flash-go/sdk/state"
+
+	// Ports
+
+	//// Services
+	emailsServicePort "github.com/flash-go/notifications-service/internal/port/service/emails"
+
+	// Implementations
+
+	//// Repository
+	emailsRepositoryAdapterImpl "github.com/flash-go/notifications-service/internal/adapter/repository/emails"
+
+	//// Services
+	emailsServiceImpl "github.com/flash-go/notifications-service/internal/service/emails"
+
+	// Other
+	_ "github.com/joho/godotenv/autoload"
+)
+
+const usage = `Usage:
+  sync plan [-parent ID] [-prune] DIR
+  sync apply [-parent ID] [-prune] DIR`
+
+func main() {
+	if len(os.Args) < 2 || (os.Args[1] != "plan" && os.Args[1] != "apply") {
+		log.Fatal(usage)
+	}
+	apply := os.Args[1] == "apply"
+
+	// Parse flags
+	flags := flag.NewFlagSet(os.Args[1], flag.ExitOnError)
+	parent := flags.Uint("parent", 0, "target parent folder id (root if not set)")
+	prune := flags.Bool("prune", false, "delete folders, emails and variants missing from the directory")
+	flags.Parse(os.Args[2:])
+	if flags.NArg() != 1 {
+		log.Fatal(usage)
+	}
+
+	// Read templates directory
+	bundle, err := readTemplates(flags.Arg(0))
+	if err != nil {
+		log.Fatalf("failed to read templates: %v", err)
+	}
+	data, err := json.Marshal(bundle)
+	if err != nil {
+		log.Fatalf("failed to encode templates: %v", err)
+	}
+
+	// Create state service
+	stateService := state.NewWithSecureAuth(
+		&state.SecureAuthConfig{
+			Address:            config.GetEnvStr("CONSUL_ADDR"),
+			CAPem:              config.GetEnvBase64("CONSUL_CA_CRT"),
+			CertPEM:            config.GetEnvBase64("CONSUL_CLIENT_CRT"),
+			KeyPEM:             config.GetEnvBase64("CONSUL_CLIENT_KEY"),
+			InsecureSkipVerify: config.GetEnvBool("CONSUL_INSECURE_SKIP_VERIFY"),
+			Token:              config.GetEnvStr("CONSUL_TOKEN"),
+		},
+	)
+
+	// Create config
+	cfg := config.New(
+		stateService,
+		config.GetEnvStr("SERVICE_NAME"),
+	)
+
+	// Create postgres client without migrations
+	postgresClient := infra.NewPostgresClient(
+		&infra.PostgresClientConfig{
+			Cfg:        cfg,
+			Telemetry:  nil,
+			Migrations: nil,
+		},
+	)
+
+	// Create repository
+	emailsRepository := emailsRepositoryAdapterImpl.New(
+		&emailsRepositoryAdapterImpl.Config{
+			PostgresClient: postgresClient,
+		},
+	)
+
+	// Create services
+	emailsService := emailsServiceImpl.New(
+		&emailsServiceImpl.Config{
+			EmailsRepository: emailsRepository,
+		},
+	)
+
+	// Set target parent folder
+	var parentId *uint
+	if *parent > 0 {
+		parentId = parent
+	}
+
+	// Reconcile templates
+	actions, err := emailsService.ImportBundle(
+		// Command line operators may edit system templates
+		emailsServicePort.WithSystemAccess(context.Background()),
+		emailsServicePort.ImportBundleData{
+			ParentId: parentId,
+			Strategy: emailsServicePort.ImportStrategyOverwrite,
+			Prune:    *prune,
+			DryRun:   !apply,
+			Format:   emailsServicePort.BundleFormatJson,
+			Bundle:   data,
+		},
+	)
+	if err != nil {
+		log.Fatalf("failed to sync templates: %v", err)
+	}
+
+	printPlan(*actions, apply)
+}
+
+// Print plan in a terraform like format
+func printPlan(actions []emailsServicePort.ImportActionResult, applied bool) {
+	var add, change, destroy int
+	for _, action := range actions {
+		var symbol string
+		switch action.Action {
+		case "create":
+			symbol = "+"
+			add++
+		case "update":
+			symbol = "~"
+			change++
+		case "delete":
+			symbol = "-"
+			destroy++
+		default:
+			continue
+		}
+		line := fmt.Sprintf("  %s %s %s", symbol, action.Entity, action.Key)
+		if len(action.Changes) > 0 {
+			line += " (" + strings.Join(action.Changes, ", ") + ")"
+		}
+		fmt.Println(line)
+	}
+
+	if add+change+destroy == 0 {
+		fmt.Println("No changes. Templates are up to date.")
+		return
+	}
+	if applied {
+		fmt.Printf("\nApply complete! Resources: %d added, %d changed, %d destroyed.\n", add, change, destroy)
+		return
+	}
+	fmt.Printf("\nPlan: %d to add, %d to change, %d to destroy.\n", add, change, destroy)
+}
@@ -0,0 +1,289 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	emailsServicePort "github.com/flash-go/notifications-service/internal/port/service/emails"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// Folder manifest file name
+	folderManifest = "_folder.yaml"
+
+	// Front matter delimiter of html templates
+	frontMatterDelimiter = "---"
+)
+
+// Folder manifest
+type folderMeta struct {
+	Description string `yaml:"description"`
+	SystemFlag  bool   `yaml:"system_flag"`
+}
+
+// Html template front matter
+type emailMeta struct {
+	Key            string            `yaml:"key"`
+	FromEmail      string            `yaml:"from_email"`
+	FromName       string            `yaml:"from_name"`
+	Subject        string            `yaml:"subject"`
+	ReplyTo        *string           `yaml:"reply_to"`
+	Headers        map[string]string `yaml:"headers"`
+	PdfAttachments map[string]string `yaml:"pdf_attachments"`
+	Category       *string           `yaml:"category"`
+	TrackOpens     bool              `yaml:"track_opens"`
+	TrackClicks    bool              `yaml:"track_clicks"`
+	Description    string            `yaml:"description"`
+	SystemFlag     bool              `yaml:"system_flag"`
+	Variants       []variantMeta     `yaml:"variants"`
+}
+
+type variantMeta struct {
+	Name    string  `yaml:"name"`
+	Subject *string `yaml:"subject"`
+	Html    *string `yaml:"html"`
+	Text    *string `yaml:"text"`
+	Weight  uint    `yaml:"weight"`
+	Active  *bool   `yaml:"active"`
+}
+
+// Read templates directory into a bundle
+//
+// Every subdirectory is a folder, optionally described by a _folder.yaml manifest.
+// Every <name>.html file is an email template with a YAML front matter, the key
+// defaults to the file name. Optional <name>.txt and <name>.subject files set the
+// plain text part and the subject.
+func readTemplates(root string) (*emailsServicePort.Bundle, error) {
+	bundle := emailsServicePort.Bundle{
+		Version: emailsServicePort.BundleVersion,
+		Folders: []emailsServicePort.BundleFolder{},
+		Emails:  []emailsServicePort.BundleEmail{},
+	}
+	keys := make(map[string]string)
+
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// Skip hidden files and directories
+		if path != root && strings.HasPrefix(entry.Name(), ".") {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+
+		// Read folder
+		if entry.IsDir() {
+			if path == root {
+				return nil
+			}
+			folder, err := readFolder(path, rel)
+			if err != nil {
+				return err
+			}
+			bundle.Folders = append(bundle.Folders, *folder)
+			return nil
+		}
+
+		// Read email
+		switch filepath.Ext(path) {
+		case ".html":
+			email, err := readEmail(path, rel)
+			if err != nil {
+				return err
+			}
+			if other, ok := keys[email.Key]; ok {
+				return fmt.Errorf("%s: key %q already used by %s", rel, email.Key, other)
+			}
+			keys[email.Key] = rel
+			bundle.Emails = append(bundle.Emails, *email)
+		case ".txt", ".subject":
+			html := strings.TrimSuffix(path, filepath.Ext(path)) + ".html"
+			if _, err := os.Stat(html); err != nil {
+				return fmt.Errorf("%s: no matching html template", rel)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(bundle.Emails, func(i, j int) bool { return bundle.Emails[i].Key < bundle.Emails[j].Key })
+
+	return &bundle, nil
+}
+
+func readFolder(path, rel string) (*emailsServicePort.BundleFolder, error) {
+	folder := emailsServicePort.BundleFolder{
+		Path: strings.Split(filepath.ToSlash(rel), "/"),
+	}
+
+	// Read folder manifest
+	data, err := os.ReadFile(filepath.Join(path, folderManifest))
+	if os.IsNotExist(err) {
+		return &folder, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var meta folderMeta
+	if err := decodeYaml(data, &meta); err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Join(rel, folderManifest), err)
+	}
+	folder.Description = meta.Description
+	folder.SystemFlag = meta.SystemFlag
+
+	return &folder, nil
+}
+
+func readEmail(path, rel string) (*emailsServicePort.BundleEmail, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Split front matter and html
+	meta, html, err := splitFrontMatter(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", rel, err)
+	}
+	var email emailMeta
+	if err := decodeYaml(meta, &email); err != nil {
+		return nil, fmt.Errorf("%s: %w", rel, err)
+	}
+
+	base := strings.TrimSuffix(path, ".html")
+	name := strings.TrimSuffix(filepath.Base(path), ".html")
+	if email.Key == "" {
+		email.Key = name
+	}
+
+	// Read plain text part
+	text, err := readOptional(base + ".txt")
+	if err != nil {
+		return nil, err
+	}
+
+	// Read subject
+	subject, err := readOptional(base + ".subject")
+	if err != nil {
+		return nil, err
+	}
+	if subject = strings.TrimSpace(subject); subject != "" {
+		email.Subject = subject
+	}
+
+	if email.Subject == "" || strings.TrimSpace(html) == "" {
+		return nil, fmt.Errorf("%s: subject and html are required", rel)
+	}
+
+	// Set folder
+	var folder []string
+	if dir := filepath.Dir(rel); dir != "." {
+		folder = strings.Split(filepath.ToSlash(dir), "/")
+	}
+
+	// Map variants
+	variants := make([]emailsServicePort.BundleVariant, 0, len(email.Variants))
+	for _, variant := range email.Variants {
+		active := true
+		if variant.Active != nil {
+			active = *variant.Active
+		}
+		weight := variant.Weight
+		if weight == 0 {
+			weight = 1
+		}
+		variants = append(
+			variants,
+			emailsServicePort.BundleVariant{
+				Name:    variant.Name,
+				Subject: variant.Subject,
+				Html:    variant.Html,
+				Text:    variant.Text,
+				Weight:  weight,
+				Active:  active,
+			},
+		)
+	}
+
+	return &emailsServicePort.BundleEmail{
+		Key:            email.Key,
+		Folder:         folder,
+		FromEmail:      email.FromEmail,
+		FromName:       email.FromName,
+		Subject:        email.Subject,
+		Html:           html,
+		Text:           text,
+		ReplyTo:        email.ReplyTo,
+		Headers:        email.Headers,
+		PdfAttachments: email.PdfAttachments,
+		Category:       email.Category,
+		TrackOpens:     email.TrackOpens,
+		TrackClicks:    email.TrackClicks,
+		Description:    email.Description,
+		SystemFlag:     email.SystemFlag,
+		Variants:       variants,
+	}, nil
+}
+
+// Split YAML front matter enclosed in --- lines from the document body
+func splitFrontMatter(data []byte) ([]byte, string, error) {
+	content := strings.ReplaceAll(string(data), "\r\n", "\n")
+	if !strings.HasPrefix(content, frontMatterDelimiter+"\n") {
+		return nil, "", fmt.Errorf("front matter not found")
+	}
+	content = content[len(frontMatterDelimiter)+1:]
+
+	// Find closing delimiter
+	end := strings.Index(content, "\n"+frontMatterDelimiter+"\n")
+	if end < 0 {
+		if !strings.HasSuffix(content, "\n"+frontMatterDelimiter) {
+			return nil, "", fmt.Errorf("front matter is not closed")
+		}
+		end = len(content) - len(frontMatterDelimiter) - 1
+	}
+	meta := content[:end]
+	body := strings.TrimPrefix(content[end+1:], frontMatterDelimiter)
+
+	return []byte(meta), strings.TrimLeft(body, "\n"), nil
+}
+
+// Decode YAML rejecting unknown fields
+func decodeYaml(data []byte, v any) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
+
+// Read file content or empty string if the file does not exist
+func readOptional(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
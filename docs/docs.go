@@ -52,7 +52,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Possible error codes: bad_request, bad_request:invalid_folder_id, bad_request:invalid_from_email, bad_request:invalid_from_name, bad_request:invalid_subject, bad_request:invalid_html, bad_request:invalid_text",
+                        "description": "Possible error codes: bad_request, bad_request:invalid_folder_id, bad_request:invalid_key, bad_request:invalid_sender_id, bad_request:invalid_subject, bad_request:invalid_html, bad_request:invalid_reply_to, bad_request:invalid_category, bad_request:invalid_header, bad_request:header_reserved, bad_request:invalid_pdf_attachment, bad_request:folder_not_found, bad_request:sender_not_found, bad_request:email_exist, bad_request:system_access",
                         "schema": {
                             "type": "string"
                         }
@@ -60,13 +60,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/notifications/emails/filter": {
+        "/admin/notifications/emails/assets": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Stores a template image served publicly at the returned url. Html may also reference it as cid:name to embed it into the message at send time. The content is base64 encoded.",
                 "consumes": [
                     "application/json"
                 ],
@@ -77,30 +78,27 @@ const docTemplate = `{
                 "tags": [
                     "emails"
                 ],
-                "summary": "Filter emails (admin)",
+                "summary": "Upload asset (admin)",
                 "parameters": [
                     {
-                        "description": "Filter emails",
+                        "description": "Upload asset",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.FilterEmailsData"
+                            "$ref": "#/definitions/github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.CreateAssetData"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/port.EmailResponse"
-                            }
+                            "$ref": "#/definitions/port.AssetResponse"
                         }
                     },
                     "400": {
-                        "description": "Possible error codes: bad_request",
+                        "description": "Possible error codes: bad_request, bad_request:invalid_name, bad_request:invalid_content_type, bad_request:invalid_content, bad_request:asset_exist, bad_request:asset_too_large, bad_request:asset_type_not_allowed",
                         "schema": {
                             "type": "string"
                         }
@@ -108,7 +106,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/notifications/emails/folders": {
+        "/admin/notifications/emails/assets/filter": {
             "post": {
                 "security": [
                     {
@@ -125,27 +123,30 @@ const docTemplate = `{
                 "tags": [
                     "emails"
                 ],
-                "summary": "Create email folder (admin)",
+                "summary": "Filter assets (admin)",
                 "parameters": [
                     {
-                        "description": "Create email folder",
+                        "description": "Filter assets",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.CreateFolderData"
+                            "$ref": "#/definitions/github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.FilterAssetsData"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/port.FolderResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/port.AssetResponse"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Possible error codes: bad_request, bad_request:invalid_parent, bad_request:invalid_name, bad_request:folder_exist",
+                        "description": "Possible error codes: bad_request",
                         "schema": {
                             "type": "string"
                         }
@@ -153,47 +154,88 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/notifications/emails/folders/filter": {
-            "post": {
+        "/admin/notifications/emails/assets/{id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Templates still referencing the asset fail to send with asset_not_found.",
                 "produces": [
-                    "application/json",
                     "text/plain"
                 ],
                 "tags": [
                     "emails"
                 ],
-                "summary": "Filter email folders (admin)",
+                "summary": "Delete asset (admin)",
                 "parameters": [
                     {
-                        "description": "Filter email folders",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
+                        "type": "integer",
+                        "description": "Asset ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request, bad_request:asset_not_found",
                         "schema": {
-                            "$ref": "#/definitions/github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.FilterFoldersData"
+                            "type": "string"
                         }
                     }
+                }
+            }
+        },
+        "/admin/notifications/emails/bundles/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Exports a folder subtree (or the whole tree) with its emails and variants as a portable bundle.",
+                "produces": [
+                    "application/json",
+                    "application/zip",
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Export email templates bundle (admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Root folder ID",
+                        "name": "folder_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "zip"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Bundle format",
+                        "name": "format",
+                        "in": "query"
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/port.FolderResponse"
-                            }
+                            "$ref": "#/definitions/port.Bundle"
                         }
                     },
                     "400": {
-                        "description": "Possible error codes: bad_request",
+                        "description": "Possible error codes: bad_request, bad_request:invalid_folder_id, bad_request:invalid_format, bad_request:folder_not_found",
                         "schema": {
                             "type": "string"
                         }
@@ -201,47 +243,96 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/notifications/emails/folders/{id}": {
-            "delete": {
+        "/admin/notifications/emails/bundles/import": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Imports a bundle under the parent folder. Folders are matched by path and emails by key. With prune=true (overwrite strategy only) folders and emails under the parent that are missing from the bundle are deleted. By default only the plan is returned; set dry_run=false to apply it in a single transaction.",
+                "consumes": [
+                    "application/json",
+                    "application/zip"
+                ],
                 "produces": [
+                    "application/json",
                     "text/plain"
                 ],
                 "tags": [
                     "emails"
                 ],
-                "summary": "Delete email folder (admin)",
+                "summary": "Import email templates bundle (admin)",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Folder ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Target parent folder ID",
+                        "name": "parent_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "skip",
+                            "overwrite",
+                            "rename"
+                        ],
+                        "type": "string",
+                        "default": "skip",
+                        "description": "Conflict strategy",
+                        "name": "strategy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Delete entities missing from the bundle",
+                        "name": "prune",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Only return the plan",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Bundle",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/port.Bundle"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/port.ImportActionResponse"
+                            }
+                        }
                     },
                     "400": {
-                        "description": "Possible error codes: bad_request, bad_request:folder_not_found",
+                        "description": "Possible error codes: bad_request, bad_request:invalid_parent_id, bad_request:invalid_strategy, bad_request:invalid_prune, bad_request:invalid_format, bad_request:invalid_bundle, bad_request:folder_not_found, bad_request:system_entity, bad_request:system_access",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/admin/notifications/emails/domains": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Generates DKIM keys for the domain. The response lists the DNS records to publish before the domain can be verified.",
                 "consumes": [
                     "application/json"
                 ],
@@ -252,31 +343,27 @@ const docTemplate = `{
                 "tags": [
                     "emails"
                 ],
-                "summary": "Update email folder (admin)",
+                "summary": "Create sending domain (admin)",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Folder ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update email folder",
+                        "description": "Create sending domain",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/port._UpdateFolderData"
+                            "$ref": "#/definitions/github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.CreateDomainData"
                         }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/port.DomainResponse"
+                        }
                     },
                     "400": {
-                        "description": "Possible error codes: bad_request, bad_request:invalid_name, bad_request:folder_not_found",
+                        "description": "Possible error codes: bad_request, bad_request:invalid_name, bad_request:invalid_dkim_selector, bad_request:invalid_dkim_algorithm, bad_request:domain_exist",
                         "schema": {
                             "type": "string"
                         }
@@ -284,7 +371,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/notifications/emails/logs/filter": {
+        "/admin/notifications/emails/domains/filter": {
             "post": {
                 "security": [
                     {
@@ -301,15 +388,15 @@ const docTemplate = `{
                 "tags": [
                     "emails"
                 ],
-                "summary": "Filter email logs (admin)",
+                "summary": "Filter sending domains (admin)",
                 "parameters": [
                     {
-                        "description": "Filter email logs (admin)",
+                        "description": "Filter sending domains",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.FilterEmailLogsData"
+                            "$ref": "#/definitions/github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.FilterDomainsData"
                         }
                     }
                 ],
@@ -319,7 +406,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/port.EmailLogResponse"
+                                "$ref": "#/definitions/port.DomainResponse"
                             }
                         }
                     },
@@ -332,7 +419,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/notifications/emails/{id}": {
+        "/admin/notifications/emails/domains/{id}": {
             "delete": {
                 "security": [
                     {
@@ -345,11 +432,11 @@ const docTemplate = `{
                 "tags": [
                     "emails"
                 ],
-                "summary": "Delete email (admin)",
+                "summary": "Delete sending domain (admin)",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Email ID",
+                        "description": "Domain ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -360,19 +447,22 @@ const docTemplate = `{
                         "description": "No Content"
                     },
                     "400": {
-                        "description": "Possible error codes: bad_request, bad_request:email_not_found",
+                        "description": "Possible error codes: bad_request, bad_request:domain_not_found",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/admin/notifications/emails/domains/{id}/keys": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Generates an inactive key under a new selector to rotate the DKIM key. Publish its DNS record, then activate it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -383,31 +473,78 @@ const docTemplate = `{
                 "tags": [
                     "emails"
                 ],
-                "summary": "Update email (admin)",
+                "summary": "Create DKIM key of sending domain (admin)",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Email ID",
+                        "description": "Domain ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Update email",
+                        "description": "Create DKIM key",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/port._UpdateEmailData"
+                            "$ref": "#/definitions/github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.CreateDomainKeyData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/port.DomainResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request, bad_request:invalid_dkim_selector, bad_request:invalid_dkim_algorithm, bad_request:domain_not_found, bad_request:dkim_key_exist",
+                        "schema": {
+                            "type": "string"
                         }
                     }
+                }
+            }
+        },
+        "/admin/notifications/emails/domains/{id}/keys/{key_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The active key cannot be deleted.",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Delete DKIM key of sending domain (admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Domain ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Key ID",
+                        "name": "key_id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "204": {
                         "description": "No Content"
                     },
                     "400": {
-                        "description": "Possible error codes: bad_request, bad_request:invalid_folder_id, bad_request:invalid_from_email, bad_request:invalid_from_name, bad_request:invalid_subject, bad_request:invalid_html, bad_request:invalid_text, bad_request:email_not_found",
+                        "description": "Possible error codes: bad_request, bad_request:dkim_key_not_found, bad_request:dkim_key_active",
                         "schema": {
                             "type": "string"
                         }
@@ -415,11 +552,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/notifications/emails/send": {
+        "/admin/notifications/emails/domains/{id}/keys/{key_id}/activate": {
             "post": {
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Signs outgoing messages of the domain with the key. The DKIM record of the key must be published.",
                 "produces": [
                     "application/json",
                     "text/plain"
@@ -427,27 +567,73 @@ const docTemplate = `{
                 "tags": [
                     "emails"
                 ],
-                "summary": "Send email",
+                "summary": "Activate DKIM key of sending domain (admin)",
                 "parameters": [
                     {
-                        "description": "Send email",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
+                        "type": "integer",
+                        "description": "Domain ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Key ID",
+                        "name": "key_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.SendData"
+                            "$ref": "#/definitions/port.DomainResponse"
                         }
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request, bad_request:domain_not_found, bad_request:dkim_key_not_found, bad_request:dkim_key_not_published",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/notifications/emails/domains/{id}/verify": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Checks the SPF, DKIM and DMARC records of the domain now instead of waiting for the background check.",
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Verify sending domain (admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Domain ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/port.EmailLogResponse"
+                            "$ref": "#/definitions/port.DomainResponse"
                         }
                     },
                     "400": {
-                        "description": "Possible error codes: bad_request, bad_request:invalid_id, bad_request:invalid_to_email, bad_request:email_not_found",
+                        "description": "Possible error codes: bad_request, bad_request:domain_not_found",
                         "schema": {
                             "type": "string"
                         }
@@ -455,13 +641,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/notifications/emails/send/custom": {
+        "/admin/notifications/emails/files": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
+                "description": "Stores a file that sends may attach by its id. The content is base64 encoded.",
                 "consumes": [
                     "application/json"
                 ],
@@ -472,15 +659,15 @@ const docTemplate = `{
                 "tags": [
                     "emails"
                 ],
-                "summary": "Send custom email",
+                "summary": "Upload file (admin)",
                 "parameters": [
                     {
-                        "description": "Send custom email",
+                        "description": "Upload file",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.SendCustomData"
+                            "$ref": "#/definitions/github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.CreateFileData"
                         }
                     }
                 ],
@@ -488,36 +675,3367 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/port.EmailLogResponse"
+                            "$ref": "#/definitions/port.FileResponse"
                         }
                     },
                     "400": {
-                        "description": "Possible error codes: bad_request, bad_request:invalid_from_email, bad_request:invalid_from_name, bad_request:invalid_subject, bad_request:invalid_to_email, bad_request:invalid_html, bad_request:invalid_text",
+                        "description": "Possible error codes: bad_request, bad_request:invalid_name, bad_request:invalid_content_type, bad_request:invalid_content, bad_request:attachments_too_large, bad_request:attachment_type_not_allowed",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.CreateEmailData": {
+        },
+        "/admin/notifications/emails/files/filter": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Filter files (admin)",
+                "parameters": [
+                    {
+                        "description": "Filter files",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.FilterFilesData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/port.FileResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/notifications/emails/files/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Logs of emails sent with the file keep its metadata.",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Delete file (admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "File ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request, bad_request:file_not_found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/notifications/emails/filter": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Filter emails (admin)",
+                "parameters": [
+                    {
+                        "description": "Filter emails",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.FilterEmailsData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/port.EmailResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/notifications/emails/folders": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Create email folder (admin)",
+                "parameters": [
+                    {
+                        "description": "Create email folder",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.CreateFolderData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/port.FolderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request, bad_request:invalid_parent, bad_request:invalid_sender_id, bad_request:invalid_name, bad_request:folder_not_found, bad_request:sender_not_found, bad_request:folder_exist, bad_request:system_access",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/notifications/emails/folders/filter": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Filter email folders (admin)",
+                "parameters": [
+                    {
+                        "description": "Filter email folders",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.FilterFoldersData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/port.FolderResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/notifications/emails/folders/tree": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the folders tree or the subtree of the root folder. Each folder has its path, breadcrumbs from the top level folder, the number of its own emails and the number of emails in the whole subtree.",
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Get email folders tree (admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Subtree root folder ID",
+                        "name": "root_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/port.FolderTreeResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request, bad_request:invalid_root_id, bad_request:folder_not_found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/notifications/emails/folders/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves the folder with its subfolders and emails to the trash.",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Delete email folder (admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Folder ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request, bad_request:folder_not_found, bad_request:system_entity",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Update email folder (admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Folder ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update email folder",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/port._UpdateFolderData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request, bad_request:invalid_parent, bad_request:invalid_sender_id, bad_request:invalid_name, bad_request:folder_not_found, bad_request:sender_not_found, bad_request:folder_exist, bad_request:folder_cycle, bad_request:system_entity, bad_request:system_access",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/notifications/emails/folders/{id}/clone": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Copies the folder with its subfolders, emails and variants under the parent folder or to the top level if parent_id is null. Email keys of the copies get key_suffix (_copy by default), with a numeric suffix added if the key is taken. Copies are never system entities.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Clone email folder (admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Folder ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Clone email folder",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.CloneFolderData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/port.FolderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request, bad_request:invalid_parent, bad_request:invalid_name, bad_request:invalid_key_suffix, bad_request:folder_not_found, bad_request:folder_exist",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/notifications/emails/folders/{id}/move": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves the folder with its subtree under the parent folder or to the top level if parent_id is null.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Move email folder (admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Folder ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Move email folder",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.MoveFolderData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request, bad_request:invalid_parent, bad_request:folder_not_found, bad_request:folder_exist, bad_request:folder_cycle, bad_request:system_entity",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/notifications/emails/folders/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restores the folder from the trash together with the subfolders and emails deleted with it.",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Restore email folder (admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Folder ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request, bad_request:folder_not_found, bad_request:trash_expired, bad_request:parent_deleted, bad_request:folder_exist, bad_request:email_exist",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/notifications/emails/logs/filter": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Filter email logs (admin)",
+                "parameters": [
+                    {
+                        "description": "Filter email logs (admin)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.FilterEmailLogsData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/port.EmailLogResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request, bad_request:invalid_status",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/notifications/emails/move": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves emails to the folder or to the top level if folder_id is null.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Move emails (admin)",
+                "parameters": [
+                    {
+                        "description": "Move emails",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.MoveEmailsData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request, bad_request:invalid_id, bad_request:invalid_folder_id, bad_request:folder_not_found, bad_request:email_not_found, bad_request:system_entity",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/notifications/emails/preview": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Renders the email, or one of its variants, with vars as it would be sent, including generated pdf attachments. Nothing is sent or logged.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Preview email (admin)",
+                "parameters": [
+                    {
+                        "description": "Preview email",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.PreviewEmailData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/port.PreviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request, bad_request:invalid_id, bad_request:invalid_variant_id, bad_request:email_not_found, bad_request:variant_not_found, bad_request:sender_not_found, bad_request:sender_not_set, bad_request:invalid_reply_to, bad_request:invalid_header, bad_request:invalid_pdf_attachment, bad_request:asset_not_found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/notifications/emails/search": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Full-text search over subject, key, description, content and folder name, combined with the filter fields. Matches are wrapped in \u003cmark\u003e tags in highlight and snippet.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Search emails (admin)",
+                "parameters": [
+                    {
+                        "description": "Search emails",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.SearchEmailsData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/port.SearchEmailResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request, bad_request:invalid_query, bad_request:invalid_limit",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/notifications/emails/senders": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Approves a sender identity that emails, folders and custom sends may use.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Create email sender (admin)",
+                "parameters": [
+                    {
+                        "description": "Create email sender",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.CreateSenderData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/port.SenderResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request, bad_request:invalid_email, bad_request:invalid_name, bad_request:invalid_reply_to, bad_request:sender_exist",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/notifications/emails/senders/filter": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Filter email senders (admin)",
+                "parameters": [
+                    {
+                        "description": "Filter email senders",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.FilterSendersData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/port.SenderResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/notifications/emails/senders/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Only senders that no folder or email uses can be deleted.",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Delete email sender (admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Sender ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request, bad_request:sender_not_found, bad_request:sender_in_use",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Update email sender (admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Sender ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update email sender",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/port._UpdateSenderData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request, bad_request:invalid_email, bad_request:invalid_name, bad_request:invalid_reply_to, bad_request:invalid_description, bad_request:sender_not_found, bad_request:sender_exist",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/notifications/emails/suppressions": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Messages are not sent to a suppressed address until the suppression expires, they are logged with status suppressed instead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Create suppression (admin)",
+                "parameters": [
+                    {
+                        "description": "Create suppression",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.CreateSuppressionData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/port.SuppressionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request, bad_request:invalid_address, bad_request:invalid_reason, bad_request:suppression_exist",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/notifications/emails/suppressions/filter": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Filter suppressions (admin)",
+                "parameters": [
+                    {
+                        "description": "Filter suppressions",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.FilterSuppressionsData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/port.SuppressionResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/notifications/emails/suppressions/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates or overwrites suppressions of up to 10000 addresses at once, the last entry of a repeated address wins.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Import suppressions (admin)",
+                "parameters": [
+                    {
+                        "description": "Import suppressions",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.ImportSuppressionsData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/port.ImportSuppressionsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request, bad_request:invalid_suppressions, bad_request:invalid_address, bad_request:invalid_reason",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/notifications/emails/suppressions/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Delete suppression (admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Suppression ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request, bad_request:suppression_not_found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "A null expires makes the suppression permanent.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Update suppression (admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Suppression ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update suppression",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/port._UpdateSuppressionData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request, bad_request:invalid_reason, bad_request:suppression_not_found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/notifications/emails/trash": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns deleted folders and emails with the time they expire and are purged.",
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Filter trash (admin)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/port.TrashResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/notifications/emails/variants": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Create email variant (admin)",
+                "parameters": [
+                    {
+                        "description": "Create email variant",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.CreateVariantData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/port.VariantResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request, bad_request:invalid_email_id, bad_request:invalid_name, bad_request:invalid_subject, bad_request:invalid_html, bad_request:invalid_weight, bad_request:email_not_found, bad_request:system_access",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/notifications/emails/variants/filter": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Filter email variants (admin)",
+                "parameters": [
+                    {
+                        "description": "Filter email variants",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.FilterVariantsData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/port.VariantResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/notifications/emails/variants/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Delete email variant (admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Variant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request, bad_request:variant_not_found, bad_request:system_access",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Update email variant (admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Variant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update email variant",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/port._UpdateVariantData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request, bad_request:invalid_name, bad_request:invalid_subject, bad_request:invalid_html, bad_request:invalid_weight, bad_request:invalid_active, bad_request:variant_not_found, bad_request:system_access",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/notifications/emails/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves the email to the trash.",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Delete email (admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Email ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request, bad_request:email_not_found, bad_request:system_entity",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Update email (admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Email ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update email",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/port._UpdateEmailData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request, bad_request:invalid_folder_id, bad_request:invalid_key, bad_request:invalid_sender_id, bad_request:invalid_subject, bad_request:invalid_html, bad_request:invalid_text, bad_request:invalid_reply_to, bad_request:invalid_category, bad_request:invalid_track_opens, bad_request:invalid_track_clicks, bad_request:invalid_header, bad_request:header_reserved, bad_request:invalid_pdf_attachment, bad_request:folder_not_found, bad_request:sender_not_found, bad_request:email_exist, bad_request:email_not_found, bad_request:system_entity, bad_request:system_access",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/notifications/emails/{id}/clicks/report": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Clicks of tracked links of the email by url, unique clicks count email logs with at least one click of the url.",
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Get email clicks report (admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Email ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/port.ClickReportResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request, bad_request:email_not_found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/notifications/emails/{id}/clone": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Copies the email with its variants into the folder or to the top level if folder_id is null under a new key. The copy is never a system email.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Clone email (admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Email ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Clone email",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.CloneEmailData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/port.EmailResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request, bad_request:invalid_folder_id, bad_request:invalid_key, bad_request:folder_not_found, bad_request:email_not_found, bad_request:email_exist",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/notifications/emails/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Restore email (admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Email ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request, bad_request:email_not_found, bad_request:trash_expired, bad_request:parent_deleted, bad_request:email_exist",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/notifications/emails/{id}/variants/end": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deactivates all variants of the email. If a winner is set, its subject and body are promoted to the email.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "End email experiment (admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Email ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "End email experiment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.EndExperimentData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request, bad_request:invalid_winner_id, bad_request:email_not_found, bad_request:variant_not_found, bad_request:system_access",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/notifications/emails/{id}/variants/report": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Get email variants report (admin)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Email ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/port.VariantReportResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request, bad_request:email_not_found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/emails/assets/{name}": {
+            "get": {
+                "description": "Public route serving asset content for templates referencing it by url.",
+                "produces": [
+                    "image/png",
+                    "image/jpeg",
+                    "image/gif",
+                    "image/webp",
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Get asset",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Asset name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request:asset_not_found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/emails/clicks/{token}": {
+            "get": {
+                "description": "Public route of tracked links in html of emails with click tracking, records the click against the email log and redirects to the original url.",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Click redirect",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tracking token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Found"
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request:invalid_tracking_token",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/emails/opens/{token}": {
+            "get": {
+                "description": "Public route of the tracking pixel added to html of emails with open tracking, records the open against the email log. The pixel is served even if the open is not recorded, so that the message renders without broken images.",
+                "produces": [
+                    "image/gif"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Open tracking pixel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tracking token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/emails/send": {
+            "post": {
+                "description": "Recipients are addresses with optional display names (\"Name \u003caddr\u003e\"); one log is returned per message sent. An event adds a calendar invitation, reuse the returned event_uid to update or cancel it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Send email",
+                "parameters": [
+                    {
+                        "description": "Send email",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.SendData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/port.EmailLogResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request, bad_request:invalid_id, bad_request:invalid_to, bad_request:invalid_cc, bad_request:invalid_bcc, bad_request:invalid_attachments, bad_request:file_attachments_not_allowed, bad_request:invalid_event, bad_request:too_many_recipients, bad_request:email_not_found, bad_request:sender_not_found, bad_request:sender_not_set, bad_request:invalid_reply_to, bad_request:invalid_header, bad_request:domain_not_verified, bad_request:attachments_too_large, bad_request:attachment_type_not_allowed, bad_request:attachments_not_supported, bad_request:asset_not_found, bad_request:invalid_pdf_attachment, bad_request:calendar_not_supported, bad_request:headers_not_supported",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/emails/send/custom": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "The sender address, and the display name if set, must match an approved sender. Recipients are addresses with optional display names (\"Name \u003caddr\u003e\"); one log is returned per message sent.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Send custom email",
+                "parameters": [
+                    {
+                        "description": "Send custom email",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.SendCustomData"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/port.EmailLogResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request, bad_request:invalid_from_email, bad_request:invalid_reply_to, bad_request:invalid_header, bad_request:header_reserved, bad_request:invalid_subject, bad_request:invalid_to, bad_request:invalid_cc, bad_request:invalid_bcc, bad_request:invalid_html, bad_request:invalid_attachments, bad_request:too_many_recipients, bad_request:sender_not_approved, bad_request:domain_not_verified, bad_request:file_not_found, bad_request:attachments_too_large, bad_request:attachment_type_not_allowed, bad_request:attachments_not_supported, bad_request:asset_not_found, bad_request:headers_not_supported",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/emails/unsubscribe/{token}": {
+            "get": {
+                "description": "Public route of the List-Unsubscribe link opened in a browser, asks to confirm the opt-out without recording it.",
+                "produces": [
+                    "text/html",
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Get unsubscribe page",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unsubscribe token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Confirmation page",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request:invalid_unsubscribe_token",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Public one-click unsubscribe route (RFC 8058) of the List-Unsubscribe link, records the opt-out of the recipient from the email category.",
+                "produces": [
+                    "text/html",
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Unsubscribe",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Unsubscribe token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Result page",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request:invalid_unsubscribe_token",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/notifications/emails/webhooks/smtp-bz/{token}": {
+            "post": {
+                "description": "Records delivery events of sent messages reported by smtp.bz. The token is the webhook secret. Hard bounces and complaints add the recipient to the suppression list.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "emails"
+                ],
+                "summary": "Delivery status webhook of smtp.bz",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook secret",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Possible error codes: bad_request:invalid_webhook",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Possible error codes: unauthorized:invalid_webhook_token",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.AttachmentData": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "format": "base64"
+                },
+                "content_type": {
+                    "type": "string"
+                },
+                "file_id": {
+                    "type": "integer"
+                },
+                "filename": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.CloneEmailData": {
+            "type": "object",
+            "properties": {
+                "folder_id": {
+                    "type": "integer"
+                },
+                "key": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.CloneFolderData": {
+            "type": "object",
+            "properties": {
+                "key_suffix": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.CreateAssetData": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "format": "base64"
+                },
+                "content_type": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.CreateDomainData": {
+            "type": "object",
+            "properties": {
+                "dkim_algorithm": {
+                    "type": "string"
+                },
+                "dkim_selector": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.CreateDomainKeyData": {
+            "type": "object",
+            "properties": {
+                "algorithm": {
+                    "type": "string"
+                },
+                "selector": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.CreateEmailData": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "folder_id": {
+                    "type": "integer"
+                },
+                "headers": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "html": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "pdf_attachments": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "reply_to": {
+                    "type": "string"
+                },
+                "sender_id": {
+                    "type": "integer"
+                },
+                "subject": {
+                    "type": "string"
+                },
+                "system_flag": {
+                    "type": "boolean"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "track_clicks": {
+                    "type": "boolean"
+                },
+                "track_opens": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.CreateFileData": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "format": "base64"
+                },
+                "content_type": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.CreateFolderData": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "integer"
+                },
+                "sender_id": {
+                    "type": "integer"
+                },
+                "system_flag": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.CreateSenderData": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "reply_to": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.CreateSuppressionData": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "expires": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string",
+                    "enum": [
+                        "hard_bounce",
+                        "complaint",
+                        "unsubscribe",
+                        "manual"
+                    ]
+                }
+            }
+        },
+        "github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.CreateVariantData": {
+            "type": "object",
+            "properties": {
+                "email_id": {
+                    "type": "integer"
+                },
+                "html": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.EndExperimentData": {
+            "type": "object",
+            "properties": {
+                "winner_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.EventData": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "end": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "method": {
+                    "type": "string",
+                    "enum": [
+                        "REQUEST",
+                        "CANCEL"
+                    ]
+                },
+                "organizer": {
+                    "type": "string"
+                },
+                "start": {
+                    "type": "string"
+                },
+                "summary": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Europe/Berlin"
+                },
+                "uid": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.FilterAssetsData": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "name": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.FilterDomainsData": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "name": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "verified": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.FilterEmailLogsData": {
+            "type": "object",
+            "properties": {
+                "email_id": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "event_uid": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "from_email": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "from_name": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "message_id": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "recipient": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "status": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "variant_id": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.FilterEmailsData": {
+            "type": "object",
+            "properties": {
+                "folder_id": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "id": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "key": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "system_flag": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.FilterFilesData": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "name": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.FilterFoldersData": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "name": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "parent_id": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "system_flag": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.FilterSendersData": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "name": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.FilterSuppressionsData": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "address": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "reason": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "source": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.FilterVariantsData": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "email_id": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "id": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.ImportSuppressionsData": {
+            "type": "object",
+            "properties": {
+                "suppressions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.CreateSuppressionData"
+                    }
+                }
+            }
+        },
+        "github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.MoveEmailsData": {
+            "type": "object",
+            "properties": {
+                "folder_id": {
+                    "type": "integer"
+                },
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.MoveFolderData": {
+            "type": "object",
+            "properties": {
+                "parent_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.PreviewEmailData": {
+            "type": "object",
+            "properties": {
+                "email_id": {
+                    "type": "integer"
+                },
+                "variant_id": {
+                    "type": "integer"
+                },
+                "vars": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.SearchEmailsData": {
+            "type": "object",
+            "properties": {
+                "folder_id": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "id": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "key": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "system_flag": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.SendCustomData": {
+            "type": "object",
+            "properties": {
+                "attachments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.AttachmentData"
+                    }
+                },
+                "bcc": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "cc": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "from_email": {
+                    "type": "string"
+                },
+                "from_name": {
+                    "type": "string"
+                },
+                "headers": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "html": {
+                    "type": "string"
+                },
+                "reply_to": {
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.SendData": {
+            "type": "object",
+            "properties": {
+                "attachments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.AttachmentData"
+                    }
+                },
+                "bcc": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "cc": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "email_id": {
+                    "type": "integer"
+                },
+                "event": {
+                    "$ref": "#/definitions/github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.EventData"
+                },
+                "to": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "vars": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "port.AssetResponse": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string"
+                },
+                "created": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sha256": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "port.Bundle": {
+            "type": "object",
+            "properties": {
+                "emails": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/port.BundleEmail"
+                    }
+                },
+                "exported": {
+                    "type": "string"
+                },
+                "folders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/port.BundleFolder"
+                    }
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "port.BundleEmail": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "folder": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "from_email": {
+                    "type": "string"
+                },
+                "from_name": {
+                    "type": "string"
+                },
+                "headers": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "html": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "pdf_attachments": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "reply_to": {
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                },
+                "system_flag": {
+                    "type": "boolean"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "track_clicks": {
+                    "type": "boolean"
+                },
+                "track_opens": {
+                    "type": "boolean"
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/port.BundleVariant"
+                    }
+                }
+            }
+        },
+        "port.BundleFolder": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "system_flag": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "port.BundleVariant": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "html": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "integer"
+                }
+            }
+        },
+        "port.ClickReportResponse": {
+            "type": "object",
+            "properties": {
+                "clicks": {
+                    "type": "integer"
+                },
+                "unique_clicks": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "port.DeliveryEventResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "string"
+                },
+                "hard": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "log_id": {
+                    "type": "integer"
+                },
+                "occurred": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "recipient": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "port.DnsRecordResponse": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                },
+                "verified": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "port.DomainKeyResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "algorithm": {
+                    "type": "string"
+                },
+                "created": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "selector": {
+                    "type": "string"
+                },
+                "verified": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "port.DomainResponse": {
+            "type": "object",
+            "properties": {
+                "checked": {
+                    "type": "string"
+                },
+                "created": {
+                    "type": "string"
+                },
+                "dkim_verified": {
+                    "type": "boolean"
+                },
+                "dmarc_verified": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/port.DomainKeyResponse"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "records": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/port.DnsRecordResponse"
+                    }
+                },
+                "spf_verified": {
+                    "type": "boolean"
+                },
+                "updated": {
+                    "type": "string"
+                },
+                "verified": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "port.EmailClickResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ip_hash": {
+                    "type": "string"
+                },
+                "link": {
+                    "type": "integer"
+                },
+                "log_id": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string"
+                }
+            }
+        },
+        "port.EmailLogAttachmentResponse": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string"
+                },
+                "filename": {
+                    "type": "string"
+                },
+                "sha256": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "port.EmailLogResponse": {
+            "type": "object",
+            "properties": {
+                "attachments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/port.EmailLogAttachmentResponse"
+                    }
+                },
+                "bcc": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "cc": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "clicks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/port.EmailClickResponse"
+                    }
+                },
+                "created": {
+                    "type": "string"
+                },
+                "email_id": {
+                    "type": "integer"
+                },
+                "errors": {
+                    "type": "string"
+                },
+                "event_uid": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/port.DeliveryEventResponse"
+                    }
+                },
+                "from_email": {
+                    "type": "string"
+                },
+                "from_name": {
+                    "type": "string"
+                },
+                "html": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "links": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "message_id": {
+                    "type": "string"
+                },
+                "opens": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/port.EmailOpenResponse"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "transitions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/port.EmailLogTransitionResponse"
+                    }
+                },
+                "variant_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "port.EmailLogTransitionResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "log_id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "port.EmailOpenResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "string"
+                },
+                "first": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ip_hash": {
+                    "type": "string"
+                },
+                "log_id": {
+                    "type": "integer"
+                },
+                "machine": {
+                    "type": "boolean"
+                },
+                "user_agent": {
+                    "type": "string"
+                }
+            }
+        },
+        "port.EmailResponse": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "created": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "folder_id": {
+                    "type": "integer"
+                },
+                "headers": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "html": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "pdf_attachments": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "reply_to": {
+                    "type": "string"
+                },
+                "sender_id": {
+                    "type": "integer"
+                },
+                "subject": {
+                    "type": "string"
+                },
+                "system_flag": {
+                    "type": "boolean"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "track_clicks": {
+                    "type": "boolean"
+                },
+                "track_opens": {
+                    "type": "boolean"
+                },
+                "updated": {
+                    "type": "string"
+                }
+            }
+        },
+        "port.FileResponse": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string"
+                },
+                "created": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sha256": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "port.FolderBreadcrumbResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "port.FolderResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "integer"
+                },
+                "sender_id": {
+                    "type": "integer"
+                },
+                "system_flag": {
+                    "type": "boolean"
+                },
+                "updated": {
+                    "type": "string"
+                }
+            }
+        },
+        "port.FolderTreeResponse": {
+            "type": "object",
+            "properties": {
+                "breadcrumbs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/port.FolderBreadcrumbResponse"
+                    }
+                },
+                "children": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/port.FolderTreeResponse"
+                    }
+                },
+                "created": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "emails": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "integer"
+                },
+                "path": {
+                    "type": "string"
+                },
+                "system_flag": {
+                    "type": "boolean"
+                },
+                "total_emails": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "string"
+                }
+            }
+        },
+        "port.ImportActionResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "entity": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "target": {
+                    "type": "string"
+                }
+            }
+        },
+        "port.ImportSuppressionsResponse": {
+            "type": "object",
+            "properties": {
+                "imported": {
+                    "type": "integer"
+                }
+            }
+        },
+        "port.PreviewAttachmentResponse": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string",
+                    "format": "base64"
+                },
+                "content_type": {
+                    "type": "string"
+                },
+                "filename": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "port.PreviewResponse": {
+            "type": "object",
+            "properties": {
+                "attachments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/port.PreviewAttachmentResponse"
+                    }
+                },
+                "from_email": {
+                    "type": "string"
+                },
+                "from_name": {
+                    "type": "string"
+                },
+                "headers": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "html": {
+                    "type": "string"
+                },
+                "reply_to": {
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "port.SearchEmailResponse": {
             "type": "object",
             "properties": {
+                "created": {
+                    "type": "string"
+                },
                 "description": {
                     "type": "string"
                 },
                 "folder_id": {
                     "type": "integer"
                 },
-                "from_email": {
+                "folder_name": {
                     "type": "string"
                 },
-                "from_name": {
+                "highlight": {
                     "type": "string"
                 },
-                "html": {
+                "id": {
+                    "type": "integer"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "rank": {
+                    "type": "number"
+                },
+                "snippet": {
                     "type": "string"
                 },
                 "subject": {
@@ -526,180 +4044,207 @@ const docTemplate = `{
                 "system_flag": {
                     "type": "boolean"
                 },
-                "text": {
+                "updated": {
                     "type": "string"
                 }
             }
         },
-        "github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.CreateFolderData": {
+        "port.SenderResponse": {
             "type": "object",
             "properties": {
+                "created": {
+                    "type": "string"
+                },
                 "description": {
                     "type": "string"
                 },
-                "name": {
+                "email": {
                     "type": "string"
                 },
-                "parent_id": {
+                "id": {
                     "type": "integer"
                 },
-                "system_flag": {
-                    "type": "boolean"
+                "name": {
+                    "type": "string"
+                },
+                "reply_to": {
+                    "type": "string"
+                },
+                "updated": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.FilterEmailLogsData": {
+        "port.SuppressionResponse": {
             "type": "object",
             "properties": {
-                "from_email": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "address": {
+                    "type": "string"
                 },
-                "from_name": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "created": {
+                    "type": "string"
+                },
+                "expires": {
+                    "type": "string"
                 },
                 "id": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                    "type": "integer"
                 },
-                "message_id": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "reason": {
+                    "type": "string"
                 },
-                "status": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "source": {
+                    "type": "string"
                 },
-                "to_email": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "updated": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.FilterEmailsData": {
+        "port.TrashEmailResponse": {
             "type": "object",
             "properties": {
-                "folder_id": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "created": {
+                    "type": "string"
                 },
-                "id": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "deleted": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "expires": {
+                    "type": "string"
+                },
+                "folder_id": {
+                    "type": "integer"
                 },
-                "system_flag": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.FilterFoldersData": {
-            "type": "object",
-            "properties": {
                 "id": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                    "type": "integer"
                 },
-                "name": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "key": {
+                    "type": "string"
                 },
-                "parent_id": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "subject": {
+                    "type": "string"
                 },
                 "system_flag": {
                     "type": "boolean"
+                },
+                "updated": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.SendCustomData": {
+        "port.TrashFolderResponse": {
             "type": "object",
             "properties": {
-                "from_email": {
+                "created": {
                     "type": "string"
                 },
-                "from_name": {
+                "deleted": {
                     "type": "string"
                 },
-                "html": {
+                "description": {
                     "type": "string"
                 },
-                "subject": {
+                "expires": {
                     "type": "string"
                 },
-                "text": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
                 },
-                "to_email": {
+                "parent_id": {
+                    "type": "integer"
+                },
+                "system_flag": {
+                    "type": "boolean"
+                },
+                "updated": {
                     "type": "string"
                 }
             }
         },
-        "github_com_flash-go_notifications-service_internal_port_adapter_handler_emails_http.SendData": {
+        "port.TrashResponse": {
             "type": "object",
             "properties": {
-                "email_id": {
-                    "type": "integer"
-                },
-                "to_email": {
-                    "type": "string"
+                "emails": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/port.TrashEmailResponse"
+                    }
                 },
-                "vars": {
+                "folders": {
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "$ref": "#/definitions/port.TrashFolderResponse"
                     }
                 }
             }
         },
-        "port.EmailLogResponse": {
+        "port.VariantReportResponse": {
             "type": "object",
             "properties": {
-                "created": {
-                    "type": "string"
+                "active": {
+                    "type": "boolean"
                 },
-                "errors": {
-                    "type": "string"
+                "click_rate": {
+                    "type": "number"
                 },
-                "from_email": {
+                "delivered": {
+                    "type": "integer"
+                },
+                "delivery_rate": {
+                    "type": "number"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
                 },
-                "from_name": {
+                "open_rate": {
+                    "type": "number"
+                },
+                "sent": {
+                    "type": "integer"
+                },
+                "unique_clicks": {
+                    "type": "integer"
+                },
+                "unique_opens": {
+                    "type": "integer"
+                },
+                "variant_id": {
+                    "type": "integer"
+                },
+                "weight": {
+                    "type": "integer"
+                }
+            }
+        },
+        "port.VariantResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created": {
                     "type": "string"
                 },
+                "email_id": {
+                    "type": "integer"
+                },
                 "html": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "message_id": {
-                    "type": "string"
-                },
-                "status": {
+                "name": {
                     "type": "string"
                 },
                 "subject": {
@@ -708,15 +4253,18 @@ const docTemplate = `{
                 "text": {
                     "type": "string"
                 },
-                "to_email": {
+                "updated": {
                     "type": "string"
+                },
+                "weight": {
+                    "type": "integer"
                 }
             }
         },
-        "port.EmailResponse": {
+        "port._UpdateEmailData": {
             "type": "object",
             "properties": {
-                "created": {
+                "category": {
                     "type": "string"
                 },
                 "description": {
@@ -725,94 +4273,114 @@ const docTemplate = `{
                 "folder_id": {
                     "type": "integer"
                 },
-                "from_email": {
+                "headers": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "html": {
                     "type": "string"
                 },
-                "from_name": {
+                "key": {
                     "type": "string"
                 },
-                "html": {
+                "pdf_attachments": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "reply_to": {
                     "type": "string"
                 },
-                "id": {
+                "sender_id": {
                     "type": "integer"
                 },
                 "subject": {
                     "type": "string"
                 },
-                "system_flag": {
-                    "type": "boolean"
-                },
                 "text": {
                     "type": "string"
                 },
-                "updated": {
-                    "type": "string"
+                "track_clicks": {
+                    "type": "boolean"
+                },
+                "track_opens": {
+                    "type": "boolean"
                 }
             }
         },
-        "port.FolderResponse": {
+        "port._UpdateFolderData": {
             "type": "object",
             "properties": {
-                "created": {
-                    "type": "string"
-                },
                 "description": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
-                },
                 "name": {
                     "type": "string"
                 },
                 "parent_id": {
                     "type": "integer"
                 },
-                "system_flag": {
-                    "type": "boolean"
-                },
-                "updated": {
-                    "type": "string"
+                "sender_id": {
+                    "type": "integer"
                 }
             }
         },
-        "port._UpdateEmailData": {
+        "port._UpdateSenderData": {
             "type": "object",
             "properties": {
                 "description": {
                     "type": "string"
                 },
-                "folder_id": {
-                    "type": "integer"
-                },
-                "from_email": {
+                "email": {
                     "type": "string"
                 },
-                "from_name": {
+                "name": {
                     "type": "string"
                 },
-                "html": {
+                "reply_to": {
                     "type": "string"
-                },
-                "subject": {
+                }
+            }
+        },
+        "port._UpdateSuppressionData": {
+            "type": "object",
+            "properties": {
+                "expires": {
                     "type": "string"
                 },
-                "text": {
-                    "type": "string"
+                "reason": {
+                    "type": "string",
+                    "enum": [
+                        "hard_bounce",
+                        "complaint",
+                        "unsubscribe",
+                        "manual"
+                    ]
                 }
             }
         },
-        "port._UpdateFolderData": {
+        "port._UpdateVariantData": {
             "type": "object",
             "properties": {
-                "description": {
+                "active": {
+                    "type": "boolean"
+                },
+                "html": {
                     "type": "string"
                 },
                 "name": {
                     "type": "string"
                 },
-                "parent_id": {
+                "subject": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "weight": {
                     "type": "integer"
                 }
             }
@@ -1,6 +1,12 @@
 package adapter
 
 import (
+	"bytes"
+	"context"
+	"html/template"
+	"strconv"
+	"strings"
+
 	"github.com/flash-go/flash/http/server"
 	httpEmailsHandlerAdapterPort "github.com/flash-go/notifications-service/internal/port/adapter/handler/emails/http"
 	emailsServicePort "github.com/flash-go/notifications-service/internal/port/service/emails"
@@ -9,35 +15,39 @@ import (
 
 type Config struct {
 	EmailsService emailsServicePort.Interface
+	SystemRole    string
 }
 
 func New(config *Config) httpEmailsHandlerAdapterPort.Interface {
 	return &adapter{
 		config.EmailsService,
+		config.SystemRole,
 	}
 }
 
 type adapter struct {
 	emailsService emailsServicePort.Interface
+	systemRole    string
 }
 
-// Folders
+// Senders
 
-// @Summary Create email folder (admin)
+// @Summary Create email sender (admin)
+// @Description Approves a sender identity that emails, folders and custom sends may use.
 // @Tags emails
 // @Security BearerAuth
 // @Accept json
 // @Produce json,plain
-// @Param request body httpEmailsHandlerAdapterPort.CreateFolderData true "Create email folder"
-// @Success 201 {object} httpEmailsHandlerAdapterPort.FolderResponse
-// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:invalid_parent, bad_request:invalid_name, bad_request:folder_exist"
-// @Router /admin/notifications/emails/folders [post]
-func (a *adapter) AdminCreateFolder(ctx server.ReqCtx) {
-	// Create email folder
-	folder, err := a.emailsService.CreateFolder(
+// @Param request body httpEmailsHandlerAdapterPort.CreateSenderData true "Create email sender"
+// @Success 201 {object} httpEmailsHandlerAdapterPort.SenderResponse
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:invalid_email, bad_request:invalid_name, bad_request:invalid_reply_to, bad_request:sender_exist"
+// @Router /admin/notifications/emails/senders [post]
+func (a *adapter) AdminCreateSender(ctx server.ReqCtx) {
+	// Create email sender
+	sender, err := a.emailsService.CreateSender(
 		ctx.Context(),
-		emailsServicePort.CreateFolderData(
-			*ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.CreateFolderData),
+		emailsServicePort.CreateSenderData(
+			*ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.CreateSenderData),
 		),
 	)
 	if err != nil {
@@ -46,24 +56,24 @@ func (a *adapter) AdminCreateFolder(ctx server.ReqCtx) {
 	}
 
 	// Write success response
-	ctx.WriteResponse(201, httpEmailsHandlerAdapterPort.FolderResponse(*folder))
+	ctx.WriteResponse(201, httpEmailsHandlerAdapterPort.SenderResponse(*sender))
 }
 
-// @Summary Filter email folders (admin)
+// @Summary Filter email senders (admin)
 // @Tags emails
 // @Security BearerAuth
 // @Accept json
 // @Produce json,plain
-// @Param request body httpEmailsHandlerAdapterPort.FilterFoldersData true "Filter email folders"
-// @Success 200 {array} httpEmailsHandlerAdapterPort.FolderResponse
+// @Param request body httpEmailsHandlerAdapterPort.FilterSendersData true "Filter email senders"
+// @Success 200 {array} httpEmailsHandlerAdapterPort.SenderResponse
 // @Failure 400 {string} string "Possible error codes: bad_request"
-// @Router /admin/notifications/emails/folders/filter [post]
-func (a *adapter) AdminFilterFolders(ctx server.ReqCtx) {
-	// Filter email folders
-	folders, err := a.emailsService.FilterFolders(
+// @Router /admin/notifications/emails/senders/filter [post]
+func (a *adapter) AdminFilterSenders(ctx server.ReqCtx) {
+	// Filter email senders
+	senders, err := a.emailsService.FilterSenders(
 		ctx.Context(),
-		emailsServicePort.FilterFoldersData(
-			*ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.FilterFoldersData),
+		emailsServicePort.FilterSendersData(
+			*ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.FilterSendersData),
 		),
 	)
 	if err != nil {
@@ -72,11 +82,11 @@ func (a *adapter) AdminFilterFolders(ctx server.ReqCtx) {
 	}
 
 	// Map service to adapter results
-	results := make([]httpEmailsHandlerAdapterPort.FolderResponse, 0, len(*folders))
-	for _, folder := range *folders {
+	results := make([]httpEmailsHandlerAdapterPort.SenderResponse, 0, len(*senders))
+	for _, sender := range *senders {
 		results = append(
 			results,
-			httpEmailsHandlerAdapterPort.FolderResponse(folder),
+			httpEmailsHandlerAdapterPort.SenderResponse(sender),
 		)
 	}
 
@@ -84,24 +94,25 @@ func (a *adapter) AdminFilterFolders(ctx server.ReqCtx) {
 	ctx.WriteResponse(200, results)
 }
 
-// @Summary Delete email folder (admin)
+// @Summary Delete email sender (admin)
+// @Description Only senders that no folder or email uses can be deleted.
 // @Tags emails
 // @Security BearerAuth
 // @Produce plain
-// @Param id path int true "Folder ID"
+// @Param id path int true "Sender ID"
 // @Success 204
-// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:folder_not_found"
-// @Router /admin/notifications/emails/folders/{id} [delete]
-func (a *adapter) AdminDeleteFolder(ctx server.ReqCtx) {
-	// Get and convert folder id to uint64
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:sender_not_found, bad_request:sender_in_use"
+// @Router /admin/notifications/emails/senders/{id} [delete]
+func (a *adapter) AdminDeleteSender(ctx server.ReqCtx) {
+	// Get and convert sender id to uint64
 	id, err := ctx.UserValueUint64("id")
 	if err != nil {
 		ctx.WriteErrorResponse(errors.ErrBadRequest)
 		return
 	}
 
-	// Delete email folder
-	if err := a.emailsService.DeleteFolder(
+	// Delete email sender
+	if err := a.emailsService.DeleteSender(
 		ctx.Context(),
 		uint(id),
 	); err != nil {
@@ -113,18 +124,18 @@ func (a *adapter) AdminDeleteFolder(ctx server.ReqCtx) {
 	ctx.WriteResponse(204, nil)
 }
 
-// @Summary Update email folder (admin)
+// @Summary Update email sender (admin)
 // @Tags emails
 // @Security BearerAuth
 // @Accept json
 // @Produce json,plain
-// @Param id path int true "Folder ID"
-// @Param request body httpEmailsHandlerAdapterPort._UpdateFolderData true "Update email folder"
+// @Param id path int true "Sender ID"
+// @Param request body httpEmailsHandlerAdapterPort._UpdateSenderData true "Update email sender"
 // @Success 204
-// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:invalid_name, bad_request:folder_not_found"
-// @Router /admin/notifications/emails/folders/{id} [patch]
-func (a *adapter) AdminUpdateFolder(ctx server.ReqCtx) {
-	// Get and convert folder id to uint64
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:invalid_email, bad_request:invalid_name, bad_request:invalid_reply_to, bad_request:invalid_description, bad_request:sender_not_found, bad_request:sender_exist"
+// @Router /admin/notifications/emails/senders/{id} [patch]
+func (a *adapter) AdminUpdateSender(ctx server.ReqCtx) {
+	// Get and convert sender id to uint64
 	id, err := ctx.UserValueUint64("id")
 	if err != nil {
 		ctx.WriteErrorResponse(errors.ErrBadRequest)
@@ -132,26 +143,29 @@ func (a *adapter) AdminUpdateFolder(ctx server.ReqCtx) {
 	}
 
 	// Get data
-	data := ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.UpdateFolderData)
+	data := ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.UpdateSenderData)
 
-	// Set folder data
-	folder := make(map[string]any)
+	// Set sender data
+	sender := make(map[string]any)
 
-	if data.ParentId.Set {
-		folder["parent_id"] = data.ParentId.Value
+	if data.Email.Set {
+		sender["email"] = data.Email.Value
 	}
 	if data.Name.Set {
-		folder["name"] = data.Name.Value
+		sender["name"] = data.Name.Value
+	}
+	if data.ReplyTo.Set {
+		sender["reply_to"] = data.ReplyTo.Value
 	}
 	if data.Description.Set {
-		folder["Description"] = data.Description.Value
+		sender["description"] = data.Description.Value
 	}
 
-	// Update email folder
-	if err := a.emailsService.UpdateFolder(
+	// Update email sender
+	if err := a.emailsService.UpdateSender(
 		ctx.Context(),
 		uint(id),
-		folder,
+		sender,
 	); err != nil {
 		ctx.WriteErrorResponse(err)
 		return
@@ -161,23 +175,24 @@ func (a *adapter) AdminUpdateFolder(ctx server.ReqCtx) {
 	ctx.WriteResponse(204, nil)
 }
 
-// Emails
+// Domains
 
-// @Summary Create email (admin)
+// @Summary Create sending domain (admin)
+// @Description Generates DKIM keys for the domain. The response lists the DNS records to publish before the domain can be verified.
 // @Tags emails
 // @Security BearerAuth
 // @Accept json
 // @Produce json,plain
-// @Param request body httpEmailsHandlerAdapterPort.CreateEmailData true "Create email"
-// @Success 201 {object} httpEmailsHandlerAdapterPort.EmailResponse
-// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:invalid_folder_id, bad_request:invalid_from_email, bad_request:invalid_from_name, bad_request:invalid_subject, bad_request:invalid_html, bad_request:invalid_text"
-// @Router /admin/notifications/emails [post]
-func (a *adapter) AdminCreateEmail(ctx server.ReqCtx) {
-	// Create email
-	email, err := a.emailsService.CreateEmail(
+// @Param request body httpEmailsHandlerAdapterPort.CreateDomainData true "Create sending domain"
+// @Success 201 {object} httpEmailsHandlerAdapterPort.DomainResponse
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:invalid_name, bad_request:invalid_dkim_selector, bad_request:invalid_dkim_algorithm, bad_request:domain_exist"
+// @Router /admin/notifications/emails/domains [post]
+func (a *adapter) AdminCreateDomain(ctx server.ReqCtx) {
+	// Create sending domain
+	domain, err := a.emailsService.CreateDomain(
 		ctx.Context(),
-		emailsServicePort.CreateEmailData(
-			*ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.CreateEmailData),
+		emailsServicePort.CreateDomainData(
+			*ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.CreateDomainData),
 		),
 	)
 	if err != nil {
@@ -186,24 +201,24 @@ func (a *adapter) AdminCreateEmail(ctx server.ReqCtx) {
 	}
 
 	// Write success response
-	ctx.WriteResponse(201, httpEmailsHandlerAdapterPort.EmailResponse(*email))
+	ctx.WriteResponse(201, domainResponse(*domain))
 }
 
-// @Summary Filter emails (admin)
+// @Summary Filter sending domains (admin)
 // @Tags emails
 // @Security BearerAuth
 // @Accept json
 // @Produce json,plain
-// @Param request body httpEmailsHandlerAdapterPort.FilterEmailsData true "Filter emails"
-// @Success 200 {array} httpEmailsHandlerAdapterPort.EmailResponse
+// @Param request body httpEmailsHandlerAdapterPort.FilterDomainsData true "Filter sending domains"
+// @Success 200 {array} httpEmailsHandlerAdapterPort.DomainResponse
 // @Failure 400 {string} string "Possible error codes: bad_request"
-// @Router /admin/notifications/emails/filter [post]
-func (a *adapter) AdminFilterEmails(ctx server.ReqCtx) {
-	// Filter emails
-	emails, err := a.emailsService.FilterEmails(
+// @Router /admin/notifications/emails/domains/filter [post]
+func (a *adapter) AdminFilterDomains(ctx server.ReqCtx) {
+	// Filter sending domains
+	domains, err := a.emailsService.FilterDomains(
 		ctx.Context(),
-		emailsServicePort.FilterEmailsData(
-			*ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.FilterEmailsData),
+		emailsServicePort.FilterDomainsData(
+			*ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.FilterDomainsData),
 		),
 	)
 	if err != nil {
@@ -212,11 +227,11 @@ func (a *adapter) AdminFilterEmails(ctx server.ReqCtx) {
 	}
 
 	// Map service to adapter results
-	results := make([]httpEmailsHandlerAdapterPort.EmailResponse, 0, len(*emails))
-	for _, email := range *emails {
+	results := make([]httpEmailsHandlerAdapterPort.DomainResponse, 0, len(*domains))
+	for _, domain := range *domains {
 		results = append(
 			results,
-			httpEmailsHandlerAdapterPort.EmailResponse(email),
+			domainResponse(domain),
 		)
 	}
 
@@ -224,24 +239,24 @@ func (a *adapter) AdminFilterEmails(ctx server.ReqCtx) {
 	ctx.WriteResponse(200, results)
 }
 
-// @Summary Delete email (admin)
+// @Summary Delete sending domain (admin)
 // @Tags emails
 // @Security BearerAuth
 // @Produce plain
-// @Param id path int true "Email ID"
+// @Param id path int true "Domain ID"
 // @Success 204
-// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:email_not_found"
-// @Router /admin/notifications/emails/{id} [delete]
-func (a *adapter) AdminDeleteEmail(ctx server.ReqCtx) {
-	// Get and convert email id to uint64
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:domain_not_found"
+// @Router /admin/notifications/emails/domains/{id} [delete]
+func (a *adapter) AdminDeleteDomain(ctx server.ReqCtx) {
+	// Get and convert domain id to uint64
 	id, err := ctx.UserValueUint64("id")
 	if err != nil {
 		ctx.WriteErrorResponse(errors.ErrBadRequest)
 		return
 	}
 
-	// Delete email
-	if err := a.emailsService.DeleteEmail(
+	// Delete sending domain
+	if err := a.emailsService.DeleteDomain(
 		ctx.Context(),
 		uint(id),
 	); err != nil {
@@ -253,57 +268,143 @@ func (a *adapter) AdminDeleteEmail(ctx server.ReqCtx) {
 	ctx.WriteResponse(204, nil)
 }
 
-// @Summary Update email (admin)
+// @Summary Verify sending domain (admin)
+// @Description Checks the SPF, DKIM and DMARC records of the domain now instead of waiting for the background check.
 // @Tags emails
 // @Security BearerAuth
-// @Accept json
 // @Produce json,plain
-// @Param id path int true "Email ID"
-// @Param request body httpEmailsHandlerAdapterPort._UpdateEmailData true "Update email"
-// @Success 204
-// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:invalid_folder_id, bad_request:invalid_from_email, bad_request:invalid_from_name, bad_request:invalid_subject, bad_request:invalid_html, bad_request:invalid_text, bad_request:email_not_found"
-// @Router /admin/notifications/emails/{id} [patch]
-func (a *adapter) AdminUpdateEmail(ctx server.ReqCtx) {
-	// Get and convert email id to uint64
+// @Param id path int true "Domain ID"
+// @Success 200 {object} httpEmailsHandlerAdapterPort.DomainResponse
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:domain_not_found"
+// @Router /admin/notifications/emails/domains/{id}/verify [post]
+func (a *adapter) AdminVerifyDomain(ctx server.ReqCtx) {
+	// Get and convert domain id to uint64
 	id, err := ctx.UserValueUint64("id")
 	if err != nil {
 		ctx.WriteErrorResponse(errors.ErrBadRequest)
 		return
 	}
 
-	// Get data
-	data := ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.UpdateEmailData)
+	// Verify sending domain
+	domain, err := a.emailsService.VerifyDomain(
+		ctx.Context(),
+		uint(id),
+	)
+	if err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
 
-	// Set email data
-	email := make(map[string]any)
+	// Write success response
+	ctx.WriteResponse(200, domainResponse(*domain))
+}
 
-	if data.FolderId.Set {
-		email["folder_id"] = data.FolderId.Value
+// @Summary Create DKIM key of sending domain (admin)
+// @Description Generates an inactive key under a new selector to rotate the DKIM key. Publish its DNS record, then activate it.
+// @Tags emails
+// @Security BearerAuth
+// @Accept json
+// @Produce json,plain
+// @Param id path int true "Domain ID"
+// @Param request body httpEmailsHandlerAdapterPort.CreateDomainKeyData true "Create DKIM key"
+// @Success 201 {object} httpEmailsHandlerAdapterPort.DomainResponse
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:invalid_dkim_selector, bad_request:invalid_dkim_algorithm, bad_request:domain_not_found, bad_request:dkim_key_exist"
+// @Router /admin/notifications/emails/domains/{id}/keys [post]
+func (a *adapter) AdminCreateDomainKey(ctx server.ReqCtx) {
+	// Get and convert domain id to uint64
+	id, err := ctx.UserValueUint64("id")
+	if err != nil {
+		ctx.WriteErrorResponse(errors.ErrBadRequest)
+		return
 	}
-	if data.FromEmail.Set {
-		email["from_email"] = data.FromEmail.Value
+
+	// Create DKIM key
+	domain, err := a.emailsService.CreateDomainKey(
+		ctx.Context(),
+		uint(id),
+		emailsServicePort.CreateDomainKeyData(
+			*ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.CreateDomainKeyData),
+		),
+	)
+	if err != nil {
+		ctx.WriteErrorResponse(err)
+		return
 	}
-	if data.FromName.Set {
-		email["from_name"] = data.FromName.Value
+
+	// Write success response
+	ctx.WriteResponse(201, domainResponse(*domain))
+}
+
+// @Summary Activate DKIM key of sending domain (admin)
+// @Description Signs outgoing messages of the domain with the key. The DKIM record of the key must be published.
+// @Tags emails
+// @Security BearerAuth
+// @Produce json,plain
+// @Param id path int true "Domain ID"
+// @Param key_id path int true "Key ID"
+// @Success 200 {object} httpEmailsHandlerAdapterPort.DomainResponse
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:domain_not_found, bad_request:dkim_key_not_found, bad_request:dkim_key_not_published"
+// @Router /admin/notifications/emails/domains/{id}/keys/{key_id}/activate [post]
+func (a *adapter) AdminActivateDomainKey(ctx server.ReqCtx) {
+	// Get and convert domain id to uint64
+	id, err := ctx.UserValueUint64("id")
+	if err != nil {
+		ctx.WriteErrorResponse(errors.ErrBadRequest)
+		return
 	}
-	if data.Subject.Set {
-		email["subject"] = data.Subject.Value
+
+	// Get and convert key id to uint64
+	keyId, err := ctx.UserValueUint64("key_id")
+	if err != nil {
+		ctx.WriteErrorResponse(errors.ErrBadRequest)
+		return
 	}
-	if data.Html.Set {
-		email["html"] = data.Html.Value
+
+	// Activate DKIM key
+	domain, err := a.emailsService.ActivateDomainKey(
+		ctx.Context(),
+		uint(id),
+		uint(keyId),
+	)
+	if err != nil {
+		ctx.WriteErrorResponse(err)
+		return
 	}
-	if data.Text.Set {
-		email["text"] = data.Text.Value
+
+	// Write success response
+	ctx.WriteResponse(200, domainResponse(*domain))
+}
+
+// @Summary Delete DKIM key of sending domain (admin)
+// @Description The active key cannot be deleted.
+// @Tags emails
+// @Security BearerAuth
+// @Produce plain
+// @Param id path int true "Domain ID"
+// @Param key_id path int true "Key ID"
+// @Success 204
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:dkim_key_not_found, bad_request:dkim_key_active"
+// @Router /admin/notifications/emails/domains/{id}/keys/{key_id} [delete]
+func (a *adapter) AdminDeleteDomainKey(ctx server.ReqCtx) {
+	// Get and convert domain id to uint64
+	id, err := ctx.UserValueUint64("id")
+	if err != nil {
+		ctx.WriteErrorResponse(errors.ErrBadRequest)
+		return
 	}
-	if data.Description.Set {
-		email["description"] = data.Description.Value
+
+	// Get and convert key id to uint64
+	keyId, err := ctx.UserValueUint64("key_id")
+	if err != nil {
+		ctx.WriteErrorResponse(errors.ErrBadRequest)
+		return
 	}
 
-	// Update email
-	if err := a.emailsService.UpdateEmail(
+	// Delete DKIM key
+	if err := a.emailsService.DeleteDomainKey(
 		ctx.Context(),
 		uint(id),
-		email,
+		uint(keyId),
 	); err != nil {
 		ctx.WriteErrorResponse(err)
 		return
@@ -313,21 +414,24 @@ func (a *adapter) AdminUpdateEmail(ctx server.ReqCtx) {
 	ctx.WriteResponse(204, nil)
 }
 
-// @Summary Send custom email
+// Files
+
+// @Summary Upload file (admin)
+// @Description Stores a file that sends may attach by its id. The content is base64 encoded.
 // @Tags emails
 // @Security BearerAuth
 // @Accept json
 // @Produce json,plain
-// @Param request body httpEmailsHandlerAdapterPort.SendCustomData true "Send custom email"
-// @Success 201 {object} httpEmailsHandlerAdapterPort.EmailLogResponse
-// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:invalid_from_email, bad_request:invalid_from_name, bad_request:invalid_subject, bad_request:invalid_to_email, bad_request:invalid_html, bad_request:invalid_text"
-// @Router /notifications/emails/send/custom [post]
-func (a *adapter) SendCustom(ctx server.ReqCtx) {
-	// Send custom email
-	log, err := a.emailsService.SendCustom(
+// @Param request body httpEmailsHandlerAdapterPort.CreateFileData true "Upload file"
+// @Success 201 {object} httpEmailsHandlerAdapterPort.FileResponse
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:invalid_name, bad_request:invalid_content_type, bad_request:invalid_content, bad_request:attachments_too_large, bad_request:attachment_type_not_allowed"
+// @Router /admin/notifications/emails/files [post]
+func (a *adapter) AdminCreateFile(ctx server.ReqCtx) {
+	// Upload file
+	file, err := a.emailsService.CreateFile(
 		ctx.Context(),
-		emailsServicePort.SendCustomData(
-			*ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.SendCustomData),
+		emailsServicePort.CreateFileData(
+			*ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.CreateFileData),
 		),
 	)
 	if err != nil {
@@ -336,23 +440,24 @@ func (a *adapter) SendCustom(ctx server.ReqCtx) {
 	}
 
 	// Write success response
-	ctx.WriteResponse(201, httpEmailsHandlerAdapterPort.EmailLogResponse(*log))
+	ctx.WriteResponse(201, httpEmailsHandlerAdapterPort.FileResponse(*file))
 }
 
-// @Summary Send email
+// @Summary Filter files (admin)
 // @Tags emails
+// @Security BearerAuth
 // @Accept json
 // @Produce json,plain
-// @Param request body httpEmailsHandlerAdapterPort.SendData true "Send email"
-// @Success 201 {object} httpEmailsHandlerAdapterPort.EmailLogResponse
-// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:invalid_id, bad_request:invalid_to_email, bad_request:email_not_found"
-// @Router /notifications/emails/send [post]
-func (a *adapter) Send(ctx server.ReqCtx) {
-	// Send email
-	log, err := a.emailsService.Send(
+// @Param request body httpEmailsHandlerAdapterPort.FilterFilesData true "Filter files"
+// @Success 200 {array} httpEmailsHandlerAdapterPort.FileResponse
+// @Failure 400 {string} string "Possible error codes: bad_request"
+// @Router /admin/notifications/emails/files/filter [post]
+func (a *adapter) AdminFilterFiles(ctx server.ReqCtx) {
+	// Filter files
+	files, err := a.emailsService.FilterFiles(
 		ctx.Context(),
-		emailsServicePort.SendData(
-			*ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.SendData),
+		emailsServicePort.FilterFilesData(
+			*ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.FilterFilesData),
 		),
 	)
 	if err != nil {
@@ -360,25 +465,93 @@ func (a *adapter) Send(ctx server.ReqCtx) {
 		return
 	}
 
+	// Map service to adapter results
+	results := make([]httpEmailsHandlerAdapterPort.FileResponse, 0, len(*files))
+	for _, file := range *files {
+		results = append(
+			results,
+			httpEmailsHandlerAdapterPort.FileResponse(file),
+		)
+	}
+
 	// Write success response
-	ctx.WriteResponse(201, httpEmailsHandlerAdapterPort.EmailLogResponse(*log))
+	ctx.WriteResponse(200, results)
 }
 
-// @Summary Filter email logs (admin)
+// @Summary Delete file (admin)
+// @Description Logs of emails sent with the file keep its metadata.
+// @Tags emails
+// @Security BearerAuth
+// @Produce plain
+// @Param id path int true "File ID"
+// @Success 204
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:file_not_found"
+// @Router /admin/notifications/emails/files/{id} [delete]
+func (a *adapter) AdminDeleteFile(ctx server.ReqCtx) {
+	// Get and convert file id to uint64
+	id, err := ctx.UserValueUint64("id")
+	if err != nil {
+		ctx.WriteErrorResponse(errors.ErrBadRequest)
+		return
+	}
+
+	// Delete file
+	if err := a.emailsService.DeleteFile(
+		ctx.Context(),
+		uint(id),
+	); err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Write success response
+	ctx.WriteResponse(204, nil)
+}
+
+// Assets
+
+// @Summary Upload asset (admin)
+// @Description Stores a template image served publicly at the returned url. Html may also reference it as cid:name to embed it into the message at send time. The content is base64 encoded.
 // @Tags emails
 // @Security BearerAuth
 // @Accept json
 // @Produce json,plain
-// @Param request body httpEmailsHandlerAdapterPort.FilterEmailLogsData true "Filter email logs (admin)"
-// @Success 200 {array} httpEmailsHandlerAdapterPort.EmailLogResponse
+// @Param request body httpEmailsHandlerAdapterPort.CreateAssetData true "Upload asset"
+// @Success 201 {object} httpEmailsHandlerAdapterPort.AssetResponse
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:invalid_name, bad_request:invalid_content_type, bad_request:invalid_content, bad_request:asset_exist, bad_request:asset_too_large, bad_request:asset_type_not_allowed"
+// @Router /admin/notifications/emails/assets [post]
+func (a *adapter) AdminCreateAsset(ctx server.ReqCtx) {
+	// Upload asset
+	asset, err := a.emailsService.CreateAsset(
+		ctx.Context(),
+		emailsServicePort.CreateAssetData(
+			*ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.CreateAssetData),
+		),
+	)
+	if err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Write success response
+	ctx.WriteResponse(201, httpEmailsHandlerAdapterPort.AssetResponse(*asset))
+}
+
+// @Summary Filter assets (admin)
+// @Tags emails
+// @Security BearerAuth
+// @Accept json
+// @Produce json,plain
+// @Param request body httpEmailsHandlerAdapterPort.FilterAssetsData true "Filter assets"
+// @Success 200 {array} httpEmailsHandlerAdapterPort.AssetResponse
 // @Failure 400 {string} string "Possible error codes: bad_request"
-// @Router /admin/notifications/emails/logs/filter [post]
-func (a *adapter) AdminFilterEmailLogs(ctx server.ReqCtx) {
-	// Filter email logs
-	logs, err := a.emailsService.FilterEmailLogs(
+// @Router /admin/notifications/emails/assets/filter [post]
+func (a *adapter) AdminFilterAssets(ctx server.ReqCtx) {
+	// Filter assets
+	assets, err := a.emailsService.FilterAssets(
 		ctx.Context(),
-		emailsServicePort.FilterEmailLogsData(
-			*ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.FilterEmailLogsData),
+		emailsServicePort.FilterAssetsData(
+			*ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.FilterAssetsData),
 		),
 	)
 	if err != nil {
@@ -387,14 +560,1809 @@ func (a *adapter) AdminFilterEmailLogs(ctx server.ReqCtx) {
 	}
 
 	// Map service to adapter results
-	results := make([]httpEmailsHandlerAdapterPort.EmailLogResponse, 0, len(*logs))
-	for _, log := range *logs {
+	results := make([]httpEmailsHandlerAdapterPort.AssetResponse, 0, len(*assets))
+	for _, asset := range *assets {
 		results = append(
 			results,
-			httpEmailsHandlerAdapterPort.EmailLogResponse(log),
+			httpEmailsHandlerAdapterPort.AssetResponse(asset),
 		)
 	}
 
 	// Write success response
 	ctx.WriteResponse(200, results)
 }
+
+// @Summary Delete asset (admin)
+// @Description Templates still referencing the asset fail to send with asset_not_found.
+// @Tags emails
+// @Security BearerAuth
+// @Produce plain
+// @Param id path int true "Asset ID"
+// @Success 204
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:asset_not_found"
+// @Router /admin/notifications/emails/assets/{id} [delete]
+func (a *adapter) AdminDeleteAsset(ctx server.ReqCtx) {
+	// Get and convert asset id to uint64
+	id, err := ctx.UserValueUint64("id")
+	if err != nil {
+		ctx.WriteErrorResponse(errors.ErrBadRequest)
+		return
+	}
+
+	// Delete asset
+	if err := a.emailsService.DeleteAsset(
+		ctx.Context(),
+		uint(id),
+	); err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Write success response
+	ctx.WriteResponse(204, nil)
+}
+
+// @Summary Get asset
+// @Description Public route serving asset content for templates referencing it by url.
+// @Tags emails
+// @Produce image/png,image/jpeg,image/gif,image/webp,plain
+// @Param name path string true "Asset name"
+// @Success 200 {file} file
+// @Failure 400 {string} string "Possible error codes: bad_request:asset_not_found"
+// @Router /notifications/emails/assets/{name} [get]
+func (a *adapter) GetAsset(ctx server.ReqCtx) {
+	// Get asset content
+	asset, err := a.emailsService.GetAssetContent(
+		ctx.Context(),
+		ctx.UserValueStr("name"),
+	)
+	if err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Write success response
+	ctx.SetContentType(asset.ContentType)
+	ctx.SetStatusCode(200)
+	ctx.Write(asset.Content)
+}
+
+// Unsubscribes
+
+// Pages of unsubscribe links opened in a browser
+var (
+	unsubscribeConfirmPage = template.Must(template.New("confirm").Parse(`<!DOCTYPE html>
+<html><head><meta charset="utf-8"><meta name="viewport" content="width=device-width, initial-scale=1"><title>Unsubscribe</title></head>
+<body><p>Unsubscribe {{.Email}} from {{.Category}} emails?</p><form method="post"><button type="submit">Unsubscribe</button></form></body></html>
+`))
+	unsubscribeDonePage = template.Must(template.New("done").Parse(`<!DOCTYPE html>
+<html><head><meta charset="utf-8"><meta name="viewport" content="width=device-width, initial-scale=1"><title>Unsubscribed</title></head>
+<body><p>{{.Email}} is unsubscribed from {{.Category}} emails.</p></body></html>
+`))
+)
+
+// @Summary Get unsubscribe page
+// @Description Public route of the List-Unsubscribe link opened in a browser, asks to confirm the opt-out without recording it.
+// @Tags emails
+// @Produce html,plain
+// @Param token path string true "Unsubscribe token"
+// @Success 200 {string} string "Confirmation page"
+// @Failure 400 {string} string "Possible error codes: bad_request:invalid_unsubscribe_token"
+// @Router /notifications/emails/unsubscribe/{token} [get]
+func (a *adapter) GetUnsubscribe(ctx server.ReqCtx) {
+	// Verify unsubscribe token
+	unsubscribe, err := a.emailsService.GetUnsubscribe(
+		ctx.Context(),
+		ctx.UserValueStr("token"),
+	)
+	if err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Write success response
+	writePage(ctx, unsubscribeConfirmPage, unsubscribe)
+}
+
+// @Summary Unsubscribe
+// @Description Public one-click unsubscribe route (RFC 8058) of the List-Unsubscribe link, records the opt-out of the recipient from the email category.
+// @Tags emails
+// @Produce html,plain
+// @Param token path string true "Unsubscribe token"
+// @Success 200 {string} string "Result page"
+// @Failure 400 {string} string "Possible error codes: bad_request:invalid_unsubscribe_token"
+// @Router /notifications/emails/unsubscribe/{token} [post]
+func (a *adapter) Unsubscribe(ctx server.ReqCtx) {
+	// Unsubscribe recipient
+	unsubscribe, err := a.emailsService.Unsubscribe(
+		ctx.Context(),
+		ctx.UserValueStr("token"),
+	)
+	if err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Write success response
+	writePage(ctx, unsubscribeDonePage, unsubscribe)
+}
+
+// Suppressions
+
+// @Summary Create suppression (admin)
+// @Description Messages are not sent to a suppressed address until the suppression expires, they are logged with status suppressed instead.
+// @Tags emails
+// @Security BearerAuth
+// @Accept json
+// @Produce json,plain
+// @Param request body httpEmailsHandlerAdapterPort.CreateSuppressionData true "Create suppression"
+// @Success 201 {object} httpEmailsHandlerAdapterPort.SuppressionResponse
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:invalid_address, bad_request:invalid_reason, bad_request:suppression_exist"
+// @Router /admin/notifications/emails/suppressions [post]
+func (a *adapter) AdminCreateSuppression(ctx server.ReqCtx) {
+	// Create suppression
+	suppression, err := a.emailsService.CreateSuppression(
+		ctx.Context(),
+		emailsServicePort.CreateSuppressionData(
+			*ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.CreateSuppressionData),
+		),
+	)
+	if err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Write success response
+	ctx.WriteResponse(201, httpEmailsHandlerAdapterPort.SuppressionResponse(*suppression))
+}
+
+// @Summary Filter suppressions (admin)
+// @Tags emails
+// @Security BearerAuth
+// @Accept json
+// @Produce json,plain
+// @Param request body httpEmailsHandlerAdapterPort.FilterSuppressionsData true "Filter suppressions"
+// @Success 200 {array} httpEmailsHandlerAdapterPort.SuppressionResponse
+// @Failure 400 {string} string "Possible error codes: bad_request"
+// @Router /admin/notifications/emails/suppressions/filter [post]
+func (a *adapter) AdminFilterSuppressions(ctx server.ReqCtx) {
+	// Filter suppressions
+	suppressions, err := a.emailsService.FilterSuppressions(
+		ctx.Context(),
+		emailsServicePort.FilterSuppressionsData(
+			*ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.FilterSuppressionsData),
+		),
+	)
+	if err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Map service to adapter results
+	results := make([]httpEmailsHandlerAdapterPort.SuppressionResponse, 0, len(*suppressions))
+	for _, suppression := range *suppressions {
+		results = append(
+			results,
+			httpEmailsHandlerAdapterPort.SuppressionResponse(suppression),
+		)
+	}
+
+	// Write success response
+	ctx.WriteResponse(200, results)
+}
+
+// @Summary Delete suppression (admin)
+// @Tags emails
+// @Security BearerAuth
+// @Produce plain
+// @Param id path int true "Suppression ID"
+// @Success 204
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:suppression_not_found"
+// @Router /admin/notifications/emails/suppressions/{id} [delete]
+func (a *adapter) AdminDeleteSuppression(ctx server.ReqCtx) {
+	// Get and convert suppression id to uint64
+	id, err := ctx.UserValueUint64("id")
+	if err != nil {
+		ctx.WriteErrorResponse(errors.ErrBadRequest)
+		return
+	}
+
+	// Delete suppression
+	if err := a.emailsService.DeleteSuppression(
+		ctx.Context(),
+		uint(id),
+	); err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Write success response
+	ctx.WriteResponse(204, nil)
+}
+
+// @Summary Update suppression (admin)
+// @Description A null expires makes the suppression permanent.
+// @Tags emails
+// @Security BearerAuth
+// @Accept json
+// @Produce json,plain
+// @Param id path int true "Suppression ID"
+// @Param request body httpEmailsHandlerAdapterPort._UpdateSuppressionData true "Update suppression"
+// @Success 204
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:invalid_reason, bad_request:suppression_not_found"
+// @Router /admin/notifications/emails/suppressions/{id} [patch]
+func (a *adapter) AdminUpdateSuppression(ctx server.ReqCtx) {
+	// Get and convert suppression id to uint64
+	id, err := ctx.UserValueUint64("id")
+	if err != nil {
+		ctx.WriteErrorResponse(errors.ErrBadRequest)
+		return
+	}
+
+	// Get data
+	data := ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.UpdateSuppressionData)
+
+	// Set suppression data
+	suppression := make(map[string]any)
+
+	if data.Reason.Set {
+		suppression["reason"] = data.Reason.Value
+	}
+	if data.Expires.Set {
+		suppression["expires"] = data.Expires.Value
+	}
+
+	// Update suppression
+	if err := a.emailsService.UpdateSuppression(
+		ctx.Context(),
+		uint(id),
+		suppression,
+	); err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Write success response
+	ctx.WriteResponse(204, nil)
+}
+
+// @Summary Import suppressions (admin)
+// @Description Creates or overwrites suppressions of up to 10000 addresses at once, the last entry of a repeated address wins.
+// @Tags emails
+// @Security BearerAuth
+// @Accept json
+// @Produce json,plain
+// @Param request body httpEmailsHandlerAdapterPort.ImportSuppressionsData true "Import suppressions"
+// @Success 200 {object} httpEmailsHandlerAdapterPort.ImportSuppressionsResponse
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:invalid_suppressions, bad_request:invalid_address, bad_request:invalid_reason"
+// @Router /admin/notifications/emails/suppressions/import [post]
+func (a *adapter) AdminImportSuppressions(ctx server.ReqCtx) {
+	// Get data
+	data := ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.ImportSuppressionsData)
+
+	// Map suppressions
+	suppressions := make([]emailsServicePort.CreateSuppressionData, 0, len(data.Suppressions))
+	for _, suppression := range data.Suppressions {
+		suppressions = append(
+			suppressions,
+			emailsServicePort.CreateSuppressionData(suppression),
+		)
+	}
+
+	// Import suppressions
+	result, err := a.emailsService.ImportSuppressions(
+		ctx.Context(),
+		emailsServicePort.ImportSuppressionsData{
+			Suppressions: suppressions,
+		},
+	)
+	if err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Write success response
+	ctx.WriteResponse(200, httpEmailsHandlerAdapterPort.ImportSuppressionsResponse(*result))
+}
+
+// Folders
+
+// @Summary Create email folder (admin)
+// @Tags emails
+// @Security BearerAuth
+// @Accept json
+// @Produce json,plain
+// @Param request body httpEmailsHandlerAdapterPort.CreateFolderData true "Create email folder"
+// @Success 201 {object} httpEmailsHandlerAdapterPort.FolderResponse
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:invalid_parent, bad_request:invalid_sender_id, bad_request:invalid_name, bad_request:folder_not_found, bad_request:sender_not_found, bad_request:folder_exist, bad_request:system_access"
+// @Router /admin/notifications/emails/folders [post]
+func (a *adapter) AdminCreateFolder(ctx server.ReqCtx) {
+	// Create email folder
+	folder, err := a.emailsService.CreateFolder(
+		a.context(ctx),
+		emailsServicePort.CreateFolderData(
+			*ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.CreateFolderData),
+		),
+	)
+	if err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Write success response
+	ctx.WriteResponse(201, httpEmailsHandlerAdapterPort.FolderResponse(*folder))
+}
+
+// @Summary Filter email folders (admin)
+// @Tags emails
+// @Security BearerAuth
+// @Accept json
+// @Produce json,plain
+// @Param request body httpEmailsHandlerAdapterPort.FilterFoldersData true "Filter email folders"
+// @Success 200 {array} httpEmailsHandlerAdapterPort.FolderResponse
+// @Failure 400 {string} string "Possible error codes: bad_request"
+// @Router /admin/notifications/emails/folders/filter [post]
+func (a *adapter) AdminFilterFolders(ctx server.ReqCtx) {
+	// Filter email folders
+	folders, err := a.emailsService.FilterFolders(
+		ctx.Context(),
+		emailsServicePort.FilterFoldersData(
+			*ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.FilterFoldersData),
+		),
+	)
+	if err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Map service to adapter results
+	results := make([]httpEmailsHandlerAdapterPort.FolderResponse, 0, len(*folders))
+	for _, folder := range *folders {
+		results = append(
+			results,
+			httpEmailsHandlerAdapterPort.FolderResponse(folder),
+		)
+	}
+
+	// Write success response
+	ctx.WriteResponse(200, results)
+}
+
+// @Summary Delete email folder (admin)
+// @Description Moves the folder with its subfolders and emails to the trash.
+// @Tags emails
+// @Security BearerAuth
+// @Produce plain
+// @Param id path int true "Folder ID"
+// @Success 204
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:folder_not_found, bad_request:system_entity"
+// @Router /admin/notifications/emails/folders/{id} [delete]
+func (a *adapter) AdminDeleteFolder(ctx server.ReqCtx) {
+	// Get and convert folder id to uint64
+	id, err := ctx.UserValueUint64("id")
+	if err != nil {
+		ctx.WriteErrorResponse(errors.ErrBadRequest)
+		return
+	}
+
+	// Delete email folder
+	if err := a.emailsService.DeleteFolder(
+		a.context(ctx),
+		uint(id),
+	); err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Write success response
+	ctx.WriteResponse(204, nil)
+}
+
+// @Summary Update email folder (admin)
+// @Tags emails
+// @Security BearerAuth
+// @Accept json
+// @Produce json,plain
+// @Param id path int true "Folder ID"
+// @Param request body httpEmailsHandlerAdapterPort._UpdateFolderData true "Update email folder"
+// @Success 204
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:invalid_parent, bad_request:invalid_sender_id, bad_request:invalid_name, bad_request:folder_not_found, bad_request:sender_not_found, bad_request:folder_exist, bad_request:folder_cycle, bad_request:system_entity, bad_request:system_access"
+// @Router /admin/notifications/emails/folders/{id} [patch]
+func (a *adapter) AdminUpdateFolder(ctx server.ReqCtx) {
+	// Get and convert folder id to uint64
+	id, err := ctx.UserValueUint64("id")
+	if err != nil {
+		ctx.WriteErrorResponse(errors.ErrBadRequest)
+		return
+	}
+
+	// Get data
+	data := ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.UpdateFolderData)
+
+	// Set folder data
+	folder := make(map[string]any)
+
+	if data.ParentId.Set {
+		folder["parent_id"] = data.ParentId.Value
+	}
+	if data.SenderId.Set {
+		folder["sender_id"] = data.SenderId.Value
+	}
+	if data.Name.Set {
+		folder["name"] = data.Name.Value
+	}
+	if data.Description.Set {
+		folder["description"] = data.Description.Value
+	}
+
+	// Update email folder
+	if err := a.emailsService.UpdateFolder(
+		a.context(ctx),
+		uint(id),
+		folder,
+	); err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Write success response
+	ctx.WriteResponse(204, nil)
+}
+
+// @Summary Get email folders tree (admin)
+// @Description Returns the folders tree or the subtree of the root folder. Each folder has its path, breadcrumbs from the top level folder, the number of its own emails and the number of emails in the whole subtree.
+// @Tags emails
+// @Security BearerAuth
+// @Produce json,plain
+// @Param root_id query int false "Subtree root folder ID"
+// @Success 200 {array} httpEmailsHandlerAdapterPort.FolderTreeResponse
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:invalid_root_id, bad_request:folder_not_found"
+// @Router /admin/notifications/emails/folders/tree [get]
+func (a *adapter) AdminGetFolderTree(ctx server.ReqCtx) {
+	// Get data
+	rootId, err := queryUint(ctx, "root_id")
+	if err != nil {
+		ctx.WriteErrorResponse(errors.ErrBadRequest)
+		return
+	}
+	data := httpEmailsHandlerAdapterPort.FolderTreeData{
+		RootId: rootId,
+	}
+
+	// Validate data
+	if err := data.Validate(); err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Get folders tree
+	tree, err := a.emailsService.GetFolderTree(
+		ctx.Context(),
+		emailsServicePort.FolderTreeData(data),
+	)
+	if err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Write success response
+	ctx.WriteResponse(200, folderTreeResponse(*tree))
+}
+
+// @Summary Move email folder (admin)
+// @Description Moves the folder with its subtree under the parent folder or to the top level if parent_id is null.
+// @Tags emails
+// @Security BearerAuth
+// @Accept json
+// @Produce plain
+// @Param id path int true "Folder ID"
+// @Param request body httpEmailsHandlerAdapterPort.MoveFolderData true "Move email folder"
+// @Success 204
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:invalid_parent, bad_request:folder_not_found, bad_request:folder_exist, bad_request:folder_cycle, bad_request:system_entity"
+// @Router /admin/notifications/emails/folders/{id}/move [post]
+func (a *adapter) AdminMoveFolder(ctx server.ReqCtx) {
+	// Get and convert folder id to uint64
+	id, err := ctx.UserValueUint64("id")
+	if err != nil {
+		ctx.WriteErrorResponse(errors.ErrBadRequest)
+		return
+	}
+
+	// Move email folder
+	if err := a.emailsService.MoveFolder(
+		a.context(ctx),
+		uint(id),
+		emailsServicePort.MoveFolderData(
+			*ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.MoveFolderData),
+		),
+	); err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Write success response
+	ctx.WriteResponse(204, nil)
+}
+
+// Emails
+
+// @Summary Clone email folder (admin)
+// @Description Copies the folder with its subfolders, emails and variants under the parent folder or to the top level if parent_id is null. Email keys of the copies get key_suffix (_copy by default), with a numeric suffix added if the key is taken. Copies are never system entities.
+// @Tags emails
+// @Security BearerAuth
+// @Accept json
+// @Produce json,plain
+// @Param id path int true "Folder ID"
+// @Param request body httpEmailsHandlerAdapterPort.CloneFolderData true "Clone email folder"
+// @Success 201 {object} httpEmailsHandlerAdapterPort.FolderResponse
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:invalid_parent, bad_request:invalid_name, bad_request:invalid_key_suffix, bad_request:folder_not_found, bad_request:folder_exist"
+// @Router /admin/notifications/emails/folders/{id}/clone [post]
+func (a *adapter) AdminCloneFolder(ctx server.ReqCtx) {
+	// Get and convert folder id to uint64
+	id, err := ctx.UserValueUint64("id")
+	if err != nil {
+		ctx.WriteErrorResponse(errors.ErrBadRequest)
+		return
+	}
+
+	// Clone email folder
+	folder, err := a.emailsService.CloneFolder(
+		ctx.Context(),
+		uint(id),
+		emailsServicePort.CloneFolderData(
+			*ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.CloneFolderData),
+		),
+	)
+	if err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Write success response
+	ctx.WriteResponse(201, httpEmailsHandlerAdapterPort.FolderResponse(*folder))
+}
+
+// @Summary Create email (admin)
+// @Tags emails
+// @Security BearerAuth
+// @Accept json
+// @Produce json,plain
+// @Param request body httpEmailsHandlerAdapterPort.CreateEmailData true "Create email"
+// @Success 201 {object} httpEmailsHandlerAdapterPort.EmailResponse
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:invalid_folder_id, bad_request:invalid_key, bad_request:invalid_sender_id, bad_request:invalid_subject, bad_request:invalid_html, bad_request:invalid_reply_to, bad_request:invalid_category, bad_request:invalid_header, bad_request:header_reserved, bad_request:invalid_pdf_attachment, bad_request:folder_not_found, bad_request:sender_not_found, bad_request:email_exist, bad_request:system_access"
+// @Router /admin/notifications/emails [post]
+func (a *adapter) AdminCreateEmail(ctx server.ReqCtx) {
+	// Create email
+	email, err := a.emailsService.CreateEmail(
+		a.context(ctx),
+		emailsServicePort.CreateEmailData(
+			*ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.CreateEmailData),
+		),
+	)
+	if err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Write success response
+	ctx.WriteResponse(201, httpEmailsHandlerAdapterPort.EmailResponse(*email))
+}
+
+// @Summary Filter emails (admin)
+// @Tags emails
+// @Security BearerAuth
+// @Accept json
+// @Produce json,plain
+// @Param request body httpEmailsHandlerAdapterPort.FilterEmailsData true "Filter emails"
+// @Success 200 {array} httpEmailsHandlerAdapterPort.EmailResponse
+// @Failure 400 {string} string "Possible error codes: bad_request"
+// @Router /admin/notifications/emails/filter [post]
+func (a *adapter) AdminFilterEmails(ctx server.ReqCtx) {
+	// Filter emails
+	emails, err := a.emailsService.FilterEmails(
+		ctx.Context(),
+		emailsServicePort.FilterEmailsData(
+			*ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.FilterEmailsData),
+		),
+	)
+	if err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Map service to adapter results
+	results := make([]httpEmailsHandlerAdapterPort.EmailResponse, 0, len(*emails))
+	for _, email := range *emails {
+		results = append(
+			results,
+			httpEmailsHandlerAdapterPort.EmailResponse(email),
+		)
+	}
+
+	// Write success response
+	ctx.WriteResponse(200, results)
+}
+
+// @Summary Search emails (admin)
+// @Description Full-text search over subject, key, description, content and folder name, combined with the filter fields. Matches are wrapped in <mark> tags in highlight and snippet.
+// @Tags emails
+// @Security BearerAuth
+// @Accept json
+// @Produce json,plain
+// @Param request body httpEmailsHandlerAdapterPort.SearchEmailsData true "Search emails"
+// @Success 200 {array} httpEmailsHandlerAdapterPort.SearchEmailResponse
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:invalid_query, bad_request:invalid_limit"
+// @Router /admin/notifications/emails/search [post]
+func (a *adapter) AdminSearchEmails(ctx server.ReqCtx) {
+	// Search emails
+	emails, err := a.emailsService.SearchEmails(
+		ctx.Context(),
+		emailsServicePort.SearchEmailsData(
+			*ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.SearchEmailsData),
+		),
+	)
+	if err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Map service to adapter results
+	results := make([]httpEmailsHandlerAdapterPort.SearchEmailResponse, 0, len(*emails))
+	for _, email := range *emails {
+		results = append(
+			results,
+			httpEmailsHandlerAdapterPort.SearchEmailResponse(email),
+		)
+	}
+
+	// Write success response
+	ctx.WriteResponse(200, results)
+}
+
+// @Summary Delete email (admin)
+// @Description Moves the email to the trash.
+// @Tags emails
+// @Security BearerAuth
+// @Produce plain
+// @Param id path int true "Email ID"
+// @Success 204
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:email_not_found, bad_request:system_entity"
+// @Router /admin/notifications/emails/{id} [delete]
+func (a *adapter) AdminDeleteEmail(ctx server.ReqCtx) {
+	// Get and convert email id to uint64
+	id, err := ctx.UserValueUint64("id")
+	if err != nil {
+		ctx.WriteErrorResponse(errors.ErrBadRequest)
+		return
+	}
+
+	// Delete email
+	if err := a.emailsService.DeleteEmail(
+		a.context(ctx),
+		uint(id),
+	); err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Write success response
+	ctx.WriteResponse(204, nil)
+}
+
+// @Summary Update email (admin)
+// @Tags emails
+// @Security BearerAuth
+// @Accept json
+// @Produce json,plain
+// @Param id path int true "Email ID"
+// @Param request body httpEmailsHandlerAdapterPort._UpdateEmailData true "Update email"
+// @Success 204
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:invalid_folder_id, bad_request:invalid_key, bad_request:invalid_sender_id, bad_request:invalid_subject, bad_request:invalid_html, bad_request:invalid_text, bad_request:invalid_reply_to, bad_request:invalid_category, bad_request:invalid_track_opens, bad_request:invalid_track_clicks, bad_request:invalid_header, bad_request:header_reserved, bad_request:invalid_pdf_attachment, bad_request:folder_not_found, bad_request:sender_not_found, bad_request:email_exist, bad_request:email_not_found, bad_request:system_entity, bad_request:system_access"
+// @Router /admin/notifications/emails/{id} [patch]
+func (a *adapter) AdminUpdateEmail(ctx server.ReqCtx) {
+	// Get and convert email id to uint64
+	id, err := ctx.UserValueUint64("id")
+	if err != nil {
+		ctx.WriteErrorResponse(errors.ErrBadRequest)
+		return
+	}
+
+	// Get data
+	data := ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.UpdateEmailData)
+
+	// Set email data
+	email := make(map[string]any)
+
+	if data.FolderId.Set {
+		email["folder_id"] = data.FolderId.Value
+	}
+	if data.Key.Set {
+		email["key"] = data.Key.Value
+	}
+	if data.SenderId.Set {
+		email["sender_id"] = data.SenderId.Value
+	}
+	if data.Subject.Set {
+		email["subject"] = data.Subject.Value
+	}
+	if data.Html.Set {
+		email["html"] = data.Html.Value
+	}
+	if data.Text.Set {
+		email["text"] = data.Text.Value
+	}
+	if data.ReplyTo.Set {
+		email["reply_to"] = data.ReplyTo.Value
+	}
+	if data.Headers.Set {
+		headers := map[string]string{}
+		if data.Headers.Value != nil {
+			headers = *data.Headers.Value
+		}
+		email["headers"] = headers
+	}
+	if data.PdfAttachments.Set {
+		templates := map[string]string{}
+		if data.PdfAttachments.Value != nil {
+			templates = *data.PdfAttachments.Value
+		}
+		email["pdf_attachments"] = templates
+	}
+	if data.Category.Set {
+		email["category"] = data.Category.Value
+	}
+	if data.TrackOpens.Set {
+		email["track_opens"] = data.TrackOpens.Value
+	}
+	if data.TrackClicks.Set {
+		email["track_clicks"] = data.TrackClicks.Value
+	}
+	if data.Description.Set {
+		email["description"] = data.Description.Value
+	}
+
+	// Update email
+	if err := a.emailsService.UpdateEmail(
+		a.context(ctx),
+		uint(id),
+		email,
+	); err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Write success response
+	ctx.WriteResponse(204, nil)
+}
+
+// @Summary Move emails (admin)
+// @Description Moves emails to the folder or to the top level if folder_id is null.
+// @Tags emails
+// @Security BearerAuth
+// @Accept json
+// @Produce plain
+// @Param request body httpEmailsHandlerAdapterPort.MoveEmailsData true "Move emails"
+// @Success 204
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:invalid_id, bad_request:invalid_folder_id, bad_request:folder_not_found, bad_request:email_not_found, bad_request:system_entity"
+// @Router /admin/notifications/emails/move [post]
+func (a *adapter) AdminMoveEmails(ctx server.ReqCtx) {
+	// Move emails
+	if err := a.emailsService.MoveEmails(
+		a.context(ctx),
+		emailsServicePort.MoveEmailsData(
+			*ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.MoveEmailsData),
+		),
+	); err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Write success response
+	ctx.WriteResponse(204, nil)
+}
+
+// @Summary Clone email (admin)
+// @Description Copies the email with its variants into the folder or to the top level if folder_id is null under a new key. The copy is never a system email.
+// @Tags emails
+// @Security BearerAuth
+// @Accept json
+// @Produce json,plain
+// @Param id path int true "Email ID"
+// @Param request body httpEmailsHandlerAdapterPort.CloneEmailData true "Clone email"
+// @Success 201 {object} httpEmailsHandlerAdapterPort.EmailResponse
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:invalid_folder_id, bad_request:invalid_key, bad_request:folder_not_found, bad_request:email_not_found, bad_request:email_exist"
+// @Router /admin/notifications/emails/{id}/clone [post]
+func (a *adapter) AdminCloneEmail(ctx server.ReqCtx) {
+	// Get and convert email id to uint64
+	id, err := ctx.UserValueUint64("id")
+	if err != nil {
+		ctx.WriteErrorResponse(errors.ErrBadRequest)
+		return
+	}
+
+	// Clone email
+	email, err := a.emailsService.CloneEmail(
+		ctx.Context(),
+		uint(id),
+		emailsServicePort.CloneEmailData(
+			*ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.CloneEmailData),
+		),
+	)
+	if err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Write success response
+	ctx.WriteResponse(201, httpEmailsHandlerAdapterPort.EmailResponse(*email))
+}
+
+// @Summary Send custom email
+// @Description The sender address, and the display name if set, must match an approved sender. Recipients are addresses with optional display names ("Name <addr>"); one log is returned per message sent.
+// @Tags emails
+// @Security BearerAuth
+// @Accept json
+// @Produce json,plain
+// @Param request body httpEmailsHandlerAdapterPort.SendCustomData true "Send custom email"
+// @Success 201 {array} httpEmailsHandlerAdapterPort.EmailLogResponse
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:invalid_from_email, bad_request:invalid_reply_to, bad_request:invalid_header, bad_request:header_reserved, bad_request:invalid_subject, bad_request:invalid_to, bad_request:invalid_cc, bad_request:invalid_bcc, bad_request:invalid_html, bad_request:invalid_attachments, bad_request:too_many_recipients, bad_request:sender_not_approved, bad_request:domain_not_verified, bad_request:file_not_found, bad_request:attachments_too_large, bad_request:attachment_type_not_allowed, bad_request:attachments_not_supported, bad_request:asset_not_found, bad_request:headers_not_supported"
+// @Router /notifications/emails/send/custom [post]
+func (a *adapter) SendCustom(ctx server.ReqCtx) {
+	// Get data
+	data := ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.SendCustomData)
+
+	// Send custom email
+	logs, err := a.emailsService.SendCustom(
+		ctx.Context(),
+		emailsServicePort.SendCustomData{
+			FromEmail:   data.FromEmail,
+			FromName:    data.FromName,
+			ReplyTo:     data.ReplyTo,
+			Headers:     data.Headers,
+			Subject:     data.Subject,
+			To:          data.To,
+			Cc:          data.Cc,
+			Bcc:         data.Bcc,
+			Html:        data.Html,
+			Text:        data.Text,
+			Attachments: attachmentsData(data.Attachments),
+		},
+	)
+	if err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Map service to adapter results
+	results := make([]httpEmailsHandlerAdapterPort.EmailLogResponse, 0, len(*logs))
+	for _, log := range *logs {
+		results = append(
+			results,
+			emailLogResponse(log),
+		)
+	}
+
+	// Write success response
+	ctx.WriteResponse(201, results)
+}
+
+// @Summary Send email
+// @Description Recipients are addresses with optional display names ("Name <addr>"); one log is returned per message sent. An event adds a calendar invitation, reuse the returned event_uid to update or cancel it.
+// @Tags emails
+// @Accept json
+// @Produce json,plain
+// @Param request body httpEmailsHandlerAdapterPort.SendData true "Send email"
+// @Success 201 {array} httpEmailsHandlerAdapterPort.EmailLogResponse
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:invalid_id, bad_request:invalid_to, bad_request:invalid_cc, bad_request:invalid_bcc, bad_request:invalid_attachments, bad_request:file_attachments_not_allowed, bad_request:invalid_event, bad_request:too_many_recipients, bad_request:email_not_found, bad_request:sender_not_found, bad_request:sender_not_set, bad_request:invalid_reply_to, bad_request:invalid_header, bad_request:domain_not_verified, bad_request:attachments_too_large, bad_request:attachment_type_not_allowed, bad_request:attachments_not_supported, bad_request:asset_not_found, bad_request:invalid_pdf_attachment, bad_request:calendar_not_supported, bad_request:headers_not_supported"
+// @Router /notifications/emails/send [post]
+func (a *adapter) Send(ctx server.ReqCtx) {
+	// Get data
+	data := ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.SendData)
+
+	// Map calendar event
+	var event *emailsServicePort.EventData
+	if data.Event != nil {
+		start, end, err := data.Event.Times()
+		if err != nil {
+			ctx.WriteErrorResponse(httpEmailsHandlerAdapterPort.ErrEmailInvalidEvent)
+			return
+		}
+		event = &emailsServicePort.EventData{
+			Uid:         data.Event.Uid,
+			Method:      strings.ToUpper(data.Event.Method),
+			Start:       start,
+			End:         end,
+			Location:    data.Event.Location,
+			Organizer:   data.Event.Organizer,
+			Summary:     data.Event.Summary,
+			Description: data.Event.Description,
+		}
+	}
+
+	// Send email
+	logs, err := a.emailsService.Send(
+		ctx.Context(),
+		emailsServicePort.SendData{
+			EmailId:     data.EmailId,
+			To:          data.To,
+			Cc:          data.Cc,
+			Bcc:         data.Bcc,
+			Vars:        data.Vars,
+			Attachments: attachmentsData(data.Attachments),
+			Event:       event,
+		},
+	)
+	if err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Map service to adapter results
+	results := make([]httpEmailsHandlerAdapterPort.EmailLogResponse, 0, len(*logs))
+	for _, log := range *logs {
+		results = append(
+			results,
+			emailLogResponse(log),
+		)
+	}
+
+	// Write success response
+	ctx.WriteResponse(201, results)
+}
+
+// @Summary Preview email (admin)
+// @Description Renders the email, or one of its variants, with vars as it would be sent, including generated pdf attachments. Nothing is sent or logged.
+// @Tags emails
+// @Security BearerAuth
+// @Accept json
+// @Produce json,plain
+// @Param request body httpEmailsHandlerAdapterPort.PreviewEmailData true "Preview email"
+// @Success 200 {object} httpEmailsHandlerAdapterPort.PreviewResponse
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:invalid_id, bad_request:invalid_variant_id, bad_request:email_not_found, bad_request:variant_not_found, bad_request:sender_not_found, bad_request:sender_not_set, bad_request:invalid_reply_to, bad_request:invalid_header, bad_request:invalid_pdf_attachment, bad_request:asset_not_found"
+// @Router /admin/notifications/emails/preview [post]
+func (a *adapter) AdminPreviewEmail(ctx server.ReqCtx) {
+	// Preview email
+	preview, err := a.emailsService.PreviewEmail(
+		ctx.Context(),
+		emailsServicePort.PreviewEmailData(
+			*ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.PreviewEmailData),
+		),
+	)
+	if err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Map service to adapter results
+	attachments := make([]httpEmailsHandlerAdapterPort.PreviewAttachmentResponse, 0, len(preview.Attachments))
+	for _, attachment := range preview.Attachments {
+		attachments = append(
+			attachments,
+			httpEmailsHandlerAdapterPort.PreviewAttachmentResponse(attachment),
+		)
+	}
+
+	// Write success response
+	ctx.WriteResponse(200, httpEmailsHandlerAdapterPort.PreviewResponse{
+		FromEmail:   preview.FromEmail,
+		FromName:    preview.FromName,
+		ReplyTo:     preview.ReplyTo,
+		Headers:     preview.Headers,
+		Subject:     preview.Subject,
+		Html:        preview.Html,
+		Text:        preview.Text,
+		Attachments: attachments,
+	})
+}
+
+// @Summary Filter email logs (admin)
+// @Tags emails
+// @Security BearerAuth
+// @Accept json
+// @Produce json,plain
+// @Param request body httpEmailsHandlerAdapterPort.FilterEmailLogsData true "Filter email logs (admin)"
+// @Success 200 {array} httpEmailsHandlerAdapterPort.EmailLogResponse
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:invalid_status"
+// @Router /admin/notifications/emails/logs/filter [post]
+func (a *adapter) AdminFilterEmailLogs(ctx server.ReqCtx) {
+	// Filter email logs
+	logs, err := a.emailsService.FilterEmailLogs(
+		ctx.Context(),
+		emailsServicePort.FilterEmailLogsData(
+			*ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.FilterEmailLogsData),
+		),
+	)
+	if err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Map service to adapter results
+	results := make([]httpEmailsHandlerAdapterPort.EmailLogResponse, 0, len(*logs))
+	for _, log := range *logs {
+		results = append(
+			results,
+			emailLogResponse(log),
+		)
+	}
+
+	// Write success response
+	ctx.WriteResponse(200, results)
+}
+
+// Delivery events
+
+// @Summary Delivery status webhook of smtp.bz
+// @Description Records delivery events of sent messages reported by smtp.bz. The token is the webhook secret. Hard bounces and complaints add the recipient to the suppression list.
+// @Tags emails
+// @Accept json
+// @Produce plain
+// @Param token path string true "Webhook secret"
+// @Success 204
+// @Failure 400 {string} string "Possible error codes: bad_request:invalid_webhook"
+// @Failure 401 {string} string "Possible error codes: unauthorized:invalid_webhook_token"
+// @Router /notifications/emails/webhooks/smtp-bz/{token} [post]
+func (a *adapter) SmtpBzWebhook(ctx server.ReqCtx) {
+	// Record delivery events
+	if err := a.emailsService.SmtpBzWebhook(
+		ctx.Context(),
+		ctx.UserValueStr("token"),
+		ctx.Body(),
+	); err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Write success response
+	ctx.WriteResponse(204, nil)
+}
+
+// Tracking
+
+// Transparent 1x1 gif of open tracking
+var trackingPixel = []byte{
+	0x47, 0x49, 0x46, 0x38, 0x39, 0x61, 0x01, 0x00, 0x01, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0xff, 0xff, 0xff, 0x21, 0xf9, 0x04, 0x01, 0x00, 0x00, 0x00, 0x00, 0x2c, 0x00, 0x00, 0x00, 0x00,
+	0x01, 0x00, 0x01, 0x00, 0x00, 0x02, 0x02, 0x44, 0x01, 0x00, 0x3b,
+}
+
+// @Summary Open tracking pixel
+// @Description Public route of the tracking pixel added to html of emails with open tracking, records the open against the email log. The pixel is served even if the open is not recorded, so that the message renders without broken images.
+// @Tags emails
+// @Produce image/gif
+// @Param token path string true "Tracking token"
+// @Success 200 {file} file
+// @Router /notifications/emails/opens/{token} [get]
+func (a *adapter) TrackOpen(ctx server.ReqCtx) {
+	// Record open
+	a.emailsService.TrackOpen(
+		ctx.Context(),
+		emailsServicePort.TrackOpenData{
+			Token:        ctx.UserValueStr("token"),
+			UserAgent:    ctx.UserAgent(),
+			RemoteAddr:   remoteAddr(ctx),
+			ForwardedFor: ctx.GetHeader("X-Forwarded-For"),
+		},
+	)
+
+	// Write success response
+	ctx.SetContentType("image/gif")
+	ctx.SetStatusCode(200)
+	ctx.Write(trackingPixel)
+}
+
+// @Summary Click redirect
+// @Description Public route of tracked links in html of emails with click tracking, records the click against the email log and redirects to the original url.
+// @Tags emails
+// @Produce plain
+// @Param token path string true "Tracking token"
+// @Success 302
+// @Failure 400 {string} string "Possible error codes: bad_request:invalid_tracking_token"
+// @Router /notifications/emails/clicks/{token} [get]
+func (a *adapter) TrackClick(ctx server.ReqCtx) {
+	// Record click
+	click, err := a.emailsService.TrackClick(
+		ctx.Context(),
+		emailsServicePort.TrackClickData{
+			Token:        ctx.UserValueStr("token"),
+			UserAgent:    ctx.UserAgent(),
+			RemoteAddr:   remoteAddr(ctx),
+			ForwardedFor: ctx.GetHeader("X-Forwarded-For"),
+		},
+	)
+	if err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Write success response
+	redirect(ctx, click.Url)
+}
+
+// @Summary Get email clicks report (admin)
+// @Description Clicks of tracked links of the email by url, unique clicks count email logs with at least one click of the url.
+// @Tags emails
+// @Security BearerAuth
+// @Produce json,plain
+// @Param id path int true "Email ID"
+// @Success 200 {array} httpEmailsHandlerAdapterPort.ClickReportResponse
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:email_not_found"
+// @Router /admin/notifications/emails/{id}/clicks/report [get]
+func (a *adapter) AdminGetClickReport(ctx server.ReqCtx) {
+	// Get and convert email id to uint64
+	id, err := ctx.UserValueUint64("id")
+	if err != nil {
+		ctx.WriteErrorResponse(errors.ErrBadRequest)
+		return
+	}
+
+	// Get email clicks report
+	report, err := a.emailsService.GetClickReport(
+		ctx.Context(),
+		uint(id),
+	)
+	if err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Map service to adapter results
+	results := make([]httpEmailsHandlerAdapterPort.ClickReportResponse, 0, len(*report))
+	for _, item := range *report {
+		results = append(
+			results,
+			httpEmailsHandlerAdapterPort.ClickReportResponse(item),
+		)
+	}
+
+	// Write success response
+	ctx.WriteResponse(200, results)
+}
+
+// Variants
+
+// @Summary Create email variant (admin)
+// @Tags emails
+// @Security BearerAuth
+// @Accept json
+// @Produce json,plain
+// @Param request body httpEmailsHandlerAdapterPort.CreateVariantData true "Create email variant"
+// @Success 201 {object} httpEmailsHandlerAdapterPort.VariantResponse
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:invalid_email_id, bad_request:invalid_name, bad_request:invalid_subject, bad_request:invalid_html, bad_request:invalid_weight, bad_request:email_not_found, bad_request:system_access"
+// @Router /admin/notifications/emails/variants [post]
+func (a *adapter) AdminCreateVariant(ctx server.ReqCtx) {
+	// Create email variant
+	variant, err := a.emailsService.CreateVariant(
+		a.context(ctx),
+		emailsServicePort.CreateVariantData(
+			*ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.CreateVariantData),
+		),
+	)
+	if err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Write success response
+	ctx.WriteResponse(201, httpEmailsHandlerAdapterPort.VariantResponse(*variant))
+}
+
+// @Summary Filter email variants (admin)
+// @Tags emails
+// @Security BearerAuth
+// @Accept json
+// @Produce json,plain
+// @Param request body httpEmailsHandlerAdapterPort.FilterVariantsData true "Filter email variants"
+// @Success 200 {array} httpEmailsHandlerAdapterPort.VariantResponse
+// @Failure 400 {string} string "Possible error codes: bad_request"
+// @Router /admin/notifications/emails/variants/filter [post]
+func (a *adapter) AdminFilterVariants(ctx server.ReqCtx) {
+	// Filter email variants
+	variants, err := a.emailsService.FilterVariants(
+		ctx.Context(),
+		emailsServicePort.FilterVariantsData(
+			*ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.FilterVariantsData),
+		),
+	)
+	if err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Map service to adapter results
+	results := make([]httpEmailsHandlerAdapterPort.VariantResponse, 0, len(*variants))
+	for _, variant := range *variants {
+		results = append(
+			results,
+			httpEmailsHandlerAdapterPort.VariantResponse(variant),
+		)
+	}
+
+	// Write success response
+	ctx.WriteResponse(200, results)
+}
+
+// @Summary Delete email variant (admin)
+// @Tags emails
+// @Security BearerAuth
+// @Produce plain
+// @Param id path int true "Variant ID"
+// @Success 204
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:variant_not_found, bad_request:system_access"
+// @Router /admin/notifications/emails/variants/{id} [delete]
+func (a *adapter) AdminDeleteVariant(ctx server.ReqCtx) {
+	// Get and convert variant id to uint64
+	id, err := ctx.UserValueUint64("id")
+	if err != nil {
+		ctx.WriteErrorResponse(errors.ErrBadRequest)
+		return
+	}
+
+	// Delete email variant
+	if err := a.emailsService.DeleteVariant(
+		a.context(ctx),
+		uint(id),
+	); err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Write success response
+	ctx.WriteResponse(204, nil)
+}
+
+// @Summary Update email variant (admin)
+// @Tags emails
+// @Security BearerAuth
+// @Accept json
+// @Produce json,plain
+// @Param id path int true "Variant ID"
+// @Param request body httpEmailsHandlerAdapterPort._UpdateVariantData true "Update email variant"
+// @Success 204
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:invalid_name, bad_request:invalid_subject, bad_request:invalid_html, bad_request:invalid_weight, bad_request:invalid_active, bad_request:variant_not_found, bad_request:system_access"
+// @Router /admin/notifications/emails/variants/{id} [patch]
+func (a *adapter) AdminUpdateVariant(ctx server.ReqCtx) {
+	// Get and convert variant id to uint64
+	id, err := ctx.UserValueUint64("id")
+	if err != nil {
+		ctx.WriteErrorResponse(errors.ErrBadRequest)
+		return
+	}
+
+	// Get data
+	data := ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.UpdateVariantData)
+
+	// Set variant data
+	variant := make(map[string]any)
+
+	if data.Name.Set {
+		variant["name"] = data.Name.Value
+	}
+	if data.Subject.Set {
+		variant["subject"] = data.Subject.Value
+	}
+	if data.Html.Set {
+		variant["html"] = data.Html.Value
+	}
+	if data.Text.Set {
+		variant["text"] = data.Text.Value
+	}
+	if data.Weight.Set {
+		variant["weight"] = data.Weight.Value
+	}
+	if data.Active.Set {
+		variant["active"] = data.Active.Value
+	}
+
+	// Update email variant
+	if err := a.emailsService.UpdateVariant(
+		a.context(ctx),
+		uint(id),
+		variant,
+	); err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Write success response
+	ctx.WriteResponse(204, nil)
+}
+
+// @Summary Get email variants report (admin)
+// @Tags emails
+// @Security BearerAuth
+// @Produce json,plain
+// @Param id path int true "Email ID"
+// @Success 200 {array} httpEmailsHandlerAdapterPort.VariantReportResponse
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:email_not_found"
+// @Router /admin/notifications/emails/{id}/variants/report [get]
+func (a *adapter) AdminGetVariantReport(ctx server.ReqCtx) {
+	// Get and convert email id to uint64
+	id, err := ctx.UserValueUint64("id")
+	if err != nil {
+		ctx.WriteErrorResponse(errors.ErrBadRequest)
+		return
+	}
+
+	// Get email variants report
+	report, err := a.emailsService.GetVariantReport(
+		ctx.Context(),
+		uint(id),
+	)
+	if err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Map service to adapter results
+	results := make([]httpEmailsHandlerAdapterPort.VariantReportResponse, 0, len(*report))
+	for _, item := range *report {
+		results = append(
+			results,
+			httpEmailsHandlerAdapterPort.VariantReportResponse(item),
+		)
+	}
+
+	// Write success response
+	ctx.WriteResponse(200, results)
+}
+
+// @Summary End email experiment (admin)
+// @Description Deactivates all variants of the email. If a winner is set, its subject and body are promoted to the email.
+// @Tags emails
+// @Security BearerAuth
+// @Accept json
+// @Produce plain
+// @Param id path int true "Email ID"
+// @Param request body httpEmailsHandlerAdapterPort.EndExperimentData true "End email experiment"
+// @Success 204
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:invalid_winner_id, bad_request:email_not_found, bad_request:variant_not_found, bad_request:system_access"
+// @Router /admin/notifications/emails/{id}/variants/end [post]
+func (a *adapter) AdminEndExperiment(ctx server.ReqCtx) {
+	// Get and convert email id to uint64
+	id, err := ctx.UserValueUint64("id")
+	if err != nil {
+		ctx.WriteErrorResponse(errors.ErrBadRequest)
+		return
+	}
+
+	// End email experiment
+	if err := a.emailsService.EndExperiment(
+		a.context(ctx),
+		uint(id),
+		emailsServicePort.EndExperimentData(
+			*ctx.GetJsonBody().(*httpEmailsHandlerAdapterPort.EndExperimentData),
+		),
+	); err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Write success response
+	ctx.WriteResponse(204, nil)
+}
+
+// Bundles
+
+// @Summary Export email templates bundle (admin)
+// @Description Exports a folder subtree (or the whole tree) with its emails and variants as a portable bundle.
+// @Tags emails
+// @Security BearerAuth
+// @Produce json,application/zip,plain
+// @Param folder_id query int false "Root folder ID"
+// @Param format query string false "Bundle format" Enums(json, zip) default(json)
+// @Success 200 {object} emailsServicePort.Bundle
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:invalid_folder_id, bad_request:invalid_format, bad_request:folder_not_found"
+// @Router /admin/notifications/emails/bundles/export [get]
+func (a *adapter) AdminExportBundle(ctx server.ReqCtx) {
+	// Get data
+	folderId, err := queryUint(ctx, "folder_id")
+	if err != nil {
+		ctx.WriteErrorResponse(errors.ErrBadRequest)
+		return
+	}
+	data := httpEmailsHandlerAdapterPort.ExportBundleData{
+		FolderId: folderId,
+		Format:   queryStr(ctx, "format", "json"),
+	}
+
+	// Validate data
+	if err := data.Validate(); err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Export bundle
+	bundle, err := a.emailsService.ExportBundle(
+		ctx.Context(),
+		emailsServicePort.ExportBundleData(data),
+	)
+	if err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Write success response
+	if bundle.Format == emailsServicePort.BundleFormatZip {
+		ctx.SetContentType("application/zip")
+	} else {
+		ctx.SetContentType("application/json; charset=utf-8")
+	}
+	ctx.SetStatusCode(200)
+	ctx.Write(bundle.Data)
+}
+
+// @Summary Import email templates bundle (admin)
+// @Description Imports a bundle under the parent folder. Folders are matched by path and emails by key. With prune=true (overwrite strategy only) folders and emails under the parent that are missing from the bundle are deleted. By default only the plan is returned; set dry_run=false to apply it in a single transaction.
+// @Tags emails
+// @Security BearerAuth
+// @Accept json,application/zip
+// @Produce json,plain
+// @Param parent_id query int false "Target parent folder ID"
+// @Param strategy query string false "Conflict strategy" Enums(skip, overwrite, rename) default(skip)
+// @Param prune query bool false "Delete entities missing from the bundle" default(false)
+// @Param dry_run query bool false "Only return the plan" default(true)
+// @Param request body emailsServicePort.Bundle true "Bundle"
+// @Success 200 {array} httpEmailsHandlerAdapterPort.ImportActionResponse
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:invalid_parent_id, bad_request:invalid_strategy, bad_request:invalid_prune, bad_request:invalid_format, bad_request:invalid_bundle, bad_request:folder_not_found, bad_request:system_entity, bad_request:system_access"
+// @Router /admin/notifications/emails/bundles/import [post]
+func (a *adapter) AdminImportBundle(ctx server.ReqCtx) {
+	// Get data
+	parentId, err := queryUint(ctx, "parent_id")
+	if err != nil {
+		ctx.WriteErrorResponse(errors.ErrBadRequest)
+		return
+	}
+	prune, err := strconv.ParseBool(queryStr(ctx, "prune", "false"))
+	if err != nil {
+		ctx.WriteErrorResponse(errors.ErrBadRequest)
+		return
+	}
+	dryRun, err := strconv.ParseBool(queryStr(ctx, "dry_run", "true"))
+	if err != nil {
+		ctx.WriteErrorResponse(errors.ErrBadRequest)
+		return
+	}
+	format := emailsServicePort.BundleFormatJson
+	if strings.HasPrefix(ctx.GetHeader("Content-Type"), "application/zip") {
+		format = emailsServicePort.BundleFormatZip
+	}
+	data := httpEmailsHandlerAdapterPort.ImportBundleData{
+		ParentId: parentId,
+		Strategy: queryStr(ctx, "strategy", "skip"),
+		Prune:    prune,
+		DryRun:   dryRun,
+		Format:   format,
+		Bundle:   ctx.Body(),
+	}
+
+	// Validate data
+	if err := data.Validate(); err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Import bundle
+	actions, err := a.emailsService.ImportBundle(
+		a.context(ctx),
+		emailsServicePort.ImportBundleData(data),
+	)
+	if err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Map service to adapter results
+	results := make([]httpEmailsHandlerAdapterPort.ImportActionResponse, 0, len(*actions))
+	for _, action := range *actions {
+		results = append(
+			results,
+			httpEmailsHandlerAdapterPort.ImportActionResponse(action),
+		)
+	}
+
+	// Write success response
+	ctx.WriteResponse(200, results)
+}
+
+// @Summary Filter trash (admin)
+// @Description Returns deleted folders and emails with the time they expire and are purged.
+// @Tags emails
+// @Security BearerAuth
+// @Produce json,plain
+// @Success 200 {object} httpEmailsHandlerAdapterPort.TrashResponse
+// @Failure 400 {string} string "Possible error codes: bad_request"
+// @Router /admin/notifications/emails/trash [get]
+func (a *adapter) AdminFilterTrash(ctx server.ReqCtx) {
+	// Get trash
+	trash, err := a.emailsService.FilterTrash(ctx.Context())
+	if err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Map service to adapter results
+	results := httpEmailsHandlerAdapterPort.TrashResponse{
+		Folders: make([]httpEmailsHandlerAdapterPort.TrashFolderResponse, 0, len(trash.Folders)),
+		Emails:  make([]httpEmailsHandlerAdapterPort.TrashEmailResponse, 0, len(trash.Emails)),
+	}
+	for _, folder := range trash.Folders {
+		results.Folders = append(
+			results.Folders,
+			httpEmailsHandlerAdapterPort.TrashFolderResponse(folder),
+		)
+	}
+	for _, email := range trash.Emails {
+		results.Emails = append(
+			results.Emails,
+			httpEmailsHandlerAdapterPort.TrashEmailResponse(email),
+		)
+	}
+
+	// Write success response
+	ctx.WriteResponse(200, results)
+}
+
+// @Summary Restore email folder (admin)
+// @Description Restores the folder from the trash together with the subfolders and emails deleted with it.
+// @Tags emails
+// @Security BearerAuth
+// @Produce plain
+// @Param id path int true "Folder ID"
+// @Success 204
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:folder_not_found, bad_request:trash_expired, bad_request:parent_deleted, bad_request:folder_exist, bad_request:email_exist"
+// @Router /admin/notifications/emails/folders/{id}/restore [post]
+func (a *adapter) AdminRestoreFolder(ctx server.ReqCtx) {
+	// Get and convert folder id to uint64
+	id, err := ctx.UserValueUint64("id")
+	if err != nil {
+		ctx.WriteErrorResponse(errors.ErrBadRequest)
+		return
+	}
+
+	// Restore email folder
+	if err := a.emailsService.RestoreFolder(
+		ctx.Context(),
+		uint(id),
+	); err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Write success response
+	ctx.WriteResponse(204, nil)
+}
+
+// @Summary Restore email (admin)
+// @Tags emails
+// @Security BearerAuth
+// @Produce plain
+// @Param id path int true "Email ID"
+// @Success 204
+// @Failure 400 {string} string "Possible error codes: bad_request, bad_request:email_not_found, bad_request:trash_expired, bad_request:parent_deleted, bad_request:email_exist"
+// @Router /admin/notifications/emails/{id}/restore [post]
+func (a *adapter) AdminRestoreEmail(ctx server.ReqCtx) {
+	// Get and convert email id to uint64
+	id, err := ctx.UserValueUint64("id")
+	if err != nil {
+		ctx.WriteErrorResponse(errors.ErrBadRequest)
+		return
+	}
+
+	// Restore email
+	if err := a.emailsService.RestoreEmail(
+		ctx.Context(),
+		uint(id),
+	); err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+
+	// Write success response
+	ctx.WriteResponse(204, nil)
+}
+
+// Map service folders tree to adapter results
+func folderTreeResponse(items []emailsServicePort.FolderTreeResult) []httpEmailsHandlerAdapterPort.FolderTreeResponse {
+	results := make([]httpEmailsHandlerAdapterPort.FolderTreeResponse, 0, len(items))
+	for _, item := range items {
+		breadcrumbs := make([]httpEmailsHandlerAdapterPort.FolderBreadcrumbResponse, 0, len(item.Breadcrumbs))
+		for _, breadcrumb := range item.Breadcrumbs {
+			breadcrumbs = append(
+				breadcrumbs,
+				httpEmailsHandlerAdapterPort.FolderBreadcrumbResponse(breadcrumb),
+			)
+		}
+		results = append(
+			results,
+			httpEmailsHandlerAdapterPort.FolderTreeResponse{
+				Id:          item.Id,
+				ParentId:    item.ParentId,
+				Name:        item.Name,
+				Description: item.Description,
+				SystemFlag:  item.SystemFlag,
+				Path:        item.Path,
+				Breadcrumbs: breadcrumbs,
+				Emails:      item.Emails,
+				TotalEmails: item.TotalEmails,
+				Children:    folderTreeResponse(item.Children),
+				Updated:     item.Updated,
+				Created:     item.Created,
+			},
+		)
+	}
+	return results
+}
+
+func domainResponse(domain emailsServicePort.DomainResult) httpEmailsHandlerAdapterPort.DomainResponse {
+	keys := make([]httpEmailsHandlerAdapterPort.DomainKeyResponse, 0, len(domain.Keys))
+	for _, key := range domain.Keys {
+		keys = append(
+			keys,
+			httpEmailsHandlerAdapterPort.DomainKeyResponse(key),
+		)
+	}
+	records := make([]httpEmailsHandlerAdapterPort.DnsRecordResponse, 0, len(domain.Records))
+	for _, record := range domain.Records {
+		records = append(
+			records,
+			httpEmailsHandlerAdapterPort.DnsRecordResponse(record),
+		)
+	}
+	return httpEmailsHandlerAdapterPort.DomainResponse{
+		Id:            domain.Id,
+		Name:          domain.Name,
+		SpfVerified:   domain.SpfVerified,
+		DkimVerified:  domain.DkimVerified,
+		DmarcVerified: domain.DmarcVerified,
+		Verified:      domain.Verified,
+		Keys:          keys,
+		Records:       records,
+		Checked:       domain.Checked,
+		Updated:       domain.Updated,
+		Created:       domain.Created,
+	}
+}
+
+func attachmentsData(attachments []httpEmailsHandlerAdapterPort.AttachmentData) []emailsServicePort.AttachmentData {
+	results := make([]emailsServicePort.AttachmentData, 0, len(attachments))
+	for _, attachment := range attachments {
+		results = append(
+			results,
+			emailsServicePort.AttachmentData(attachment),
+		)
+	}
+	return results
+}
+
+func emailLogResponse(log emailsServicePort.EmailLogResult) httpEmailsHandlerAdapterPort.EmailLogResponse {
+	attachments := make([]httpEmailsHandlerAdapterPort.EmailLogAttachmentResponse, 0, len(log.Attachments))
+	for _, attachment := range log.Attachments {
+		attachments = append(
+			attachments,
+			httpEmailsHandlerAdapterPort.EmailLogAttachmentResponse(attachment),
+		)
+	}
+	events := make([]httpEmailsHandlerAdapterPort.DeliveryEventResponse, 0, len(log.Events))
+	for _, event := range log.Events {
+		events = append(
+			events,
+			httpEmailsHandlerAdapterPort.DeliveryEventResponse(event),
+		)
+	}
+	transitions := make([]httpEmailsHandlerAdapterPort.EmailLogTransitionResponse, 0, len(log.Transitions))
+	for _, transition := range log.Transitions {
+		transitions = append(
+			transitions,
+			httpEmailsHandlerAdapterPort.EmailLogTransitionResponse(transition),
+		)
+	}
+	opens := make([]httpEmailsHandlerAdapterPort.EmailOpenResponse, 0, len(log.Opens))
+	for _, open := range log.Opens {
+		opens = append(
+			opens,
+			httpEmailsHandlerAdapterPort.EmailOpenResponse(open),
+		)
+	}
+	clicks := make([]httpEmailsHandlerAdapterPort.EmailClickResponse, 0, len(log.Clicks))
+	for _, click := range log.Clicks {
+		clicks = append(
+			clicks,
+			httpEmailsHandlerAdapterPort.EmailClickResponse(click),
+		)
+	}
+	return httpEmailsHandlerAdapterPort.EmailLogResponse{
+		Id:          log.Id,
+		EmailId:     log.EmailId,
+		VariantId:   log.VariantId,
+		FromEmail:   log.FromEmail,
+		FromName:    log.FromName,
+		Subject:     log.Subject,
+		To:          log.To,
+		Cc:          log.Cc,
+		Bcc:         log.Bcc,
+		Html:        log.Html,
+		Text:        log.Text,
+		Status:      log.Status,
+		MessageId:   log.MessageId,
+		Errors:      log.Errors,
+		Attachments: attachments,
+		EventUid:    log.EventUid,
+		Links:       log.Links,
+		Events:      events,
+		Transitions: transitions,
+		Opens:       opens,
+		Clicks:      clicks,
+		Created:     log.Created,
+	}
+}
+
+// Write html page rendered with data
+func writePage(ctx server.ReqCtx, page *template.Template, data any) {
+	var body bytes.Buffer
+	if err := page.Execute(&body, data); err != nil {
+		ctx.WriteErrorResponse(err)
+		return
+	}
+	ctx.SetContentType("text/html; charset=utf-8")
+	ctx.SetStatusCode(200)
+	ctx.Write(body.Bytes())
+}
+
+// Page of a redirect for request contexts unable to redirect
+var redirectPage = template.Must(template.New("redirect").Parse(`<!DOCTYPE html>
+<html><head><meta charset="utf-8"><meta name="viewport" content="width=device-width, initial-scale=1"><title>Redirect</title></head>
+<body><p><a href="{{.}}">Continue</a></p></body></html>
+`))
+
+// Request context able to redirect, the server request context wraps the fasthttp one
+type redirector interface {
+	Redirect(uri string, statusCode int)
+}
+
+// Redirect the client to the url with 302 Found
+func redirect(ctx server.ReqCtx, url string) {
+	if r, ok := ctx.(redirector); ok {
+		r.Redirect(url, 302)
+		return
+	}
+	writePage(ctx, redirectPage, url)
+}
+
+// Address of the connection, empty if unknown
+func remoteAddr(ctx server.ReqCtx) string {
+	if addr := ctx.RemoteAddr(); addr != nil {
+		return addr.String()
+	}
+	return ""
+}
+
+// Get query string value or default
+func queryStr(ctx server.ReqCtx, key, def string) string {
+	if v := ctx.Request().URI().QueryArgs().Peek(key); len(v) > 0 {
+		return string(v)
+	}
+	return def
+}
+
+// Get optional query uint value
+func queryUint(ctx server.ReqCtx, key string) (*uint, error) {
+	v := queryStr(ctx, key, "")
+	if v == "" {
+		return nil, nil
+	}
+	id, err := strconv.ParseUint(v, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	result := uint(id)
+	return &result, nil
+}
+
+// Get request context with system access for the system role
+func (a *adapter) context(ctx server.ReqCtx) context.Context {
+	if role, _ := ctx.UserValue("role").(string); a.systemRole != "" && role == a.systemRole {
+		return emailsServicePort.WithSystemAccess(ctx.Context())
+	}
+	return ctx.Context()
+}
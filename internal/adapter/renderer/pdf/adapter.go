@@ -0,0 +1,181 @@
+package adapter
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"strings"
+
+	pdfRendererAdapterPort "github.com/flash-go/notifications-service/internal/port/adapter/renderer/pdf"
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/font/gofont/gomonobold"
+)
+
+// A4 page in points with uniform margins
+const (
+	pageWidth  = 595
+	pageHeight = 842
+	pageMargin = 50
+)
+
+// Go Mono subsets are embedded, its glyphs are 600/1000 of the font size wide
+const (
+	textFont    = "F1"
+	textSize    = 10
+	headingFont = "F2"
+	headingSize = 14
+	glyphWidth  = 0.6
+	lineSpacing = 1.4
+)
+
+func New() pdfRendererAdapterPort.Interface {
+	return &adapter{}
+}
+
+type adapter struct{}
+
+type line struct {
+	font string
+	size float64
+	text string
+}
+
+func (a *adapter) Render(content string) ([]byte, error) {
+	// Lay out lines into pages
+	var pages [][]line
+	var page []line
+	y := float64(pageHeight - pageMargin)
+	for _, item := range layout(content) {
+		height := item.size * lineSpacing
+		if y-height < pageMargin && len(page) > 0 {
+			pages = append(pages, page)
+			page = nil
+			y = pageHeight - pageMargin
+		}
+		y -= height
+		page = append(page, item)
+	}
+	pages = append(pages, page)
+
+	// Load fonts, glyphs used by the content streams make up the subsets
+	fonts := make(map[string]*font)
+	for name, data := range map[string][]byte{textFont: gomono.TTF, headingFont: gomonobold.TTF} {
+		f, err := newFont(data)
+		if err != nil {
+			return nil, err
+		}
+		fonts[name] = f
+	}
+
+	// Write objects: catalog, pages, page and content pairs, then text and heading fonts
+	var objects []string
+	objects = append(objects, "<< /Type /Catalog /Pages 2 0 R >>")
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 3+i*2)
+	}
+	objects = append(objects, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	textFontObject := 3 + len(pages)*2
+	headingFontObject := textFontObject + fontObjects
+	for i, lines := range pages {
+		stream, err := contentStream(lines, fonts)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /%s %d 0 R /%s %d 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, textFont, textFontObject, headingFont, headingFontObject, 4+i*2,
+		))
+		objects = append(objects, fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", len(stream), stream))
+	}
+	for _, name := range []string{textFont, headingFont} {
+		written, err := fonts[name].objects(len(objects) + 1)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, written...)
+	}
+
+	// Write document with cross-reference table
+	var doc bytes.Buffer
+	doc.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = doc.Len()
+		fmt.Fprintf(&doc, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	xref := doc.Len()
+	fmt.Fprintf(&doc, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&doc, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&doc, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	return doc.Bytes(), nil
+}
+
+// Split content into lines wrapped to the page width
+func layout(content string) []line {
+	var results []line
+	content = strings.ReplaceAll(strings.ReplaceAll(content, "\r\n", "\n"), "\t", "    ")
+	for _, text := range strings.Split(content, "\n") {
+		item := line{font: textFont, size: textSize, text: text}
+		if heading, ok := strings.CutPrefix(text, "# "); ok {
+			item = line{font: headingFont, size: headingSize, text: heading}
+		}
+		width := int((pageWidth - 2*pageMargin) / (item.size * glyphWidth))
+		for _, wrapped := range wrap(item.text, width) {
+			results = append(results, line{font: item.font, size: item.size, text: wrapped})
+		}
+	}
+	return results
+}
+
+// Wrap text at spaces, words longer than the width are broken
+func wrap(text string, width int) []string {
+	runes := []rune(strings.TrimRight(text, " "))
+	if len(runes) <= width {
+		return []string{string(runes)}
+	}
+	var results []string
+	for len(runes) > width {
+		cut := width
+		for i := width; i > 0; i-- {
+			if runes[i] == ' ' {
+				cut = i
+				break
+			}
+		}
+		results = append(results, strings.TrimRight(string(runes[:cut]), " "))
+		runes = []rune(strings.TrimLeft(string(runes[cut:]), " "))
+	}
+	return append(results, string(runes))
+}
+
+// Compressed page content with one text object per line
+func contentStream(lines []line, fonts map[string]*font) (string, error) {
+	var content bytes.Buffer
+	y := float64(pageHeight - pageMargin)
+	for _, item := range lines {
+		y -= item.size * lineSpacing
+		text, err := fonts[item.font].encode(item.text)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&content, "BT /%s %g Tf 1 0 0 1 %d %g Tm %s Tj ET\n", item.font, item.size, pageMargin, y, text)
+	}
+	return deflate(content.Bytes())
+}
+
+// Zlib compressed stream data
+func deflate(data []byte) (string, error) {
+	var stream bytes.Buffer
+	writer := zlib.NewWriter(&stream)
+	if _, err := writer.Write(data); err != nil {
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+	return stream.String(), nil
+}
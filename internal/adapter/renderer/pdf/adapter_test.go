@@ -0,0 +1,184 @@
+package adapter
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+var testLengthRegexp = regexp.MustCompile(`^<<[^\n]*/Length (\d+)`)
+
+// Objects of the document read through the cross-reference table, streams are inflated
+func testObjects(t *testing.T, document []byte) []string {
+	t.Helper()
+	start := bytes.LastIndex(document, []byte("startxref\n"))
+	if !bytes.HasPrefix(document, []byte("%PDF-1.4\n")) || start < 0 {
+		t.Fatalf("document without header or startxref")
+	}
+	xref, _ := strconv.Atoi(strings.Fields(string(document[start+len("startxref\n"):]))[0])
+	entries := strings.Split(string(document[xref:]), "\n")
+	count, _ := strconv.Atoi(strings.Fields(entries[1])[1])
+
+	var objects []string
+	for i := 1; i < count; i++ {
+		offset, _ := strconv.Atoi(strings.Fields(entries[2+i])[0])
+		header := fmt.Sprintf("%d 0 obj\n", i)
+		if !bytes.HasPrefix(document[offset:], []byte(header)) {
+			t.Fatalf("xref entry %d does not point to its object", i)
+		}
+		object := string(document[offset+len(header):])
+		if match := testLengthRegexp.FindStringSubmatch(object); match != nil {
+			length, _ := strconv.Atoi(match[1])
+			data := object[strings.Index(object, "stream\n")+len("stream\n"):][:length]
+			reader, err := zlib.NewReader(strings.NewReader(data))
+			if err != nil {
+				t.Fatalf("object %d: %v", i, err)
+			}
+			inflated, err := io.ReadAll(reader)
+			if err != nil {
+				t.Fatalf("object %d: %v", i, err)
+			}
+			object = object[:strings.Index(object, "stream\n")] + "stream\n" + string(inflated)
+		} else {
+			object = object[:strings.Index(object, "\nendobj")]
+		}
+		objects = append(objects, object)
+	}
+	return objects
+}
+
+func TestRender(t *testing.T) {
+	document, err := New().Render("# Счёт №42\n\nСумма:  1 200 €\nΕλληνικά — “quoted”\nCJK 中 (fallback)")
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	objects := testObjects(t, document)
+
+	// Catalog, pages, one page with its content, then two fonts of five objects each
+	if len(objects) != 2+2+2*fontObjects {
+		t.Fatalf("Render() wrote %d objects", len(objects))
+	}
+	if !strings.Contains(objects[2], "/Font << /F1 5 0 R /F2 10 0 R >>") {
+		t.Errorf("page resources = %s", objects[2])
+	}
+	for i, subtype := range []string{"/Type0", "/CIDFontType2", "/FontDescriptor", "/Length1", "/CIDInit"} {
+		if !strings.Contains(objects[4+i], subtype) || !strings.Contains(objects[4+fontObjects+i], subtype) {
+			t.Errorf("font object %d is not %s", i, subtype)
+		}
+	}
+	if !regexp.MustCompile(`/BaseFont /[A-Z]{6}\+GoMono /`).MatchString(objects[4]) || !strings.Contains(objects[4+fontObjects], "+GoMono-Bold ") {
+		t.Errorf("font names %s, %s", objects[4], objects[4+fontObjects])
+	}
+
+	// Text is encoded as glyph ids of the font, characters the font lacks become "?"
+	regular, err := newFont(gomono.TTF)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, text := range []string{"Сумма:  1 200 €", "Ελληνικά — “quoted”", "CJK ? (fallback)"} {
+		encoded, err := regular.encode(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(objects[3], " "+encoded+" Tj") {
+			t.Errorf("content stream does not show %q as %s", text, encoded)
+		}
+	}
+
+	// Glyphs are mapped back to characters and are 600/1000 wide
+	for _, r := range "С€Ε“?" {
+		glyph, _ := regular.glyph(r)
+		if mapping := fmt.Sprintf("<%04x> <%04x>", glyph, r); !strings.Contains(objects[8], mapping) {
+			t.Errorf("ToUnicode map lacks %s for %q", mapping, r)
+		}
+		if width := fmt.Sprintf(" %d [600]", glyph); !strings.Contains(objects[5], width) {
+			t.Errorf("widths lack %s for %q", width, r)
+		}
+	}
+
+	// Font file keeps outlines of used glyphs only
+	file := objects[7][strings.Index(objects[7], "stream\n")+len("stream\n"):]
+	subset, err := sfnt.Parse([]byte(file))
+	if err != nil {
+		t.Fatalf("embedded font: %v", err)
+	}
+	var buffer sfnt.Buffer
+	for r, want := range map[rune]bool{'С': true, '€': true, '?': true, 'Z': false, 'Ж': false} {
+		glyph, _ := regular.glyph(r)
+		segments, err := subset.LoadGlyph(&buffer, glyph, fixed.I(10), nil)
+		if err != nil {
+			t.Fatalf("embedded glyph %q: %v", r, err)
+		}
+		if len(segments) > 0 != want {
+			t.Errorf("embedded glyph %q has %d segments, want outline %v", r, len(segments), want)
+		}
+	}
+	if len(file) > len(gomono.TTF)/4 {
+		t.Errorf("embedded font is %d bytes, not subset", len(file))
+	}
+}
+
+func TestSubsetFontComposite(t *testing.T) {
+	// Font with a simple glyph 1, unused glyph 2 and glyph 3 composed of glyph 1
+	simple := []byte{0, 1, 0, 0, 0, 0, 0, 10, 0, 10, 0, 0, 0, 0, 1, 0, 0, 0}
+	unused := append([]byte{}, simple...)
+	composite := []byte{0xff, 0xff, 0, 0, 0, 0, 0, 10, 0, 10, 0x00, 0x02, 0, 1, 0, 0}
+	var glyf, loca []byte
+	for _, outline := range [][]byte{nil, simple, unused, composite} {
+		loca = binary.BigEndian.AppendUint32(loca, uint32(len(glyf)))
+		glyf = append(glyf, outline...)
+		for len(glyf)%4 != 0 {
+			glyf = append(glyf, 0)
+		}
+	}
+	loca = binary.BigEndian.AppendUint32(loca, uint32(len(glyf)))
+	head := make([]byte, 54)
+	binary.BigEndian.PutUint16(head[50:], 1)
+	maxp := []byte{0, 0, 0x50, 0, 0, 4}
+
+	tables := [][]byte{glyf, head, loca, maxp}
+	var font bytes.Buffer
+	binary.Write(&font, binary.BigEndian, []uint16{1, 0, 4, 64, 2, 0})
+	offset := 12 + 16*len(tables)
+	for i, tag := range []string{"glyf", "head", "loca", "maxp"} {
+		font.WriteString(tag)
+		binary.Write(&font, binary.BigEndian, []uint32{0, uint32(offset), uint32(len(tables[i]))})
+		offset += len(tables[i])
+	}
+	for _, table := range tables {
+		font.Write(table)
+	}
+
+	result, err := subsetFont(font.Bytes(), []sfnt.GlyphIndex{3})
+	if err != nil {
+		t.Fatalf("subsetFont() error = %v", err)
+	}
+
+	// Read rebuilt loca and check glyph lengths
+	directory := make(map[string][]byte)
+	for i := range int(binary.BigEndian.Uint16(result[4:])) {
+		entry := result[12+16*i:]
+		start, length := binary.BigEndian.Uint32(entry[8:]), binary.BigEndian.Uint32(entry[12:])
+		directory[string(entry[:4])] = result[start : start+length]
+	}
+	if checksum(result) != 0xb1b0afba {
+		t.Errorf("font checksum = %08x, want b1b0afba", checksum(result))
+	}
+	var lengths []uint32
+	for glyph := range 4 {
+		lengths = append(lengths, binary.BigEndian.Uint32(directory["loca"][4*glyph+4:])-binary.BigEndian.Uint32(directory["loca"][4*glyph:]))
+	}
+	if want := []uint32{0, 20, 0, 16}; fmt.Sprint(lengths) != fmt.Sprint(want) {
+		t.Errorf("glyph lengths = %v, want %v", lengths, want)
+	}
+}
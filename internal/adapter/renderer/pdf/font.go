@@ -0,0 +1,338 @@
+package adapter
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"strings"
+	"unicode/utf16"
+
+	xfont "golang.org/x/image/font"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// Glyph space of PDF fonts, metrics are requested at this size to get them in 1/1000 of the font size
+const glyphUnits = 1000
+
+// Objects written per font
+const fontObjects = 5
+
+// TrueType tables of the embedded font, glyphs are selected by the Identity CID to glyph mapping but cmap and post
+// keep the font readable by other tools
+var subsetTables = []string{"cmap", "cvt ", "fpgm", "glyf", "head", "hhea", "hmtx", "loca", "maxp", "post", "prep"}
+
+// Embedded TrueType font written as a Type0 font with Identity-H encoding, so text is a string of glyph ids
+type font struct {
+	name   string
+	data   []byte
+	sfnt   *sfnt.Font
+	buffer sfnt.Buffer
+	runes  map[rune]sfnt.GlyphIndex
+}
+
+func newFont(data []byte) (*font, error) {
+	parsed, err := sfnt.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("font: %v", err)
+	}
+	name, err := parsed.Name(nil, sfnt.NameIDPostScript)
+	if err != nil {
+		return nil, fmt.Errorf("font: %v", err)
+	}
+	return &font{
+		name:  name,
+		data:  data,
+		sfnt:  parsed,
+		runes: make(map[rune]sfnt.GlyphIndex),
+	}, nil
+}
+
+// Encode text as a hex string of glyph ids, characters the font lacks become "?"
+func (f *font) encode(text string) (string, error) {
+	var sb strings.Builder
+	sb.WriteByte('<')
+	for _, r := range text {
+		glyph, err := f.glyph(r)
+		if err != nil {
+			return "", err
+		}
+		if glyph == 0 {
+			if glyph, err = f.glyph('?'); err != nil {
+				return "", err
+			}
+		}
+		fmt.Fprintf(&sb, "%04x", uint16(glyph))
+	}
+	sb.WriteByte('>')
+	return sb.String(), nil
+}
+
+// Glyph of the character, recorded for the subset
+func (f *font) glyph(r rune) (sfnt.GlyphIndex, error) {
+	if glyph, ok := f.runes[r]; ok {
+		return glyph, nil
+	}
+	glyph, err := f.sfnt.GlyphIndex(&f.buffer, r)
+	if err != nil {
+		return 0, fmt.Errorf("font %s: %v", f.name, err)
+	}
+	f.runes[r] = glyph
+	return glyph, nil
+}
+
+// Font objects numbered from first: Type0 font, CID font, descriptor, font file and ToUnicode map
+func (f *font) objects(first int) ([]string, error) {
+	// Used glyphs with the first character mapped to each
+	glyphs := make(map[sfnt.GlyphIndex]rune)
+	for r, glyph := range f.runes {
+		if current, ok := glyphs[glyph]; glyph != 0 && (!ok || r < current) {
+			glyphs[glyph] = r
+		}
+	}
+	ids := make([]sfnt.GlyphIndex, 0, len(glyphs))
+	for glyph := range glyphs {
+		ids = append(ids, glyph)
+	}
+	slices.Sort(ids)
+
+	// Subset name prefix derived from the glyph set (ISO 32000-1, 9.6.4)
+	hash := fnv.New32a()
+	for _, glyph := range ids {
+		binary.Write(hash, binary.BigEndian, uint16(glyph))
+	}
+	tag := make([]byte, 6)
+	for i, sum := 0, hash.Sum32(); i < len(tag); i, sum = i+1, sum/26 {
+		tag[i] = 'A' + byte(sum%26)
+	}
+	name := string(tag) + "+" + f.name
+
+	// Widths
+	var widths strings.Builder
+	for _, glyph := range ids {
+		advance, err := f.sfnt.GlyphAdvance(&f.buffer, glyph, fixed.I(glyphUnits), xfont.HintingNone)
+		if err != nil {
+			return nil, fmt.Errorf("font %s: %v", f.name, err)
+		}
+		fmt.Fprintf(&widths, "%d [%d] ", glyph, advance.Round())
+	}
+
+	// Descriptor metrics
+	metrics, err := f.sfnt.Metrics(&f.buffer, fixed.I(glyphUnits), xfont.HintingNone)
+	if err != nil {
+		return nil, fmt.Errorf("font %s: %v", f.name, err)
+	}
+	bounds, err := f.sfnt.Bounds(&f.buffer, fixed.I(glyphUnits), xfont.HintingNone)
+	if err != nil {
+		return nil, fmt.Errorf("font %s: %v", f.name, err)
+	}
+
+	// Font file and ToUnicode map
+	subset, err := subsetFont(f.data, ids)
+	if err != nil {
+		return nil, fmt.Errorf("font %s: %v", f.name, err)
+	}
+	file, err := deflate(subset)
+	if err != nil {
+		return nil, err
+	}
+	toUnicode, err := deflate(toUnicodeMap(ids, glyphs))
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{
+		fmt.Sprintf(
+			"<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /Identity-H /DescendantFonts [%d 0 R] /ToUnicode %d 0 R >>",
+			name, first+1, first+4,
+		),
+		fmt.Sprintf(
+			"<< /Type /Font /Subtype /CIDFontType2 /BaseFont /%s /CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> /FontDescriptor %d 0 R /W [%s] /CIDToGIDMap /Identity >>",
+			name, first+2, strings.TrimSpace(widths.String()),
+		),
+		fmt.Sprintf(
+			"<< /Type /FontDescriptor /FontName /%s /Flags 33 /FontBBox [%d %d %d %d] /ItalicAngle 0 /Ascent %d /Descent %d /CapHeight %d /StemV 80 /FontFile2 %d 0 R >>",
+			name, bounds.Min.X.Floor(), -bounds.Max.Y.Ceil(), bounds.Max.X.Ceil(), -bounds.Min.Y.Floor(),
+			metrics.Ascent.Round(), -metrics.Descent.Round(), metrics.CapHeight.Round(), first+3,
+		),
+		fmt.Sprintf("<< /Length %d /Length1 %d /Filter /FlateDecode >>\nstream\n%s\nendstream", len(file), len(subset), file),
+		fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", len(toUnicode), toUnicode),
+	}, nil
+}
+
+// CMap mapping glyph ids back to characters for text extraction
+func toUnicodeMap(ids []sfnt.GlyphIndex, glyphs map[sfnt.GlyphIndex]rune) []byte {
+	var cmap bytes.Buffer
+	cmap.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n")
+	cmap.WriteString("/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n")
+	cmap.WriteString("/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n")
+	cmap.WriteString("1 begincodespacerange\n<0000> <ffff>\nendcodespacerange\n")
+	for chunk := range slices.Chunk(ids, 100) {
+		fmt.Fprintf(&cmap, "%d beginbfchar\n", len(chunk))
+		for _, glyph := range chunk {
+			fmt.Fprintf(&cmap, "<%04x> <", uint16(glyph))
+			for _, unit := range utf16.Encode([]rune{glyphs[glyph]}) {
+				fmt.Fprintf(&cmap, "%04x", unit)
+			}
+			cmap.WriteString(">\n")
+		}
+		cmap.WriteString("endbfchar\n")
+	}
+	cmap.WriteString("endcmap\nCMapName currentdict /defineresource pop\nend\nend\n")
+	return cmap.Bytes()
+}
+
+// TrueType font keeping the glyph ids, outlines of glyphs outside the subset and their components are emptied
+func subsetFont(data []byte, ids []sfnt.GlyphIndex) ([]byte, error) {
+	// Read table directory
+	if len(data) < 12 {
+		return nil, fmt.Errorf("truncated table directory")
+	}
+	tables := make(map[string][]byte)
+	for i := range int(binary.BigEndian.Uint16(data[4:])) {
+		entry := 12 + 16*i
+		if entry+16 > len(data) {
+			return nil, fmt.Errorf("truncated table directory")
+		}
+		offset, length := binary.BigEndian.Uint32(data[entry+8:]), binary.BigEndian.Uint32(data[entry+12:])
+		if uint64(offset)+uint64(length) > uint64(len(data)) {
+			return nil, fmt.Errorf("table %s out of bounds", data[entry:entry+4])
+		}
+		tables[string(data[entry:entry+4])] = data[offset : offset+length]
+	}
+	head, maxp, loca, glyf := tables["head"], tables["maxp"], tables["loca"], tables["glyf"]
+	if len(head) < 54 || len(maxp) < 6 || loca == nil || glyf == nil {
+		return nil, fmt.Errorf("missing glyph tables")
+	}
+	longLoca := binary.BigEndian.Uint16(head[50:]) == 1
+	count := int(binary.BigEndian.Uint16(maxp[4:]))
+	if longLoca && len(loca) < 4*(count+1) || !longLoca && len(loca) < 2*(count+1) {
+		return nil, fmt.Errorf("truncated loca table")
+	}
+	outline := func(glyph int) []byte {
+		var start, end int
+		if longLoca {
+			start, end = int(binary.BigEndian.Uint32(loca[4*glyph:])), int(binary.BigEndian.Uint32(loca[4*glyph+4:]))
+		} else {
+			start, end = 2*int(binary.BigEndian.Uint16(loca[2*glyph:])), 2*int(binary.BigEndian.Uint16(loca[2*glyph+2:]))
+		}
+		if start > end || end > len(glyf) {
+			return nil
+		}
+		return glyf[start:end]
+	}
+
+	// Collect glyphs with the components of composite glyphs, .notdef is always kept
+	used := map[int]bool{0: true}
+	queue := []int{0}
+	for _, glyph := range ids {
+		if int(glyph) < count && !used[int(glyph)] {
+			used[int(glyph)] = true
+			queue = append(queue, int(glyph))
+		}
+	}
+	for len(queue) > 0 {
+		glyph := queue[0]
+		queue = queue[1:]
+		composite := outline(glyph)
+		if len(composite) < 10 || int16(binary.BigEndian.Uint16(composite)) >= 0 {
+			continue
+		}
+		for offset := 10; offset+4 <= len(composite); {
+			flags, component := binary.BigEndian.Uint16(composite[offset:]), int(binary.BigEndian.Uint16(composite[offset+2:]))
+			if component < count && !used[component] {
+				used[component] = true
+				queue = append(queue, component)
+			}
+			offset += 4
+			switch {
+			case flags&0x0001 != 0: // ARG_1_AND_2_ARE_WORDS
+				offset += 4
+			default:
+				offset += 2
+			}
+			switch {
+			case flags&0x0008 != 0: // WE_HAVE_A_SCALE
+				offset += 2
+			case flags&0x0040 != 0: // WE_HAVE_AN_X_AND_Y_SCALE
+				offset += 4
+			case flags&0x0080 != 0: // WE_HAVE_A_TWO_BY_TWO
+				offset += 8
+			}
+			if flags&0x0020 == 0 { // MORE_COMPONENTS
+				break
+			}
+		}
+	}
+
+	// Rebuild glyf and loca with empty outlines for unused glyphs
+	var newGlyf, newLoca []byte
+	for glyph := 0; glyph <= count; glyph++ {
+		if longLoca {
+			newLoca = binary.BigEndian.AppendUint32(newLoca, uint32(len(newGlyf)))
+		} else {
+			newLoca = binary.BigEndian.AppendUint16(newLoca, uint16(len(newGlyf)/2))
+		}
+		if glyph < count && used[glyph] {
+			newGlyf = append(newGlyf, outline(glyph)...)
+			for len(newGlyf)%4 != 0 {
+				newGlyf = append(newGlyf, 0)
+			}
+		}
+	}
+	tables["glyf"], tables["loca"] = newGlyf, newLoca
+	tables["head"] = slices.Clone(head)
+	binary.BigEndian.PutUint32(tables["head"][8:], 0)
+
+	// Drop glyph names, post version 3 is the header only
+	if post := tables["post"]; len(post) >= 32 {
+		tables["post"] = slices.Clone(post[:32])
+		binary.BigEndian.PutUint32(tables["post"], 0x00030000)
+	}
+
+	// Write tables in tag order after the directory
+	var tags []string
+	for _, tag := range subsetTables {
+		if _, ok := tables[tag]; ok {
+			tags = append(tags, tag)
+		}
+	}
+	selector := 0
+	for 1<<(selector+1) <= len(tags) {
+		selector++
+	}
+	var out bytes.Buffer
+	binary.Write(&out, binary.BigEndian, []uint16{0x0001, 0x0000, uint16(len(tags)), uint16(16 << selector), uint16(selector), uint16(16*len(tags) - 16<<selector)})
+	offset := 12 + 16*len(tags)
+	for _, tag := range tags {
+		out.WriteString(tag)
+		binary.Write(&out, binary.BigEndian, []uint32{checksum(tables[tag]), uint32(offset), uint32(len(tables[tag]))})
+		offset += (len(tables[tag]) + 3) &^ 3
+	}
+	headOffset := 0
+	for _, tag := range tags {
+		if tag == "head" {
+			headOffset = out.Len()
+		}
+		out.Write(tables[tag])
+		for out.Len()%4 != 0 {
+			out.WriteByte(0)
+		}
+	}
+	result := out.Bytes()
+	binary.BigEndian.PutUint32(result[headOffset+8:], 0xb1b0afba-checksum(result))
+	return result, nil
+}
+
+// Sum of big-endian uint32 words, the last word is zero padded
+func checksum(data []byte) uint32 {
+	var sum uint32
+	for i := 0; i < len(data); i += 4 {
+		var word [4]byte
+		copy(word[:], data[i:])
+		sum += binary.BigEndian.Uint32(word[:])
+	}
+	return sum
+}
@@ -3,10 +3,14 @@ package adapter
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"mime/multipart"
+	"net"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
@@ -14,69 +18,114 @@ import (
 	"github.com/flash-go/notifications-service/internal/adapter/repository/emails/model"
 	emailsRepositoryAdapterPort "github.com/flash-go/notifications-service/internal/port/adapter/repository/emails"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 const (
 	smtpBxApiBaseUrl = "https://api.smtp.bz/v1"
 )
 
+// Email providers
+const (
+	ProviderSmtpBz = "smtp_bz"
+	ProviderSmtp   = "smtp"
+)
+
 type Config struct {
 	PostgresClient *gorm.DB
 	HttpClient     client.Client
+	Provider       string
 	SmtpBzApiKey   string
+	SmtpBzApiUrl   string
+	SmtpAddr       string
+	SmtpUsername   string
+	SmtpPassword   string
+	DnsResolver    string
 }
 
 func New(config *Config) emailsRepositoryAdapterPort.Interface {
+	// Use the public smtp.bz API unless another URL is set
+	smtpBzApiUrl := strings.TrimSuffix(config.SmtpBzApiUrl, "/")
+	if smtpBzApiUrl == "" {
+		smtpBzApiUrl = smtpBxApiBaseUrl
+	}
+
 	return &adapter{
 		postgres:     config.PostgresClient,
 		httpClient:   config.HttpClient,
+		provider:     config.Provider,
 		smtpBzApiKey: config.SmtpBzApiKey,
+		smtpBzApiUrl: smtpBzApiUrl,
+		smtpAddr:     config.SmtpAddr,
+		smtpUsername: config.SmtpUsername,
+		smtpPassword: config.SmtpPassword,
+		resolver:     newResolver(config.DnsResolver),
 	}
 }
 
 type adapter struct {
 	postgres     *gorm.DB
 	httpClient   client.Client
+	provider     string
 	smtpBzApiKey string
+	smtpBzApiUrl string
+	smtpAddr     string
+	smtpUsername string
+	smtpPassword string
+	resolver     *net.Resolver
 }
 
-// Folders
+// Use the system resolver unless the address (host:port) of a DNS server is set
+func newResolver(address string) *net.Resolver {
+	if address == "" {
+		return net.DefaultResolver
+	}
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, network, address)
+		},
+	}
+}
 
-func (a *adapter) CreateFolder(ctx context.Context, data emailsRepositoryAdapterPort.CreateFolderData) (*emailsRepositoryAdapterPort.FolderResult, error) {
+// Recipient lists are stored as JSON arrays, never as null
+func recipientList(list []string) []string {
+	if list == nil {
+		return []string{}
+	}
+	return list
+}
+
+// Senders
+
+func (a *adapter) CreateSender(ctx context.Context, data emailsRepositoryAdapterPort.CreateSenderData) (*emailsRepositoryAdapterPort.SenderResult, error) {
 	now := time.Now()
 
 	// Create model
-	obj := model.EmailFolder{
-		ParentId:    data.ParentId,
+	obj := model.EmailSender{
+		Email:       data.Email,
 		Name:        data.Name,
+		ReplyTo:     data.ReplyTo,
 		Description: data.Description,
-		SystemFlag:  data.SystemFlag,
 		Updated:     time.Unix(0, now.UnixNano()),
 		Created:     time.Unix(0, now.UnixNano()),
 	}
 
-	// Save folder to database
+	// Save sender to database
 	if err := a.postgres.WithContext(ctx).Create(&obj).Error; err != nil {
 		return nil, err
 	}
 
-	// Mapping model to repository
-	folder := emailsRepositoryAdapterPort.FolderResult{
-		Id:          obj.Id,
-		ParentId:    obj.ParentId,
-		Name:        obj.Name,
-		Description: obj.Description,
-		SystemFlag:  obj.SystemFlag,
-		Updated:     obj.Updated,
-		Created:     obj.Created,
-	}
+	// Map model to repository results
+	sender := emailsRepositoryAdapterPort.SenderResult(obj)
 
-	return &folder, nil
+	return &sender, nil
 }
 
-func (a *adapter) FilterFolders(ctx context.Context, data emailsRepositoryAdapterPort.FilterFoldersData) (*[]emailsRepositoryAdapterPort.FolderResult, error) {
+func (a *adapter) FilterSenders(ctx context.Context, data emailsRepositoryAdapterPort.FilterSendersData) (*[]emailsRepositoryAdapterPort.SenderResult, error) {
 	// Create model
-	obj := []model.EmailFolder{}
+	obj := []model.EmailSender{}
 
 	// Create query with context
 	query := a.postgres.WithContext(ctx)
@@ -86,25 +135,9 @@ func (a *adapter) FilterFolders(ctx context.Context, data emailsRepositoryAdapte
 		query = query.Where("id IN ?", *data.Id)
 	}
 
-	// Filter by parent_id
-	if data.ParentId != nil {
-		var ids []uint
-		hasNull := false
-		for _, v := range *data.ParentId {
-			if v == nil {
-				hasNull = true
-			} else {
-				ids = append(ids, *v)
-			}
-		}
-
-		if len(ids) > 0 && hasNull {
-			query = query.Where("parent_id IN ? OR parent_id IS NULL", ids)
-		} else if len(ids) > 0 {
-			query = query.Where("parent_id IN ?", ids)
-		} else if hasNull {
-			query = query.Where("parent_id IS NULL")
-		}
+	// Filter by email
+	if data.Email != nil {
+		query = query.Where("email IN ?", *data.Email)
 	}
 
 	// Filter by name
@@ -112,112 +145,104 @@ func (a *adapter) FilterFolders(ctx context.Context, data emailsRepositoryAdapte
 		query = query.Where("name IN ?", *data.Name)
 	}
 
-	// Filter by system_flag
-	if data.SystemFlag != nil {
-		query = query.Where("system_flag = ?", *data.SystemFlag)
-	}
-
-	// Get folders from database
-	if err := query.Find(&obj).Error; err != nil {
+	// Get senders from database
+	if err := query.Order("id").Find(&obj).Error; err != nil {
 		return nil, err
 	}
 
 	// Mapping model to repository
-	folders := make([]emailsRepositoryAdapterPort.FolderResult, len(obj))
+	senders := make([]emailsRepositoryAdapterPort.SenderResult, len(obj))
 	for i, item := range obj {
-		folders[i] = emailsRepositoryAdapterPort.FolderResult{
-			Id:          item.Id,
-			ParentId:    item.ParentId,
-			Name:        item.Name,
-			Description: item.Description,
-			SystemFlag:  item.SystemFlag,
-			Updated:     item.Updated,
-			Created:     item.Created,
-		}
+		senders[i] = emailsRepositoryAdapterPort.SenderResult(item)
 	}
 
-	return &folders, nil
+	return &senders, nil
 }
 
-func (a *adapter) DeleteFolder(ctx context.Context, id uint) error {
-	// Delete folder from database
-	result := a.postgres.WithContext(ctx).Delete(&model.EmailFolder{}, "id = ?", id)
+func (a *adapter) DeleteSender(ctx context.Context, id uint) error {
+	// Delete sender from database
+	result := a.postgres.WithContext(ctx).Delete(&model.EmailSender{}, "id = ?", id)
 
 	// Check errors
 	if result.Error != nil {
 		return result.Error
 	}
 
-	// If folder not found
+	// If sender not found
 	if result.RowsAffected == 0 {
-		return emailsRepositoryAdapterPort.ErrFolderNotFound
+		return emailsRepositoryAdapterPort.ErrSenderNotFound
 	}
 
 	return nil
 }
 
-func (a *adapter) UpdateFolder(ctx context.Context, id uint, data map[string]any) error {
-	// Update folder in database
-	result := a.postgres.WithContext(ctx).Model(&model.EmailFolder{}).Where("id = ?", id).Updates(data)
+func (a *adapter) UpdateSender(ctx context.Context, id uint, data map[string]any) error {
+	// Update sender in database
+	result := a.postgres.WithContext(ctx).Model(&model.EmailSender{}).Where("id = ?", id).Updates(data)
 
 	// Check errors
 	if result.Error != nil {
 		return result.Error
 	}
 
-	// If folder not found
+	// If sender not found
 	if result.RowsAffected == 0 {
-		return emailsRepositoryAdapterPort.ErrFolderNotFound
+		return emailsRepositoryAdapterPort.ErrSenderNotFound
 	}
 
 	return nil
 }
 
-// Emails
+// Count folders and emails referencing the sender, including those in trash
+func (a *adapter) CountSenderUsage(ctx context.Context, id uint) (uint, error) {
+	var count uint
+	if err := a.postgres.WithContext(ctx).Raw(`
+		SELECT (SELECT COUNT(*) FROM email_folders WHERE sender_id = ?) + (SELECT COUNT(*) FROM emails WHERE sender_id = ?)
+	`, id, id).Scan(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
 
-func (a *adapter) CreateEmail(ctx context.Context, data emailsRepositoryAdapterPort.CreateEmailData) (*emailsRepositoryAdapterPort.EmailResult, error) {
+// Domains
+
+func (a *adapter) CreateDomain(ctx context.Context, data emailsRepositoryAdapterPort.CreateDomainData) (*emailsRepositoryAdapterPort.DomainResult, error) {
 	now := time.Now()
 
 	// Create model
-	obj := model.Email{
-		FolderId:    data.FolderId,
-		FromEmail:   data.FromEmail,
-		FromName:    data.FromName,
-		Subject:     data.Subject,
-		Html:        data.Html,
-		Text:        data.Text,
-		Description: data.Description,
-		SystemFlag:  data.SystemFlag,
-		Updated:     time.Unix(0, now.UnixNano()),
-		Created:     time.Unix(0, now.UnixNano()),
+	obj := model.EmailDomain{
+		Name:    data.Name,
+		Updated: time.Unix(0, now.UnixNano()),
+		Created: time.Unix(0, now.UnixNano()),
 	}
 
-	// Save email to database
-	if err := a.postgres.WithContext(ctx).Create(&obj).Error; err != nil {
+	// Save domain with active key to database
+	if err := a.postgres.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&obj).Error; err != nil {
+			return err
+		}
+		return tx.Create(&model.EmailDomainKey{
+			DomainId:   obj.Id,
+			Selector:   data.DkimSelector,
+			Algorithm:  data.DkimAlgorithm,
+			PrivateKey: data.DkimPrivateKey,
+			PublicKey:  data.DkimPublicKey,
+			Active:     true,
+			Created:    obj.Created,
+		}).Error
+	}); err != nil {
 		return nil, err
 	}
 
-	// Mapping model to repository
-	email := emailsRepositoryAdapterPort.EmailResult{
-		Id:          obj.Id,
-		FolderId:    obj.FolderId,
-		FromEmail:   obj.FromEmail,
-		FromName:    obj.FromName,
-		Subject:     obj.Subject,
-		Html:        obj.Html,
-		Text:        obj.Text,
-		Description: obj.Description,
-		SystemFlag:  obj.SystemFlag,
-		Updated:     obj.Updated,
-		Created:     obj.Created,
-	}
+	// Map model to repository results
+	domain := emailsRepositoryAdapterPort.DomainResult(obj)
 
-	return &email, nil
+	return &domain, nil
 }
 
-func (a *adapter) FilterEmails(ctx context.Context, data emailsRepositoryAdapterPort.FilterEmailsData) (*[]emailsRepositoryAdapterPort.EmailResult, error) {
+func (a *adapter) FilterDomains(ctx context.Context, data emailsRepositoryAdapterPort.FilterDomainsData) (*[]emailsRepositoryAdapterPort.DomainResult, error) {
 	// Create model
-	obj := []model.Email{}
+	obj := []model.EmailDomain{}
 
 	// Create query with context
 	query := a.postgres.WithContext(ctx)
@@ -227,199 +252,89 @@ func (a *adapter) FilterEmails(ctx context.Context, data emailsRepositoryAdapter
 		query = query.Where("id IN ?", *data.Id)
 	}
 
-	// Filter by folder_id
-	if data.FolderId != nil {
-		var ids []uint
-		hasNull := false
-		for _, v := range *data.FolderId {
-			if v == nil {
-				hasNull = true
-			} else {
-				ids = append(ids, *v)
-			}
-		}
-
-		if len(ids) > 0 && hasNull {
-			query = query.Where("folder_id IN ? OR folder_id IS NULL", ids)
-		} else if len(ids) > 0 {
-			query = query.Where("folder_id IN ?", ids)
-		} else if hasNull {
-			query = query.Where("folder_id IS NULL")
-		}
+	// Filter by name
+	if data.Name != nil {
+		query = query.Where("name IN ?", *data.Name)
 	}
 
-	// Filter by system_flag
-	if data.SystemFlag != nil {
-		query = query.Where("system_flag = ?", *data.SystemFlag)
+	// Filter by verified
+	if data.Verified != nil {
+		query = query.Where("verified = ?", *data.Verified)
 	}
 
-	// Get emails from database
-	if err := query.Find(&obj).Error; err != nil {
+	// Get domains from database
+	if err := query.Order("id").Find(&obj).Error; err != nil {
 		return nil, err
 	}
 
 	// Mapping model to repository
-	emails := make([]emailsRepositoryAdapterPort.EmailResult, len(obj))
+	domains := make([]emailsRepositoryAdapterPort.DomainResult, len(obj))
 	for i, item := range obj {
-		emails[i] = emailsRepositoryAdapterPort.EmailResult{
-			Id:          item.Id,
-			FolderId:    item.FolderId,
-			FromEmail:   item.FromEmail,
-			FromName:    item.FromName,
-			Subject:     item.Subject,
-			Html:        item.Html,
-			Text:        item.Text,
-			Description: item.Description,
-			SystemFlag:  item.SystemFlag,
-			Updated:     item.Updated,
-			Created:     item.Created,
-		}
+		domains[i] = emailsRepositoryAdapterPort.DomainResult(item)
 	}
 
-	return &emails, nil
+	return &domains, nil
 }
 
-func (a *adapter) DeleteEmail(ctx context.Context, id uint) error {
-	// Delete email from database
-	result := a.postgres.WithContext(ctx).Delete(&model.Email{}, "id = ?", id)
+func (a *adapter) DeleteDomain(ctx context.Context, id uint) error {
+	// Delete domain from database
+	result := a.postgres.WithContext(ctx).Delete(&model.EmailDomain{}, "id = ?", id)
 
 	// Check errors
 	if result.Error != nil {
 		return result.Error
 	}
 
-	// If email not found
+	// If domain not found
 	if result.RowsAffected == 0 {
-		return emailsRepositoryAdapterPort.ErrEmailNotFound
+		return emailsRepositoryAdapterPort.ErrDomainNotFound
 	}
 
 	return nil
 }
 
-func (a *adapter) UpdateEmail(ctx context.Context, id uint, data map[string]any) error {
-	// Update email in database
-	result := a.postgres.WithContext(ctx).Model(&model.Email{}).Where("id = ?", id).Updates(data)
+func (a *adapter) UpdateDomain(ctx context.Context, id uint, data map[string]any) error {
+	// Update domain in database
+	result := a.postgres.WithContext(ctx).Model(&model.EmailDomain{}).Where("id = ?", id).Updates(data)
 
 	// Check errors
 	if result.Error != nil {
 		return result.Error
 	}
 
-	// If email not found
+	// If domain not found
 	if result.RowsAffected == 0 {
-		return emailsRepositoryAdapterPort.ErrEmailNotFound
+		return emailsRepositoryAdapterPort.ErrDomainNotFound
 	}
 
 	return nil
 }
 
-func (a *adapter) Send(ctx context.Context, data emailsRepositoryAdapterPort.SendData) (*emailsRepositoryAdapterPort.EmailLogResult, error) {
-	// Create buffer and multipart writer
-	var body bytes.Buffer
-	writer := multipart.NewWriter(&body)
-
-	// Set fields
-	if err := writer.WriteField("from", data.FromEmail); err != nil {
-		return nil, err
-	}
-	if err := writer.WriteField("name", data.FromName); err != nil {
-		return nil, err
-	}
-	if err := writer.WriteField("subject", data.Subject); err != nil {
-		return nil, err
-	}
-	if err := writer.WriteField("to", data.ToEmail); err != nil {
-		return nil, err
-	}
-	if err := writer.WriteField("html", data.Html); err != nil {
-		return nil, err
-	}
-	if err := writer.WriteField("text", data.Text); err != nil {
-		return nil, err
-	}
-
-	// Close writer
-	if err := writer.Close(); err != nil {
-		return nil, fmt.Errorf("close writer: %v", err)
-	}
-
-	// Send service request
-	res, err := a.httpClient.Request(
-		// Context
-		ctx,
-		// Method
-		http.MethodPost,
-		// URL
-		smtpBxApiBaseUrl+"/smtp/send",
-		// Body opt
-		client.WithRequestBodyOption(
-			body.Bytes(),
-		),
-		// Headers opts
-		client.WithRequestHeadersOption(
-			client.NewRequestHeader("Authorization", a.smtpBzApiKey),
-			client.NewRequestHeader("Content-Type", writer.FormDataContentType()),
-		),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("service smtp.bz unavailable: %v", err)
-	}
-
+func (a *adapter) CreateDomainKey(ctx context.Context, data emailsRepositoryAdapterPort.CreateDomainKeyData) (*emailsRepositoryAdapterPort.DomainKeyResult, error) {
 	// Create model
-	obj := model.EmailLog{
-		FromEmail: data.FromEmail,
-		FromName:  data.FromName,
-		Subject:   data.Subject,
-		ToEmail:   data.ToEmail,
-		Html:      data.Html,
-		Text:      data.Text,
-		Created:   time.Unix(0, time.Now().UnixNano()),
-	}
-
-	switch res.StatusCode() {
-	case sendEmailSuccessCode:
-		var response sendSuccessResponse
-		if err := json.Unmarshal(res.Body(), &response); err != nil {
-			return nil, fmt.Errorf("error parsing success response body: %v", err)
-		}
-		obj.Status = "success"
-		obj.MessageId = &response.Messageid
-	case sendEmailBadRequestCode:
-		var response sendErrorResponse
-		if err := json.Unmarshal(res.Body(), &response); err != nil {
-			return nil, fmt.Errorf("error parsing bad request response body: %v", err)
-		}
-		var m map[string]interface{}
-		if err := json.Unmarshal(response.Errors, &m); err != nil {
-			return nil, fmt.Errorf("error parsing bad request response errors: %v", err)
-		}
-		parts := make([]string, 0, len(m))
-		for k, v := range m {
-			parts = append(parts, fmt.Sprintf("%s: %v", k, v))
-		}
-		errors := strings.Join(parts, ", ")
-		obj.Status = "error"
-		obj.Errors = &errors
-	case sendEmailUnautorizedCode:
-		message := "Unautorized"
-		obj.Status = "error"
-		obj.Errors = &message
+	obj := model.EmailDomainKey{
+		DomainId:   data.DomainId,
+		Selector:   data.Selector,
+		Algorithm:  data.Algorithm,
+		PrivateKey: data.PrivateKey,
+		PublicKey:  data.PublicKey,
+		Created:    time.Unix(0, time.Now().UnixNano()),
 	}
 
-	// Save email to database
+	// Save key to database
 	if err := a.postgres.WithContext(ctx).Create(&obj).Error; err != nil {
 		return nil, err
 	}
 
 	// Map model to repository results
-	results := emailsRepositoryAdapterPort.EmailLogResult(obj)
+	key := emailsRepositoryAdapterPort.DomainKeyResult(obj)
 
-	return &results, nil
+	return &key, nil
 }
 
-func (a *adapter) FilterEmailLogs(ctx context.Context, data emailsRepositoryAdapterPort.FilterEmailLogsData) (*[]emailsRepositoryAdapterPort.EmailLogResult, error) {
+func (a *adapter) FilterDomainKeys(ctx context.Context, data emailsRepositoryAdapterPort.FilterDomainKeysData) (*[]emailsRepositoryAdapterPort.DomainKeyResult, error) {
 	// Create model
-	obj := []model.EmailLog{}
+	obj := []model.EmailDomainKey{}
 
 	// Create query with context
 	query := a.postgres.WithContext(ctx)
@@ -429,41 +344,1964 @@ func (a *adapter) FilterEmailLogs(ctx context.Context, data emailsRepositoryAdap
 		query = query.Where("id IN ?", *data.Id)
 	}
 
-	// Filter by from_email
-	if data.FromEmail != nil {
-		query = query.Where("from_email IN ?", *data.FromEmail)
+	// Filter by domain_id
+	if data.DomainId != nil {
+		query = query.Where("domain_id IN ?", *data.DomainId)
 	}
 
-	// Filter by from_name
-	if data.FromName != nil {
-		query = query.Where("from_name IN ?", *data.FromName)
+	// Filter by active
+	if data.Active != nil {
+		query = query.Where("active = ?", *data.Active)
 	}
 
-	// Filter by to_email
-	if data.ToEmail != nil {
-		query = query.Where("to_email IN ?", *data.ToEmail)
+	// Get keys from database
+	if err := query.Order("id").Find(&obj).Error; err != nil {
+		return nil, err
 	}
 
-	// Filter by status
-	if data.Status != nil {
-		query = query.Where("status IN ?", *data.Status)
+	// Mapping model to repository
+	keys := make([]emailsRepositoryAdapterPort.DomainKeyResult, len(obj))
+	for i, item := range obj {
+		keys[i] = emailsRepositoryAdapterPort.DomainKeyResult(item)
 	}
 
-	// Filter by message_id
-	if data.MessageId != nil {
-		query = query.Where("message_id IN ?", *data.MessageId)
+	return &keys, nil
+}
+
+func (a *adapter) DeleteDomainKey(ctx context.Context, id uint) error {
+	// Delete key from database
+	result := a.postgres.WithContext(ctx).Delete(&model.EmailDomainKey{}, "id = ?", id)
+
+	// Check errors
+	if result.Error != nil {
+		return result.Error
 	}
 
-	// Get email logs from database
-	if err := query.Find(&obj).Error; err != nil {
-		return nil, err
+	// If key not found
+	if result.RowsAffected == 0 {
+		return emailsRepositoryAdapterPort.ErrDomainKeyNotFound
 	}
 
-	// Mapping model to repository
+	return nil
+}
+
+func (a *adapter) UpdateDomainKey(ctx context.Context, id uint, data map[string]any) error {
+	// Update key in database
+	result := a.postgres.WithContext(ctx).Model(&model.EmailDomainKey{}).Where("id = ?", id).Updates(data)
+
+	// Check errors
+	if result.Error != nil {
+		return result.Error
+	}
+
+	// If key not found
+	if result.RowsAffected == 0 {
+		return emailsRepositoryAdapterPort.ErrDomainKeyNotFound
+	}
+
+	return nil
+}
+
+// Switch signing of the domain to the key
+func (a *adapter) ActivateDomainKey(ctx context.Context, domainId uint, id uint) error {
+	return a.postgres.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Deactivate current key
+		if err := tx.Model(&model.EmailDomainKey{}).
+			Where("domain_id = ? AND active", domainId).
+			Update("active", false).Error; err != nil {
+			return err
+		}
+
+		// Activate key
+		result := tx.Model(&model.EmailDomainKey{}).
+			Where("id = ? AND domain_id = ?", id, domainId).
+			Update("active", true)
+
+		// Check errors
+		if result.Error != nil {
+			return result.Error
+		}
+
+		// If key not found
+		if result.RowsAffected == 0 {
+			return emailsRepositoryAdapterPort.ErrDomainKeyNotFound
+		}
+
+		return nil
+	})
+}
+
+// Get TXT records of the name, a missing name has no records
+func (a *adapter) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	records, err := a.resolver.LookupTXT(ctx, name)
+	if err != nil {
+		if dnsErr, ok := err.(*net.DNSError); ok && dnsErr.IsNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return records, nil
+}
+
+// Files
+
+func (a *adapter) CreateFile(ctx context.Context, data emailsRepositoryAdapterPort.CreateFileData) (*emailsRepositoryAdapterPort.FileResult, error) {
+	// Create model
+	obj := model.EmailFile{
+		Name:        data.Name,
+		ContentType: data.ContentType,
+		Size:        data.Size,
+		Sha256:      data.Sha256,
+		StorageKey:  data.StorageKey,
+		Created:     time.Unix(0, time.Now().UnixNano()),
+	}
+
+	// Save file to database
+	if err := a.postgres.WithContext(ctx).Create(&obj).Error; err != nil {
+		return nil, err
+	}
+
+	// Map model to repository results
+	file := emailsRepositoryAdapterPort.FileResult(obj)
+
+	return &file, nil
+}
+
+func (a *adapter) FilterFiles(ctx context.Context, data emailsRepositoryAdapterPort.FilterFilesData) (*[]emailsRepositoryAdapterPort.FileResult, error) {
+	// Create model
+	obj := []model.EmailFile{}
+
+	// Create query with context
+	query := a.postgres.WithContext(ctx)
+
+	// Filter by id
+	if data.Id != nil {
+		query = query.Where("id IN ?", *data.Id)
+	}
+
+	// Filter by name
+	if data.Name != nil {
+		query = query.Where("name IN ?", *data.Name)
+	}
+
+	// Get files from database
+	if err := query.Order("id").Find(&obj).Error; err != nil {
+		return nil, err
+	}
+
+	// Mapping model to repository
+	files := make([]emailsRepositoryAdapterPort.FileResult, len(obj))
+	for i, item := range obj {
+		files[i] = emailsRepositoryAdapterPort.FileResult(item)
+	}
+
+	return &files, nil
+}
+
+func (a *adapter) DeleteFile(ctx context.Context, id uint) error {
+	// Delete file from database
+	result := a.postgres.WithContext(ctx).Delete(&model.EmailFile{}, "id = ?", id)
+
+	// Check errors
+	if result.Error != nil {
+		return result.Error
+	}
+
+	// If file not found
+	if result.RowsAffected == 0 {
+		return emailsRepositoryAdapterPort.ErrFileNotFound
+	}
+
+	return nil
+}
+
+// Assets
+
+func (a *adapter) CreateAsset(ctx context.Context, data emailsRepositoryAdapterPort.CreateAssetData) (*emailsRepositoryAdapterPort.AssetResult, error) {
+	// Create model
+	obj := model.EmailAsset{
+		Name:        data.Name,
+		ContentType: data.ContentType,
+		Size:        data.Size,
+		Sha256:      data.Sha256,
+		StorageKey:  data.StorageKey,
+		Created:     time.Unix(0, time.Now().UnixNano()),
+	}
+
+	// Save asset to database
+	if err := a.postgres.WithContext(ctx).Create(&obj).Error; err != nil {
+		return nil, err
+	}
+
+	// Map model to repository results
+	asset := emailsRepositoryAdapterPort.AssetResult(obj)
+
+	return &asset, nil
+}
+
+func (a *adapter) FilterAssets(ctx context.Context, data emailsRepositoryAdapterPort.FilterAssetsData) (*[]emailsRepositoryAdapterPort.AssetResult, error) {
+	// Create model
+	obj := []model.EmailAsset{}
+
+	// Create query with context
+	query := a.postgres.WithContext(ctx)
+
+	// Filter by id
+	if data.Id != nil {
+		query = query.Where("id IN ?", *data.Id)
+	}
+
+	// Filter by name
+	if data.Name != nil {
+		query = query.Where("name IN ?", *data.Name)
+	}
+
+	// Get assets from database
+	if err := query.Order("id").Find(&obj).Error; err != nil {
+		return nil, err
+	}
+
+	// Mapping model to repository
+	assets := make([]emailsRepositoryAdapterPort.AssetResult, len(obj))
+	for i, item := range obj {
+		assets[i] = emailsRepositoryAdapterPort.AssetResult(item)
+	}
+
+	return &assets, nil
+}
+
+func (a *adapter) DeleteAsset(ctx context.Context, id uint) error {
+	// Delete asset from database
+	result := a.postgres.WithContext(ctx).Delete(&model.EmailAsset{}, "id = ?", id)
+
+	// Check errors
+	if result.Error != nil {
+		return result.Error
+	}
+
+	// If asset not found
+	if result.RowsAffected == 0 {
+		return emailsRepositoryAdapterPort.ErrAssetNotFound
+	}
+
+	return nil
+}
+
+// Unsubscribes
+
+func (a *adapter) CreateUnsubscribe(ctx context.Context, data emailsRepositoryAdapterPort.CreateUnsubscribeData) error {
+	// Create model
+	obj := model.EmailUnsubscribe{
+		Email:    strings.ToLower(data.Email),
+		Category: data.Category,
+		Created:  time.Unix(0, time.Now().UnixNano()),
+	}
+
+	// Save unsubscribe to database, repeated opt-outs keep the first one
+	return a.postgres.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&obj).Error
+}
+
+func (a *adapter) FilterUnsubscribes(ctx context.Context, data emailsRepositoryAdapterPort.FilterUnsubscribesData) (*[]emailsRepositoryAdapterPort.UnsubscribeResult, error) {
+	// Create model
+	obj := []model.EmailUnsubscribe{}
+
+	// Create query with context
+	query := a.postgres.WithContext(ctx)
+
+	// Filter by email
+	if data.Email != nil {
+		emails := make([]string, len(*data.Email))
+		for i, email := range *data.Email {
+			emails[i] = strings.ToLower(email)
+		}
+		query = query.Where("email IN ?", emails)
+	}
+
+	// Filter by category
+	if data.Category != nil {
+		query = query.Where("category IN ?", *data.Category)
+	}
+
+	// Get unsubscribes from database
+	if err := query.Order("created").Find(&obj).Error; err != nil {
+		return nil, err
+	}
+
+	// Mapping model to repository
+	unsubscribes := make([]emailsRepositoryAdapterPort.UnsubscribeResult, len(obj))
+	for i, item := range obj {
+		unsubscribes[i] = emailsRepositoryAdapterPort.UnsubscribeResult(item)
+	}
+
+	return &unsubscribes, nil
+}
+
+// Suppressions
+
+func (a *adapter) CreateSuppression(ctx context.Context, data emailsRepositoryAdapterPort.CreateSuppressionData) (*emailsRepositoryAdapterPort.SuppressionResult, error) {
+	// Create model
+	obj := suppressionModel(data, time.Now())
+
+	// Save suppression to database
+	if err := a.postgres.WithContext(ctx).Create(&obj).Error; err != nil {
+		return nil, err
+	}
+
+	// Map model to repository results
+	suppression := emailsRepositoryAdapterPort.SuppressionResult(obj)
+
+	return &suppression, nil
+}
+
+func (a *adapter) FilterSuppressions(ctx context.Context, data emailsRepositoryAdapterPort.FilterSuppressionsData) (*[]emailsRepositoryAdapterPort.SuppressionResult, error) {
+	// Create model
+	obj := []model.EmailSuppression{}
+
+	// Create query with context
+	query := a.postgres.WithContext(ctx)
+
+	// Filter by id
+	if data.Id != nil {
+		query = query.Where("id IN ?", *data.Id)
+	}
+
+	// Filter by address
+	if data.Address != nil {
+		addresses := make([]string, len(*data.Address))
+		for i, address := range *data.Address {
+			addresses[i] = strings.ToLower(address)
+		}
+		query = query.Where("address IN ?", addresses)
+	}
+
+	// Filter by reason
+	if data.Reason != nil {
+		query = query.Where("reason IN ?", *data.Reason)
+	}
+
+	// Filter by source
+	if data.Source != nil {
+		query = query.Where("source IN ?", *data.Source)
+	}
+
+	// Filter by expiry
+	if data.Active != nil {
+		if *data.Active {
+			query = query.Where("expires IS NULL OR expires > ?", time.Now())
+		} else {
+			query = query.Where("expires <= ?", time.Now())
+		}
+	}
+
+	// Get suppressions from database
+	if err := query.Order("id").Find(&obj).Error; err != nil {
+		return nil, err
+	}
+
+	// Mapping model to repository
+	suppressions := make([]emailsRepositoryAdapterPort.SuppressionResult, len(obj))
+	for i, item := range obj {
+		suppressions[i] = emailsRepositoryAdapterPort.SuppressionResult(item)
+	}
+
+	return &suppressions, nil
+}
+
+func (a *adapter) DeleteSuppression(ctx context.Context, id uint) error {
+	// Delete suppression from database
+	result := a.postgres.WithContext(ctx).Delete(&model.EmailSuppression{}, "id = ?", id)
+
+	// Check errors
+	if result.Error != nil {
+		return result.Error
+	}
+
+	// If suppression not found
+	if result.RowsAffected == 0 {
+		return emailsRepositoryAdapterPort.ErrSuppressionNotFound
+	}
+
+	return nil
+}
+
+func (a *adapter) UpdateSuppression(ctx context.Context, id uint, data map[string]any) error {
+	// Update suppression in database
+	result := a.postgres.WithContext(ctx).Model(&model.EmailSuppression{}).Where("id = ?", id).Updates(data)
+
+	// Check errors
+	if result.Error != nil {
+		return result.Error
+	}
+
+	// If suppression not found
+	if result.RowsAffected == 0 {
+		return emailsRepositoryAdapterPort.ErrSuppressionNotFound
+	}
+
+	return nil
+}
+
+// Create or overwrite suppressions of the addresses, returns the number of saved suppressions
+func (a *adapter) ImportSuppressions(ctx context.Context, data []emailsRepositoryAdapterPort.CreateSuppressionData) (uint, error) {
+	if len(data) == 0 {
+		return 0, nil
+	}
+
+	// Create models
+	now := time.Now()
+	obj := make([]model.EmailSuppression, len(data))
+	for i, item := range data {
+		obj[i] = suppressionModel(item, now)
+	}
+
+	// Save suppressions to database, the created time of existing addresses is kept
+	result := a.postgres.WithContext(ctx).Clauses(
+		clause.OnConflict{
+			Columns:   []clause.Column{{Name: "address"}},
+			DoUpdates: clause.AssignmentColumns([]string{"reason", "source", "expires", "updated"}),
+		},
+	).CreateInBatches(&obj, 500)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return uint(result.RowsAffected), nil
+}
+
+// Create suppressions of addresses not suppressed yet, expired suppressions are renewed
+func (a *adapter) AddSuppressions(ctx context.Context, data []emailsRepositoryAdapterPort.CreateSuppressionData) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	// Create models
+	now := time.Now()
+	obj := make([]model.EmailSuppression, len(data))
+	for i, item := range data {
+		obj[i] = suppressionModel(item, now)
+	}
+
+	// Save suppressions to database, active suppressions are kept as they are
+	return a.postgres.WithContext(ctx).Clauses(
+		clause.OnConflict{
+			Columns:   []clause.Column{{Name: "address"}},
+			DoUpdates: clause.AssignmentColumns([]string{"reason", "source", "expires", "updated"}),
+			Where: clause.Where{
+				Exprs: []clause.Expression{
+					clause.Expr{SQL: "email_suppressions.expires <= ?", Vars: []any{now}},
+				},
+			},
+		},
+	).Create(&obj).Error
+}
+
+// Folders
+
+func (a *adapter) CreateFolder(ctx context.Context, data emailsRepositoryAdapterPort.CreateFolderData) (*emailsRepositoryAdapterPort.FolderResult, error) {
+	now := time.Now()
+
+	// Create model
+	obj := model.EmailFolder{
+		ParentId:    data.ParentId,
+		SenderId:    data.SenderId,
+		Name:        data.Name,
+		Description: data.Description,
+		SystemFlag:  data.SystemFlag,
+		Updated:     time.Unix(0, now.UnixNano()),
+		Created:     time.Unix(0, now.UnixNano()),
+	}
+
+	// Save folder to database
+	if err := a.postgres.WithContext(ctx).Create(&obj).Error; err != nil {
+		return nil, err
+	}
+
+	// Mapping model to repository
+	folder := emailsRepositoryAdapterPort.FolderResult{
+		Id:          obj.Id,
+		ParentId:    obj.ParentId,
+		SenderId:    obj.SenderId,
+		Name:        obj.Name,
+		Description: obj.Description,
+		SystemFlag:  obj.SystemFlag,
+		Updated:     obj.Updated,
+		Created:     obj.Created,
+	}
+
+	return &folder, nil
+}
+
+func (a *adapter) FilterFolders(ctx context.Context, data emailsRepositoryAdapterPort.FilterFoldersData) (*[]emailsRepositoryAdapterPort.FolderResult, error) {
+	// Create model
+	obj := []model.EmailFolder{}
+
+	// Create query with context skipping deleted folders
+	query := a.postgres.WithContext(ctx).Where("deleted IS NULL")
+
+	// Filter by id
+	if data.Id != nil {
+		query = query.Where("id IN ?", *data.Id)
+	}
+
+	// Filter by parent_id
+	if data.ParentId != nil {
+		var ids []uint
+		hasNull := false
+		for _, v := range *data.ParentId {
+			if v == nil {
+				hasNull = true
+			} else {
+				ids = append(ids, *v)
+			}
+		}
+
+		if len(ids) > 0 && hasNull {
+			query = query.Where("parent_id IN ? OR parent_id IS NULL", ids)
+		} else if len(ids) > 0 {
+			query = query.Where("parent_id IN ?", ids)
+		} else if hasNull {
+			query = query.Where("parent_id IS NULL")
+		}
+	}
+
+	// Filter by name
+	if data.Name != nil {
+		query = query.Where("name IN ?", *data.Name)
+	}
+
+	// Filter by system_flag
+	if data.SystemFlag != nil {
+		query = query.Where("system_flag = ?", *data.SystemFlag)
+	}
+
+	// Get folders from database
+	if err := query.Find(&obj).Error; err != nil {
+		return nil, err
+	}
+
+	// Mapping model to repository
+	folders := make([]emailsRepositoryAdapterPort.FolderResult, len(obj))
+	for i, item := range obj {
+		folders[i] = emailsRepositoryAdapterPort.FolderResult{
+			Id:          item.Id,
+			ParentId:    item.ParentId,
+			SenderId:    item.SenderId,
+			Name:        item.Name,
+			Description: item.Description,
+			SystemFlag:  item.SystemFlag,
+			Updated:     item.Updated,
+			Created:     item.Created,
+		}
+	}
+
+	return &folders, nil
+}
+
+func (a *adapter) DeleteFolder(ctx context.Context, id uint) error {
+	return a.postgres.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Unix(0, time.Now().UnixNano())
+
+		// Get folder subtree
+		var ids []uint
+		if err := tx.Raw(`
+			WITH RECURSIVE subtree AS (
+				SELECT id FROM email_folders WHERE id = ? AND deleted IS NULL
+				UNION ALL
+				SELECT f.id FROM email_folders f JOIN subtree s ON f.parent_id = s.id WHERE f.deleted IS NULL
+			)
+			SELECT id FROM subtree
+		`, id).Scan(&ids).Error; err != nil {
+			return err
+		}
+
+		// If folder not found
+		if len(ids) == 0 {
+			return emailsRepositoryAdapterPort.ErrFolderNotFound
+		}
+
+		// Move subtree folders and emails to trash
+		if err := tx.Model(&model.EmailFolder{}).Where("id IN ?", ids).Update("deleted", now).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&model.Email{}).Where("folder_id IN ? AND deleted IS NULL", ids).Update("deleted", now).Error; err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (a *adapter) UpdateFolder(ctx context.Context, id uint, data map[string]any) error {
+	// Update folder in database
+	result := a.postgres.WithContext(ctx).Model(&model.EmailFolder{}).Where("id = ? AND deleted IS NULL", id).Updates(data)
+
+	// Check errors
+	if result.Error != nil {
+		return result.Error
+	}
+
+	// If folder not found
+	if result.RowsAffected == 0 {
+		return emailsRepositoryAdapterPort.ErrFolderNotFound
+	}
+
+	return nil
+}
+
+func (a *adapter) CountFolderEmails(ctx context.Context) (*[]emailsRepositoryAdapterPort.FolderEmailsCountResult, error) {
+	// Create model
+	obj := []emailsRepositoryAdapterPort.FolderEmailsCountResult{}
+
+	// Aggregate emails by folder
+	if err := a.postgres.WithContext(ctx).
+		Model(&model.Email{}).
+		Select("folder_id, COUNT(*) AS count").
+		Where("deleted IS NULL").
+		Group("folder_id").
+		Scan(&obj).Error; err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
+
+// Emails
+
+func (a *adapter) CreateEmail(ctx context.Context, data emailsRepositoryAdapterPort.CreateEmailData) (*emailsRepositoryAdapterPort.EmailResult, error) {
+	now := time.Now()
+
+	// Create model
+	obj := model.Email{
+		FolderId:       data.FolderId,
+		Key:            data.Key,
+		SenderId:       data.SenderId,
+		Subject:        data.Subject,
+		Html:           data.Html,
+		Text:           data.Text,
+		ReplyTo:        data.ReplyTo,
+		Headers:        model.StringMap(data.Headers),
+		PdfAttachments: model.StringMap(data.PdfAttachments),
+		Category:       data.Category,
+		TrackOpens:     data.TrackOpens,
+		TrackClicks:    data.TrackClicks,
+		Description:    data.Description,
+		SystemFlag:     data.SystemFlag,
+		Updated:        time.Unix(0, now.UnixNano()),
+		Created:        time.Unix(0, now.UnixNano()),
+	}
+
+	// Save email to database
+	if err := a.postgres.WithContext(ctx).Create(&obj).Error; err != nil {
+		return nil, err
+	}
+
+	// Mapping model to repository
+	email := emailsRepositoryAdapterPort.EmailResult{
+		Id:             obj.Id,
+		FolderId:       obj.FolderId,
+		Key:            obj.Key,
+		SenderId:       obj.SenderId,
+		Subject:        obj.Subject,
+		Html:           obj.Html,
+		Text:           obj.Text,
+		ReplyTo:        obj.ReplyTo,
+		Headers:        obj.Headers,
+		PdfAttachments: obj.PdfAttachments,
+		Category:       obj.Category,
+		TrackOpens:     obj.TrackOpens,
+		TrackClicks:    obj.TrackClicks,
+		Description:    obj.Description,
+		SystemFlag:     obj.SystemFlag,
+		Updated:        obj.Updated,
+		Created:        obj.Created,
+	}
+
+	return &email, nil
+}
+
+func (a *adapter) FilterEmails(ctx context.Context, data emailsRepositoryAdapterPort.FilterEmailsData) (*[]emailsRepositoryAdapterPort.EmailResult, error) {
+	// Create model
+	obj := []model.Email{}
+
+	// Get emails from database
+	if err := a.filterEmailsQuery(ctx, data).Find(&obj).Error; err != nil {
+		return nil, err
+	}
+
+	// Mapping model to repository
+	emails := make([]emailsRepositoryAdapterPort.EmailResult, len(obj))
+	for i, item := range obj {
+		emails[i] = emailsRepositoryAdapterPort.EmailResult{
+			Id:             item.Id,
+			FolderId:       item.FolderId,
+			Key:            item.Key,
+			SenderId:       item.SenderId,
+			Subject:        item.Subject,
+			Html:           item.Html,
+			Text:           item.Text,
+			ReplyTo:        item.ReplyTo,
+			Headers:        item.Headers,
+			PdfAttachments: item.PdfAttachments,
+			Category:       item.Category,
+			TrackOpens:     item.TrackOpens,
+			TrackClicks:    item.TrackClicks,
+			Description:    item.Description,
+			SystemFlag:     item.SystemFlag,
+			Updated:        item.Updated,
+			Created:        item.Created,
+		}
+	}
+
+	return &emails, nil
+}
+
+func (a *adapter) SearchEmails(ctx context.Context, data emailsRepositoryAdapterPort.SearchEmailsData) (*[]emailsRepositoryAdapterPort.SearchEmailResult, error) {
+	// Create model
+	obj := []emailsRepositoryAdapterPort.SearchEmailResult{}
+
+	// Restrict search to filtered emails
+	filtered := a.filterEmailsQuery(
+		ctx,
+		emailsRepositoryAdapterPort.FilterEmailsData{
+			Id:         data.Id,
+			FolderId:   data.FolderId,
+			Key:        data.Key,
+			SystemFlag: data.SystemFlag,
+		},
+	).Model(&model.Email{}).Select("id")
+
+	// Rank full-text matches and fuzzy matches of subject and folder name
+	if err := a.postgres.WithContext(ctx).
+		Table("emails AS e").
+		Select(`
+			e.id,
+			e.folder_id,
+			f.name AS folder_name,
+			e.key,
+			e.subject,
+			e.description,
+			e.system_flag,
+			ts_rank_cd(e.search, q) + GREATEST(word_similarity(?, e.subject), COALESCE(word_similarity(?, f.name), 0)) AS rank,
+			ts_headline('simple', e.subject, q, 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true') AS highlight,
+			ts_headline('simple', COALESCE(NULLIF(e.text, ''), regexp_replace(e.html, '<[^>]*>', ' ', 'g')) || ' ' || e.description, q, 'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5') AS snippet,
+			e.updated,
+			e.created
+		`, data.Query, data.Query).
+		Joins("CROSS JOIN websearch_to_tsquery('simple', ?) AS q", data.Query).
+		Joins("LEFT JOIN email_folders AS f ON f.id = e.folder_id AND f.deleted IS NULL").
+		Where("e.id IN (?)", filtered).
+		Where("e.search @@ q OR ? <% e.subject OR ? <% f.name OR to_tsvector('simple', COALESCE(f.name, '')) @@ q", data.Query, data.Query).
+		Order("rank DESC, e.id").
+		Limit(int(data.Limit)).
+		Scan(&obj).Error; err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
+
+// Create query of emails not in trash matching the filter
+func (a *adapter) filterEmailsQuery(ctx context.Context, data emailsRepositoryAdapterPort.FilterEmailsData) *gorm.DB {
+	// Create query with context skipping deleted emails
+	query := a.postgres.WithContext(ctx).Where("deleted IS NULL")
+
+	// Filter by id
+	if data.Id != nil {
+		query = query.Where("id IN ?", *data.Id)
+	}
+
+	// Filter by folder_id
+	if data.FolderId != nil {
+		var ids []uint
+		hasNull := false
+		for _, v := range *data.FolderId {
+			if v == nil {
+				hasNull = true
+			} else {
+				ids = append(ids, *v)
+			}
+		}
+
+		if len(ids) > 0 && hasNull {
+			query = query.Where("folder_id IN ? OR folder_id IS NULL", ids)
+		} else if len(ids) > 0 {
+			query = query.Where("folder_id IN ?", ids)
+		} else if hasNull {
+			query = query.Where("folder_id IS NULL")
+		}
+	}
+
+	// Filter by key
+	if data.Key != nil {
+		query = query.Where("key IN ?", *data.Key)
+	}
+
+	// Filter by system_flag
+	if data.SystemFlag != nil {
+		query = query.Where("system_flag = ?", *data.SystemFlag)
+	}
+
+	return query
+}
+
+func (a *adapter) DeleteEmail(ctx context.Context, id uint) error {
+	// Move email to trash
+	result := a.postgres.WithContext(ctx).Model(&model.Email{}).Where("id = ? AND deleted IS NULL", id).Update("deleted", time.Unix(0, time.Now().UnixNano()))
+
+	// Check errors
+	if result.Error != nil {
+		return result.Error
+	}
+
+	// If email not found
+	if result.RowsAffected == 0 {
+		return emailsRepositoryAdapterPort.ErrEmailNotFound
+	}
+
+	return nil
+}
+
+func (a *adapter) UpdateEmail(ctx context.Context, id uint, data map[string]any) error {
+	// Convert maps to the column type
+	for _, field := range []string{"headers", "pdf_attachments"} {
+		if value, ok := data[field].(map[string]string); ok {
+			data[field] = model.StringMap(value)
+		}
+	}
+
+	// Update email in database
+	result := a.postgres.WithContext(ctx).Model(&model.Email{}).Where("id = ? AND deleted IS NULL", id).Updates(data)
+
+	// Check errors
+	if result.Error != nil {
+		return result.Error
+	}
+
+	// If email not found
+	if result.RowsAffected == 0 {
+		return emailsRepositoryAdapterPort.ErrEmailNotFound
+	}
+
+	return nil
+}
+
+func (a *adapter) MoveEmails(ctx context.Context, data emailsRepositoryAdapterPort.MoveEmailsData) error {
+	// Update emails folder in database
+	return a.postgres.WithContext(ctx).Model(&model.Email{}).Where("id IN ? AND deleted IS NULL", data.Ids).Updates(
+		map[string]any{
+			"folder_id": data.FolderId,
+			"updated":   time.Unix(0, time.Now().UnixNano()),
+		},
+	).Error
+}
+
+// Submit the message to the provider
+func (a *adapter) Send(ctx context.Context, data emailsRepositoryAdapterPort.SendData) (*emailsRepositoryAdapterPort.SendResult, error) {
+	var result emailsRepositoryAdapterPort.SendResult
+
+	// Send email with provider
+	send := a.sendSmtpBz
+	if a.provider == ProviderSmtp {
+		send = a.sendSmtp
+	}
+	if err := send(ctx, data, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// Log the message with its initial status
+func (a *adapter) CreateEmailLog(ctx context.Context, data emailsRepositoryAdapterPort.SendData, transition emailsRepositoryAdapterPort.TransitionData) (*emailsRepositoryAdapterPort.EmailLogResult, error) {
+	// Create model
+	obj := emailLog(data)
+	obj.Status = transition.To
+	obj.Created = transition.Created
+	obj.Transitions = []model.EmailLogTransition{
+		{
+			From:    transition.From,
+			To:      transition.To,
+			Reason:  transition.Reason,
+			Created: transition.Created,
+		},
+	}
+
+	// Save email with transition to database
+	if err := a.postgres.WithContext(ctx).Create(&obj).Error; err != nil {
+		return nil, err
+	}
+
+	// Map model to repository results
+	results := emailLogResult(obj)
+
+	return &results, nil
+}
+
+// Change status of the email log if it still has the previous status, and record the transition
+func (a *adapter) UpdateEmailLogStatus(ctx context.Context, id uint, data emailsRepositoryAdapterPort.TransitionData) (*emailsRepositoryAdapterPort.EmailLogTransitionResult, error) {
+	// Set email log data
+	log := map[string]any{
+		"status": data.To,
+	}
+	if data.MessageId != nil {
+		log["message_id"] = *data.MessageId
+	}
+	if data.Errors != nil {
+		log["errors"] = *data.Errors
+	}
+
+	// Create model
+	obj := model.EmailLogTransition{
+		LogId:   id,
+		From:    data.From,
+		To:      data.To,
+		Reason:  data.Reason,
+		Created: data.Created,
+	}
+
+	if err := a.postgres.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Update email log in database
+		result := tx.Model(&model.EmailLog{}).Where("id = ? AND status = ?", id, data.From).Updates(log)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		// If status changed meanwhile
+		if result.RowsAffected == 0 {
+			return emailsRepositoryAdapterPort.ErrEmailLogStatusChanged
+		}
+
+		// Save transition to database
+		return tx.Create(&obj).Error
+	}); err != nil {
+		return nil, err
+	}
+
+	// Map model to repository results
+	transition := emailsRepositoryAdapterPort.EmailLogTransitionResult(obj)
+
+	return &transition, nil
+}
+
+// Send through the smtp.bz API
+func (a *adapter) sendSmtpBz(ctx context.Context, data emailsRepositoryAdapterPort.SendData, result *emailsRepositoryAdapterPort.SendResult) error {
+	// Attachments, inline parts, calendar events and custom headers are sent only through the SMTP relay
+	if len(data.Attachments) > 0 || len(data.Inline) > 0 {
+		return emailsRepositoryAdapterPort.ErrAttachmentsNotSupported
+	}
+	if data.Calendar != nil {
+		return emailsRepositoryAdapterPort.ErrCalendarNotSupported
+	}
+	if len(data.Headers) > 0 {
+		return emailsRepositoryAdapterPort.ErrHeadersNotSupported
+	}
+
+	// Create buffer and multipart writer
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	// Set fields
+	if err := writer.WriteField("from", data.FromEmail); err != nil {
+		return err
+	}
+	if err := writer.WriteField("name", data.FromName); err != nil {
+		return err
+	}
+	if data.ReplyTo != "" {
+		if err := writer.WriteField("reply", data.ReplyTo); err != nil {
+			return err
+		}
+	}
+	if err := writer.WriteField("subject", data.Subject); err != nil {
+		return err
+	}
+	if err := writer.WriteField("to", strings.Join(data.To, ", ")); err != nil {
+		return err
+	}
+	if len(data.Cc) > 0 {
+		if err := writer.WriteField("cc", strings.Join(data.Cc, ", ")); err != nil {
+			return err
+		}
+	}
+	if len(data.Bcc) > 0 {
+		if err := writer.WriteField("bcc", strings.Join(data.Bcc, ", ")); err != nil {
+			return err
+		}
+	}
+	if err := writer.WriteField("html", data.Html); err != nil {
+		return err
+	}
+	if err := writer.WriteField("text", data.Text); err != nil {
+		return err
+	}
+
+	// Close writer
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("close writer: %v", err)
+	}
+
+	// Send service request
+	res, err := a.httpClient.Request(
+		// Context
+		ctx,
+		// Method
+		http.MethodPost,
+		// URL
+		a.smtpBzApiUrl+"/smtp/send",
+		// Body opt
+		client.WithRequestBodyOption(
+			body.Bytes(),
+		),
+		// Headers opts
+		client.WithRequestHeadersOption(
+			client.NewRequestHeader("Authorization", a.smtpBzApiKey),
+			client.NewRequestHeader("Content-Type", writer.FormDataContentType()),
+		),
+	)
+	if err != nil {
+		return fmt.Errorf("service smtp.bz unavailable: %v", err)
+	}
+
+	switch res.StatusCode() {
+	case sendEmailSuccessCode:
+		var response sendSuccessResponse
+		if err := json.Unmarshal(res.Body(), &response); err != nil {
+			return fmt.Errorf("error parsing success response body: %v", err)
+		}
+		result.MessageId = &response.Messageid
+	case sendEmailBadRequestCode:
+		var response sendErrorResponse
+		if err := json.Unmarshal(res.Body(), &response); err != nil {
+			return fmt.Errorf("error parsing bad request response body: %v", err)
+		}
+		var m map[string]interface{}
+		if err := json.Unmarshal(response.Errors, &m); err != nil {
+			return fmt.Errorf("error parsing bad request response errors: %v", err)
+		}
+		parts := make([]string, 0, len(m))
+		for k, v := range m {
+			parts = append(parts, fmt.Sprintf("%s: %v", k, v))
+		}
+		errors := strings.Join(parts, ", ")
+		result.Errors = &errors
+	case sendEmailUnautorizedCode:
+		message := "Unautorized"
+		result.Errors = &message
+	default:
+		return fmt.Errorf("service smtp.bz send: status %d", res.StatusCode())
+	}
+
+	return nil
+}
+
+func (a *adapter) FilterEmailLogs(ctx context.Context, data emailsRepositoryAdapterPort.FilterEmailLogsData) (*[]emailsRepositoryAdapterPort.EmailLogResult, error) {
+	// Create model
+	obj := []model.EmailLog{}
+
+	// Create query with context
+	query := a.postgres.WithContext(ctx)
+
+	// Filter by id
+	if data.Id != nil {
+		query = query.Where("id IN ?", *data.Id)
+	}
+
+	// Filter by email_id
+	if data.EmailId != nil {
+		query = query.Where("email_id IN ?", *data.EmailId)
+	}
+
+	// Filter by variant_id
+	if data.VariantId != nil {
+		query = query.Where("variant_id IN ?", *data.VariantId)
+	}
+
+	// Filter by from_email
+	if data.FromEmail != nil {
+		query = query.Where("from_email IN ?", *data.FromEmail)
+	}
+
+	// Filter by from_name
+	if data.FromName != nil {
+		query = query.Where("from_name IN ?", *data.FromName)
+	}
+
+	// Filter by any of to, cc and bcc addresses
+	if data.Recipient != nil {
+		recipients := make([]string, len(*data.Recipient))
+		for i, recipient := range *data.Recipient {
+			recipients[i] = strings.ToLower(strings.TrimSpace(recipient))
+		}
+		query = query.Where("recipients && ARRAY[?]::TEXT[]", recipients)
+	}
+
+	// Filter by status
+	if data.Status != nil {
+		query = query.Where("status IN ?", *data.Status)
+	}
+
+	// Filter by message_id
+	if data.MessageId != nil {
+		query = query.Where("message_id IN ?", *data.MessageId)
+	}
+
+	// Filter by event_uid
+	if data.EventUid != nil {
+		query = query.Where("event_uid IN ?", *data.EventUid)
+	}
+
+	// Get email logs with delivery events, transitions, opens and clicks from database
+	if err := query.Preload("Events", func(db *gorm.DB) *gorm.DB {
+		return db.Order("occurred, id")
+	}).Preload("Transitions", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}).Preload("Opens", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}).Preload("Clicks", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	}).Find(&obj).Error; err != nil {
+		return nil, err
+	}
+
+	// Mapping model to repository
 	logs := make([]emailsRepositoryAdapterPort.EmailLogResult, len(obj))
 	for i, item := range obj {
-		logs[i] = emailsRepositoryAdapterPort.EmailLogResult(item)
+		logs[i] = emailLogResult(item)
+	}
+
+	return &logs, nil
+}
+
+// Increase sequence of the event, the first message of the event has sequence 0 (RFC 5545, 3.8.7.4)
+func (a *adapter) NextEventSequence(ctx context.Context, data emailsRepositoryAdapterPort.EventSequenceData) (uint, error) {
+	now := time.Unix(0, time.Now().UnixNano())
+	var obj model.EmailEvent
+	if err := a.postgres.WithContext(ctx).Raw(`
+		INSERT INTO email_events (uid, sequence, method, updated, created) VALUES (?, 0, ?, ?, ?)
+		ON CONFLICT (uid) DO UPDATE SET sequence = email_events.sequence + 1, method = EXCLUDED.method, updated = EXCLUDED.updated
+		RETURNING *
+	`, data.Uid, data.Method, now, now).Scan(&obj).Error; err != nil {
+		return 0, err
+	}
+	return obj.Sequence, nil
+}
+
+// Delivery events
+
+// Parse a smtp.bz webhook callback carrying one event or an array of events, unknown events are skipped
+func (a *adapter) ParseSmtpBzWebhook(body []byte) ([]emailsRepositoryAdapterPort.DeliveryEventData, error) {
+	var items []smtpBzEvent
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		if err := json.Unmarshal(body, &items); err != nil {
+			return nil, emailsRepositoryAdapterPort.ErrWebhookInvalid
+		}
+	} else {
+		var item smtpBzEvent
+		if err := json.Unmarshal(body, &item); err != nil {
+			return nil, emailsRepositoryAdapterPort.ErrWebhookInvalid
+		}
+		items = append(items, item)
+	}
+
+	return smtpBzDeliveryEvents(items), nil
+}
+
+// Save delivery events, repeated events are skipped, returns the number of new events
+func (a *adapter) CreateDeliveryEvents(ctx context.Context, data []emailsRepositoryAdapterPort.CreateDeliveryEventData) (uint, error) {
+	if len(data) == 0 {
+		return 0, nil
+	}
+
+	// Create models
+	now := time.Unix(0, time.Now().UnixNano())
+	obj := make([]model.EmailDeliveryEvent, len(data))
+	for i, item := range data {
+		obj[i] = model.EmailDeliveryEvent{
+			LogId:     item.LogId,
+			Type:      item.Type,
+			Recipient: item.Recipient,
+			Reason:    item.Reason,
+			Hard:      item.Hard,
+			Occurred:  item.Occurred,
+			Created:   now,
+		}
+	}
+
+	// Save events to database
+	result := a.postgres.WithContext(ctx).Clauses(
+		clause.OnConflict{
+			Columns:   []clause.Column{{Name: "log_id"}, {Name: "type"}, {Name: "recipient"}, {Name: "occurred"}},
+			DoNothing: true,
+		},
+	).Create(&obj)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return uint(result.RowsAffected), nil
+}
+
+// Poll events of the message from the provider, only smtp.bz has a message status API
+func (a *adapter) GetDeliveryEvents(ctx context.Context, messageId string) ([]emailsRepositoryAdapterPort.DeliveryEventData, error) {
+	if a.provider == ProviderSmtp {
+		return nil, emailsRepositoryAdapterPort.ErrDeliveryStatusNotSupported
+	}
+
+	// Send service request
+	res, err := a.httpClient.Request(
+		// Context
+		ctx,
+		// Method
+		http.MethodGet,
+		// URL
+		a.smtpBzApiUrl+"/log/message/"+url.PathEscape(messageId),
+		// Headers opts
+		client.WithRequestHeadersOption(
+			client.NewRequestHeader("Authorization", a.smtpBzApiKey),
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("service smtp.bz unavailable: %v", err)
+	}
+
+	switch res.StatusCode() {
+	case messageEventsSuccessCode:
+		var response messageEventsResponse
+		if err := json.Unmarshal(res.Body(), &response); err != nil {
+			return nil, fmt.Errorf("error parsing message events response body: %v", err)
+		}
+		// Events of the message may omit its id
+		for i := range response.Events {
+			response.Events[i].MessageId = messageId
+		}
+		return smtpBzDeliveryEvents(response.Events), nil
+	case messageEventsNotFoundCode:
+		// Message is not logged by the provider yet
+		return nil, nil
+	case messageEventsRateLimitedCode:
+		return nil, emailsRepositoryAdapterPort.ErrProviderRateLimited
+	default:
+		return nil, fmt.Errorf("service smtp.bz message events: status %d", res.StatusCode())
+	}
+}
+
+// Get messages sent since the time without a final delivery status, least recently polled first
+func (a *adapter) FilterReconcileLogs(ctx context.Context, data emailsRepositoryAdapterPort.FilterReconcileLogsData) (*[]emailsRepositoryAdapterPort.ReconcileLogResult, error) {
+	// Create model
+	obj := []model.EmailLog{}
+
+	// Get email logs from database
+	if err := a.postgres.WithContext(ctx).
+		Select("id", "message_id").
+		Where("message_id IS NOT NULL AND status = ? AND created >= ?", emailsRepositoryAdapterPort.EmailStatusSent, data.Since).
+		Order("reconciled NULLS FIRST, id").
+		Limit(data.Limit).
+		Find(&obj).Error; err != nil {
+		return nil, err
+	}
+
+	// Mapping model to repository
+	logs := make([]emailsRepositoryAdapterPort.ReconcileLogResult, len(obj))
+	for i, item := range obj {
+		logs[i] = emailsRepositoryAdapterPort.ReconcileLogResult{
+			Id:        item.Id,
+			MessageId: *item.MessageId,
+		}
 	}
 
 	return &logs, nil
 }
+
+func (a *adapter) SetLogsReconciled(ctx context.Context, ids []uint, reconciled time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	// Update email logs in database
+	return a.postgres.WithContext(ctx).Model(&model.EmailLog{}).Where("id IN ?", ids).Update("reconciled", reconciled).Error
+}
+
+// Opens
+
+// Save open of the message, opens of the log are serialized to mark only one first open
+func (a *adapter) CreateEmailOpen(ctx context.Context, data emailsRepositoryAdapterPort.CreateEmailOpenData) (*emailsRepositoryAdapterPort.EmailOpenResult, error) {
+	// Create model
+	obj := model.EmailOpen{
+		LogId:     data.LogId,
+		Machine:   data.Machine,
+		UserAgent: data.UserAgent,
+		IpHash:    data.IpHash,
+		Created:   data.Created,
+	}
+
+	if err := a.postgres.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock email log
+		var log model.EmailLog
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", data.LogId).Limit(1).Find(&log)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return emailsRepositoryAdapterPort.ErrEmailLogNotFound
+		}
+
+		// Check first open
+		if !data.Machine {
+			var count int64
+			if err := tx.Model(&model.EmailOpen{}).Where("log_id = ? AND first", data.LogId).Count(&count).Error; err != nil {
+				return err
+			}
+			obj.First = count == 0
+		}
+
+		// Save open to database
+		return tx.Create(&obj).Error
+	}); err != nil {
+		return nil, err
+	}
+
+	// Map model to repository results
+	open := emailsRepositoryAdapterPort.EmailOpenResult(obj)
+
+	return &open, nil
+}
+
+// Clicks
+
+// Save click of the tracked link, the url is taken from the links of the log
+func (a *adapter) CreateEmailClick(ctx context.Context, data emailsRepositoryAdapterPort.CreateEmailClickData) (*emailsRepositoryAdapterPort.EmailClickResult, error) {
+	// Get email log links from database
+	var log model.EmailLog
+	result := a.postgres.WithContext(ctx).Select("id", "links").Where("id = ?", data.LogId).Limit(1).Find(&log)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, emailsRepositoryAdapterPort.ErrEmailLogNotFound
+	}
+	if data.Link < 0 || data.Link >= len(log.Links) {
+		return nil, emailsRepositoryAdapterPort.ErrEmailLinkNotFound
+	}
+
+	// Create model
+	obj := model.EmailClick{
+		LogId:     data.LogId,
+		Link:      data.Link,
+		Url:       log.Links[data.Link],
+		UserAgent: data.UserAgent,
+		IpHash:    data.IpHash,
+		Created:   data.Created,
+	}
+
+	// Save click to database
+	if err := a.postgres.WithContext(ctx).Create(&obj).Error; err != nil {
+		return nil, err
+	}
+
+	// Map model to repository results
+	click := emailsRepositoryAdapterPort.EmailClickResult(obj)
+
+	return &click, nil
+}
+
+func (a *adapter) GetClickStats(ctx context.Context, emailId uint) (*[]emailsRepositoryAdapterPort.ClickStatsResult, error) {
+	// Create model
+	obj := []emailsRepositoryAdapterPort.ClickStatsResult{}
+
+	// Aggregate clicks of the email logs by url
+	if err := a.postgres.WithContext(ctx).
+		Model(&model.EmailClick{}).
+		Select(`
+			email_clicks.url,
+			COUNT(*) AS clicks,
+			COUNT(DISTINCT email_clicks.log_id) AS unique_clicks
+		`).
+		Joins("JOIN email_logs ON email_logs.id = email_clicks.log_id").
+		Where("email_logs.email_id = ?", emailId).
+		Group("email_clicks.url").
+		Order("clicks DESC, email_clicks.url").
+		Scan(&obj).Error; err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
+
+// Variants
+
+func (a *adapter) CreateVariant(ctx context.Context, data emailsRepositoryAdapterPort.CreateVariantData) (*emailsRepositoryAdapterPort.VariantResult, error) {
+	now := time.Now()
+
+	// Create model
+	obj := model.EmailVariant{
+		EmailId: data.EmailId,
+		Name:    data.Name,
+		Subject: data.Subject,
+		Html:    data.Html,
+		Text:    data.Text,
+		Weight:  data.Weight,
+		Active:  true,
+		Updated: time.Unix(0, now.UnixNano()),
+		Created: time.Unix(0, now.UnixNano()),
+	}
+
+	// Save variant to database
+	if err := a.postgres.WithContext(ctx).Create(&obj).Error; err != nil {
+		return nil, err
+	}
+
+	// Mapping model to repository
+	variant := emailsRepositoryAdapterPort.VariantResult{
+		Id:      obj.Id,
+		EmailId: obj.EmailId,
+		Name:    obj.Name,
+		Subject: obj.Subject,
+		Html:    obj.Html,
+		Text:    obj.Text,
+		Weight:  obj.Weight,
+		Active:  obj.Active,
+		Updated: obj.Updated,
+		Created: obj.Created,
+	}
+
+	return &variant, nil
+}
+
+func (a *adapter) FilterVariants(ctx context.Context, data emailsRepositoryAdapterPort.FilterVariantsData) (*[]emailsRepositoryAdapterPort.VariantResult, error) {
+	// Create model
+	obj := []model.EmailVariant{}
+
+	// Create query with context
+	query := a.postgres.WithContext(ctx)
+
+	// Filter by id
+	if data.Id != nil {
+		query = query.Where("id IN ?", *data.Id)
+	}
+
+	// Filter by email_id
+	if data.EmailId != nil {
+		query = query.Where("email_id IN ?", *data.EmailId)
+	}
+
+	// Filter by active
+	if data.Active != nil {
+		query = query.Where("active = ?", *data.Active)
+	}
+
+	// Get variants from database
+	if err := query.Order("id").Find(&obj).Error; err != nil {
+		return nil, err
+	}
+
+	// Mapping model to repository
+	variants := make([]emailsRepositoryAdapterPort.VariantResult, len(obj))
+	for i, item := range obj {
+		variants[i] = emailsRepositoryAdapterPort.VariantResult{
+			Id:      item.Id,
+			EmailId: item.EmailId,
+			Name:    item.Name,
+			Subject: item.Subject,
+			Html:    item.Html,
+			Text:    item.Text,
+			Weight:  item.Weight,
+			Active:  item.Active,
+			Updated: item.Updated,
+			Created: item.Created,
+		}
+	}
+
+	return &variants, nil
+}
+
+func (a *adapter) DeleteVariant(ctx context.Context, id uint) error {
+	// Delete variant from database
+	result := a.postgres.WithContext(ctx).Delete(&model.EmailVariant{}, "id = ?", id)
+
+	// Check errors
+	if result.Error != nil {
+		return result.Error
+	}
+
+	// If variant not found
+	if result.RowsAffected == 0 {
+		return emailsRepositoryAdapterPort.ErrVariantNotFound
+	}
+
+	return nil
+}
+
+func (a *adapter) UpdateVariant(ctx context.Context, id uint, data map[string]any) error {
+	// Update variant in database
+	result := a.postgres.WithContext(ctx).Model(&model.EmailVariant{}).Where("id = ?", id).Updates(data)
+
+	// Check errors
+	if result.Error != nil {
+		return result.Error
+	}
+
+	// If variant not found
+	if result.RowsAffected == 0 {
+		return emailsRepositoryAdapterPort.ErrVariantNotFound
+	}
+
+	return nil
+}
+
+func (a *adapter) GetVariantStats(ctx context.Context, emailId uint) (*[]emailsRepositoryAdapterPort.VariantStatsResult, error) {
+	// Create model
+	obj := []emailsRepositoryAdapterPort.VariantStatsResult{}
+
+	// Aggregate email logs by variant
+	if err := a.postgres.WithContext(ctx).
+		Model(&model.EmailLog{}).
+		Select(`
+			variant_id,
+			COUNT(*) FILTER (WHERE status IN ('sent', 'delivered', 'bounced')) AS sent,
+			COUNT(*) FILTER (WHERE status = 'delivered') AS delivered,
+			COUNT(*) FILTER (WHERE status IN ('failed', 'bounced')) AS failed,
+			COUNT(*) FILTER (WHERE EXISTS (
+				SELECT 1 FROM email_opens WHERE email_opens.log_id = email_logs.id AND email_opens.first
+			)) AS unique_opens,
+			COUNT(*) FILTER (WHERE EXISTS (
+				SELECT 1 FROM email_clicks WHERE email_clicks.log_id = email_logs.id
+			)) AS unique_clicks
+		`).
+		Where("email_id = ? AND variant_id IS NOT NULL", emailId).
+		Group("variant_id").
+		Scan(&obj).Error; err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
+
+func (a *adapter) EndExperiment(ctx context.Context, emailId uint, data map[string]any) error {
+	return a.postgres.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Deactivate variants
+		if err := tx.Model(&model.EmailVariant{}).
+			Where("email_id = ? AND active", emailId).
+			Update("active", false).Error; err != nil {
+			return err
+		}
+
+		// No winner content to promote
+		if len(data) == 0 {
+			return nil
+		}
+
+		// Promote winner content to email
+		result := tx.Model(&model.Email{}).Where("id = ?", emailId).Updates(data)
+
+		// Check errors
+		if result.Error != nil {
+			return result.Error
+		}
+
+		// If email not found
+		if result.RowsAffected == 0 {
+			return emailsRepositoryAdapterPort.ErrEmailNotFound
+		}
+
+		return nil
+	})
+}
+
+// Bundles
+
+func (a *adapter) ImportBundle(ctx context.Context, data emailsRepositoryAdapterPort.ImportBundleData) error {
+	return a.postgres.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Unix(0, time.Now().UnixNano())
+
+		// Folder ids by bundle path
+		folders := make(map[string]*uint)
+		folderId := func(path []string) *uint {
+			if len(path) == 0 {
+				return data.ParentId
+			}
+			return folders[strings.Join(path, "\x00")]
+		}
+
+		// Import folders
+		for _, item := range data.Folders {
+			id := item.Id
+			parentId := folderId(item.Path[:len(item.Path)-1])
+
+			switch {
+			case id == nil:
+				// Create folder
+				obj := model.EmailFolder{
+					ParentId:    parentId,
+					SenderId:    item.SenderId,
+					Name:        item.Name,
+					Description: item.Description,
+					SystemFlag:  item.SystemFlag,
+					Updated:     now,
+					Created:     now,
+				}
+				if err := tx.Create(&obj).Error; err != nil {
+					return err
+				}
+				id = &obj.Id
+			case item.Update:
+				// Update folder
+				if err := tx.Model(&model.EmailFolder{}).Where("id = ?", *id).Updates(
+					map[string]any{
+						"description": item.Description,
+						"system_flag": item.SystemFlag,
+						"updated":     now,
+					},
+				).Error; err != nil {
+					return err
+				}
+			}
+
+			folders[strings.Join(item.Path, "\x00")] = id
+		}
+
+		// Import emails
+		for _, item := range data.Emails {
+			id := item.Id
+
+			switch {
+			case id == nil:
+				// Create email
+				obj := model.Email{
+					FolderId:       folderId(item.FolderPath),
+					Key:            item.Key,
+					SenderId:       item.SenderId,
+					Subject:        item.Subject,
+					Html:           item.Html,
+					Text:           item.Text,
+					ReplyTo:        item.ReplyTo,
+					Headers:        model.StringMap(item.Headers),
+					PdfAttachments: model.StringMap(item.PdfAttachments),
+					Category:       item.Category,
+					TrackOpens:     item.TrackOpens,
+					TrackClicks:    item.TrackClicks,
+					Description:    item.Description,
+					SystemFlag:     item.SystemFlag,
+					Updated:        now,
+					Created:        now,
+				}
+				if err := tx.Create(&obj).Error; err != nil {
+					return err
+				}
+				id = &obj.Id
+			case item.Update:
+				// Update email
+				if err := tx.Model(&model.Email{}).Where("id = ?", *id).Updates(
+					map[string]any{
+						"folder_id":       folderId(item.FolderPath),
+						"sender_id":       item.SenderId,
+						"subject":         item.Subject,
+						"html":            item.Html,
+						"text":            item.Text,
+						"reply_to":        item.ReplyTo,
+						"headers":         model.StringMap(item.Headers),
+						"pdf_attachments": model.StringMap(item.PdfAttachments),
+						"category":        item.Category,
+						"track_opens":     item.TrackOpens,
+						"track_clicks":    item.TrackClicks,
+						"description":     item.Description,
+						"system_flag":     item.SystemFlag,
+						"updated":         now,
+					},
+				).Error; err != nil {
+					return err
+				}
+			}
+
+			// Import variants
+			for _, variant := range item.Variants {
+				switch {
+				case variant.Id == nil:
+					// Create variant
+					obj := model.EmailVariant{
+						EmailId: *id,
+						Name:    variant.Name,
+						Subject: variant.Subject,
+						Html:    variant.Html,
+						Text:    variant.Text,
+						Weight:  variant.Weight,
+						Active:  variant.Active,
+						Updated: now,
+						Created: now,
+					}
+					if err := tx.Create(&obj).Error; err != nil {
+						return err
+					}
+				case variant.Update:
+					// Update variant
+					if err := tx.Model(&model.EmailVariant{}).Where("id = ?", *variant.Id).Updates(
+						map[string]any{
+							"subject": variant.Subject,
+							"html":    variant.Html,
+							"text":    variant.Text,
+							"weight":  variant.Weight,
+							"active":  variant.Active,
+							"updated": now,
+						},
+					).Error; err != nil {
+						return err
+					}
+				}
+			}
+		}
+
+		// Delete pruned entities after moving kept emails out of pruned folders,
+		// folders and emails are moved to trash
+		if len(data.DeleteVariants) > 0 {
+			if err := tx.Delete(&model.EmailVariant{}, "id IN ?", data.DeleteVariants).Error; err != nil {
+				return err
+			}
+		}
+		if len(data.DeleteEmails) > 0 {
+			if err := tx.Model(&model.Email{}).Where("id IN ? AND deleted IS NULL", data.DeleteEmails).Update("deleted", now).Error; err != nil {
+				return err
+			}
+		}
+		if len(data.DeleteFolders) > 0 {
+			if err := tx.Model(&model.EmailFolder{}).Where("id IN ? AND deleted IS NULL", data.DeleteFolders).Update("deleted", now).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// Trash
+
+func (a *adapter) FilterTrash(ctx context.Context) (*emailsRepositoryAdapterPort.TrashResult, error) {
+	// Create models
+	folders := []model.EmailFolder{}
+	emails := []model.Email{}
+
+	// Get deleted folders from database
+	if err := a.postgres.WithContext(ctx).Where("deleted IS NOT NULL").Order("deleted DESC, id").Find(&folders).Error; err != nil {
+		return nil, err
+	}
+
+	// Get deleted emails from database
+	if err := a.postgres.WithContext(ctx).Where("deleted IS NOT NULL").Order("deleted DESC, id").Find(&emails).Error; err != nil {
+		return nil, err
+	}
+
+	// Mapping model to repository
+	trash := emailsRepositoryAdapterPort.TrashResult{
+		Folders: make([]emailsRepositoryAdapterPort.TrashFolderResult, len(folders)),
+		Emails:  make([]emailsRepositoryAdapterPort.TrashEmailResult, len(emails)),
+	}
+	for i, item := range folders {
+		trash.Folders[i] = emailsRepositoryAdapterPort.TrashFolderResult{
+			Id:          item.Id,
+			ParentId:    item.ParentId,
+			Name:        item.Name,
+			Description: item.Description,
+			SystemFlag:  item.SystemFlag,
+			Deleted:     *item.Deleted,
+			Updated:     item.Updated,
+			Created:     item.Created,
+		}
+	}
+	for i, item := range emails {
+		trash.Emails[i] = emailsRepositoryAdapterPort.TrashEmailResult{
+			Id:          item.Id,
+			FolderId:    item.FolderId,
+			Key:         item.Key,
+			Subject:     item.Subject,
+			Description: item.Description,
+			SystemFlag:  item.SystemFlag,
+			Deleted:     *item.Deleted,
+			Updated:     item.Updated,
+			Created:     item.Created,
+		}
+	}
+
+	return &trash, nil
+}
+
+func (a *adapter) RestoreFolder(ctx context.Context, id uint, deleted time.Time) error {
+	return a.postgres.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Get folder subtree deleted together with the folder
+		var ids []uint
+		if err := tx.Raw(`
+			WITH RECURSIVE subtree AS (
+				SELECT id FROM email_folders WHERE id = ? AND deleted = ?
+				UNION ALL
+				SELECT f.id FROM email_folders f JOIN subtree s ON f.parent_id = s.id WHERE f.deleted = ?
+			)
+			SELECT id FROM subtree
+		`, id, deleted, deleted).Scan(&ids).Error; err != nil {
+			return err
+		}
+
+		// If folder not found
+		if len(ids) == 0 {
+			return emailsRepositoryAdapterPort.ErrFolderNotFound
+		}
+
+		// Restore subtree folders and emails
+		if err := tx.Model(&model.EmailFolder{}).Where("id IN ?", ids).Update("deleted", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&model.Email{}).Where("folder_id IN ? AND deleted = ?", ids, deleted).Update("deleted", nil).Error; err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (a *adapter) RestoreEmail(ctx context.Context, id uint) error {
+	// Restore email from trash
+	result := a.postgres.WithContext(ctx).Model(&model.Email{}).Where("id = ? AND deleted IS NOT NULL", id).Update("deleted", nil)
+
+	// Check errors
+	if result.Error != nil {
+		return result.Error
+	}
+
+	// If email not found
+	if result.RowsAffected == 0 {
+		return emailsRepositoryAdapterPort.ErrEmailNotFound
+	}
+
+	return nil
+}
+
+func (a *adapter) PurgeTrash(ctx context.Context, before time.Time) error {
+	return a.postgres.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Delete emails before their folders
+		if err := tx.Delete(
+			&model.Email{},
+			"deleted < ? OR folder_id IN (SELECT id FROM email_folders WHERE deleted < ?)",
+			before,
+			before,
+		).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&model.EmailFolder{}, "deleted < ?", before).Error; err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+// Map smtp.bz events to delivery events, unknown events are skipped
+func smtpBzDeliveryEvents(items []smtpBzEvent) []emailsRepositoryAdapterPort.DeliveryEventData {
+	results := make([]emailsRepositoryAdapterPort.DeliveryEventData, 0, len(items))
+	for _, item := range items {
+		event, ok := smtpBzEvents[strings.ToLower(item.Event)]
+		if !ok || item.MessageId == "" {
+			continue
+		}
+		event.MessageId = item.MessageId
+		event.Recipient = strings.ToLower(strings.TrimSpace(item.Email))
+		event.Reason = item.Reason
+		event.Occurred = time.Unix(item.Time, 0)
+		if item.Time == 0 {
+			event.Occurred = time.Unix(0, time.Now().UnixNano())
+		}
+		results = append(results, event)
+	}
+	return results
+}
+
+// Addresses are stored lowercased
+func suppressionModel(data emailsRepositoryAdapterPort.CreateSuppressionData, now time.Time) model.EmailSuppression {
+	return model.EmailSuppression{
+		Address: strings.ToLower(data.Address),
+		Reason:  data.Reason,
+		Source:  data.Source,
+		Expires: data.Expires,
+		Updated: time.Unix(0, now.UnixNano()),
+		Created: time.Unix(0, now.UnixNano()),
+	}
+}
+
+// Log model of the message, attachments are kept as metadata only
+func emailLog(data emailsRepositoryAdapterPort.SendData) model.EmailLog {
+	obj := model.EmailLog{
+		EmailId:     data.EmailId,
+		VariantId:   data.VariantId,
+		FromEmail:   data.FromEmail,
+		FromName:    data.FromName,
+		Subject:     data.Subject,
+		To:          recipientList(data.To),
+		Cc:          recipientList(data.Cc),
+		Bcc:         recipientList(data.Bcc),
+		Html:        data.Html,
+		Text:        data.Text,
+		Attachments: make([]model.EmailLogAttachment, 0, len(data.Attachments)),
+		Links:       append([]string{}, data.Links...),
+		Created:     time.Unix(0, time.Now().UnixNano()),
+	}
+	if data.Calendar != nil {
+		obj.EventUid = &data.Calendar.Uid
+	}
+
+	for _, attachment := range data.Attachments {
+		sum := sha256.Sum256(attachment.Content)
+		obj.Attachments = append(
+			obj.Attachments,
+			model.EmailLogAttachment{
+				Filename:    attachment.Filename,
+				ContentType: attachment.ContentType,
+				Size:        int64(len(attachment.Content)),
+				Sha256:      hex.EncodeToString(sum[:]),
+			},
+		)
+	}
+	return obj
+}
+
+func emailLogResult(obj model.EmailLog) emailsRepositoryAdapterPort.EmailLogResult {
+	attachments := make([]emailsRepositoryAdapterPort.EmailLogAttachmentResult, len(obj.Attachments))
+	for i, item := range obj.Attachments {
+		attachments[i] = emailsRepositoryAdapterPort.EmailLogAttachmentResult(item)
+	}
+	events := make([]emailsRepositoryAdapterPort.DeliveryEventResult, len(obj.Events))
+	for i, item := range obj.Events {
+		events[i] = emailsRepositoryAdapterPort.DeliveryEventResult(item)
+	}
+	transitions := make([]emailsRepositoryAdapterPort.EmailLogTransitionResult, len(obj.Transitions))
+	for i, item := range obj.Transitions {
+		transitions[i] = emailsRepositoryAdapterPort.EmailLogTransitionResult(item)
+	}
+	opens := make([]emailsRepositoryAdapterPort.EmailOpenResult, len(obj.Opens))
+	for i, item := range obj.Opens {
+		opens[i] = emailsRepositoryAdapterPort.EmailOpenResult(item)
+	}
+	clicks := make([]emailsRepositoryAdapterPort.EmailClickResult, len(obj.Clicks))
+	for i, item := range obj.Clicks {
+		clicks[i] = emailsRepositoryAdapterPort.EmailClickResult(item)
+	}
+	return emailsRepositoryAdapterPort.EmailLogResult{
+		Id:          obj.Id,
+		EmailId:     obj.EmailId,
+		VariantId:   obj.VariantId,
+		FromEmail:   obj.FromEmail,
+		FromName:    obj.FromName,
+		Subject:     obj.Subject,
+		To:          obj.To,
+		Cc:          obj.Cc,
+		Bcc:         obj.Bcc,
+		Html:        obj.Html,
+		Text:        obj.Text,
+		Status:      obj.Status,
+		MessageId:   obj.MessageId,
+		Errors:      obj.Errors,
+		Attachments: attachments,
+		EventUid:    obj.EventUid,
+		Links:       obj.Links,
+		Events:      events,
+		Transitions: transitions,
+		Opens:       opens,
+		Clicks:      clicks,
+		Created:     obj.Created,
+	}
+}
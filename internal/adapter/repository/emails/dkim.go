@@ -0,0 +1,193 @@
+package adapter
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	emailsRepositoryAdapterPort "github.com/flash-go/notifications-service/internal/port/adapter/repository/emails"
+)
+
+// Headers signed when present in the message, one-click unsubscribe requires signed List-Unsubscribe headers (RFC 8058, 4)
+var dkimSignedHeaders = []string{
+	"From",
+	"Reply-To",
+	"To",
+	"Cc",
+	"Subject",
+	"Date",
+	"Message-ID",
+	"In-Reply-To",
+	"References",
+	"MIME-Version",
+	"Content-Type",
+	"List-Unsubscribe",
+	"List-Unsubscribe-Post",
+}
+
+type dkimSigner struct {
+	domain    string
+	selector  string
+	algorithm string
+	key       crypto.Signer
+}
+
+// Create signer from the stored domain key
+func newDkimSigner(domain string, key emailsRepositoryAdapterPort.DomainKeyResult) (*dkimSigner, error) {
+	block, _ := pem.Decode([]byte(key.PrivateKey))
+	if block == nil {
+		return nil, fmt.Errorf("dkim key %s: invalid pem", key.Selector)
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("dkim key %s: %v", key.Selector, err)
+	}
+	var signer crypto.Signer
+	switch private := parsed.(type) {
+	case *rsa.PrivateKey:
+		if key.Algorithm == emailsRepositoryAdapterPort.DkimRsaSha256 {
+			signer = private
+		}
+	case ed25519.PrivateKey:
+		if key.Algorithm == emailsRepositoryAdapterPort.DkimEd25519Sha256 {
+			signer = private
+		}
+	}
+	if signer == nil {
+		return nil, fmt.Errorf("dkim key %s: key does not match algorithm %s", key.Selector, key.Algorithm)
+	}
+	return &dkimSigner{
+		domain:    domain,
+		selector:  key.Selector,
+		algorithm: key.Algorithm,
+		key:       signer,
+	}, nil
+}
+
+// Prepend DKIM-Signature header with relaxed/relaxed canonicalization
+func (s *dkimSigner) Sign(message []byte, now time.Time) ([]byte, error) {
+	// Split header and body
+	header, body, ok := bytes.Cut(message, []byte("\r\n\r\n"))
+	if !ok {
+		return nil, fmt.Errorf("dkim: message has no body")
+	}
+
+	// Hash body
+	bodyHash := sha256.Sum256(dkimRelaxedBody(body))
+
+	// Canonicalize signed headers
+	fields := dkimHeaderFields(header)
+	var signed bytes.Buffer
+	names := make([]string, 0, len(dkimSignedHeaders))
+	for _, name := range dkimSignedHeaders {
+		if field, ok := dkimLastField(fields, name); ok {
+			names = append(names, strings.ToLower(name))
+			signed.WriteString(dkimRelaxedHeader(field))
+		}
+	}
+
+	// Add signature header without signature
+	value := fmt.Sprintf(
+		"v=1; a=%s; c=relaxed/relaxed; d=%s; s=%s; t=%d; h=%s; bh=%s; b=",
+		s.algorithm,
+		s.domain,
+		s.selector,
+		now.Unix(),
+		strings.Join(names, ":"),
+		base64.StdEncoding.EncodeToString(bodyHash[:]),
+	)
+	signed.WriteString(strings.TrimSuffix(dkimRelaxedHeader("DKIM-Signature: "+value), "\r\n"))
+
+	// Sign headers hash, Ed25519 signs the hash itself
+	hash := sha256.Sum256(signed.Bytes())
+	opts := crypto.Hash(0)
+	if s.algorithm == emailsRepositoryAdapterPort.DkimRsaSha256 {
+		opts = crypto.SHA256
+	}
+	signature, err := s.key.Sign(rand.Reader, hash[:], opts)
+	if err != nil {
+		return nil, fmt.Errorf("dkim: %v", err)
+	}
+
+	// Prepend signature header
+	var signedMessage bytes.Buffer
+	signedMessage.WriteString("DKIM-Signature: " + value + base64.StdEncoding.EncodeToString(signature) + "\r\n")
+	signedMessage.Write(message)
+
+	return signedMessage.Bytes(), nil
+}
+
+// Split header into fields, keeping folded lines
+func dkimHeaderFields(header []byte) []string {
+	var fields []string
+	for _, line := range strings.Split(string(header), "\r\n") {
+		if len(fields) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			fields[len(fields)-1] += "\r\n" + line
+			continue
+		}
+		fields = append(fields, line)
+	}
+	return fields
+}
+
+// Last field with the name is signed first
+func dkimLastField(fields []string, name string) (string, bool) {
+	for i := len(fields) - 1; i >= 0; i-- {
+		if key, _, ok := strings.Cut(fields[i], ":"); ok && strings.EqualFold(strings.TrimSpace(key), name) {
+			return fields[i], true
+		}
+	}
+	return "", false
+}
+
+// Lowercase name, unfold and compress whitespace of the value (RFC 6376, 3.4.2)
+func dkimRelaxedHeader(field string) string {
+	name, value, _ := strings.Cut(field, ":")
+	value = strings.ReplaceAll(value, "\r\n", "")
+	return strings.ToLower(strings.TrimSpace(name)) + ":" + strings.Trim(dkimCompressWsp(value), " ") + "\r\n"
+}
+
+// Compress whitespace, strip trailing whitespace and empty lines (RFC 6376, 3.4.4)
+func dkimRelaxedBody(body []byte) []byte {
+	lines := strings.Split(string(body), "\r\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(dkimCompressWsp(line), " ")
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	return []byte(strings.Join(lines, "\r\n") + "\r\n")
+}
+
+// Replace every sequence of spaces and tabs with a single space
+func dkimCompressWsp(line string) string {
+	var b strings.Builder
+	wsp := false
+	for _, r := range line {
+		if r == ' ' || r == '\t' {
+			wsp = true
+			continue
+		}
+		if wsp {
+			b.WriteByte(' ')
+			wsp = false
+		}
+		b.WriteRune(r)
+	}
+	if wsp {
+		b.WriteByte(' ')
+	}
+	return b.String()
+}
@@ -0,0 +1,231 @@
+package adapter
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	emailsRepositoryAdapterPort "github.com/flash-go/notifications-service/internal/port/adapter/repository/emails"
+)
+
+const dkimTestMessage = "From: Sender <sender@example.com>\r\n" +
+	"To: recipient@example.org\r\n" +
+	"Subject:   Monthly\t news \r\n" +
+	"   and updates\r\n" +
+	"Date: Mon, 19 Oct 2026 10:00:00 +0000\r\n" +
+	"Message-ID: <1@example.com>\r\n" +
+	"X-Custom: not signed\r\n" +
+	"List-Unsubscribe: <https://example.com/notifications/emails/unsubscribe/token>\r\n" +
+	"List-Unsubscribe-Post: List-Unsubscribe=One-Click\r\n" +
+	"MIME-Version: 1.0\r\n" +
+	"Content-Type: text/plain; charset=utf-8\r\n" +
+	"\r\n" +
+	"Hello  \t world \r\n" +
+	"\r\n" +
+	" indented line\r\n" +
+	"\r\n" +
+	"\r\n"
+
+func TestDkimRelaxedCanonicalization(t *testing.T) {
+	// Example of RFC 6376, 3.4.5
+	headers := []struct {
+		field string
+		want  string
+	}{
+		{"A: X", "a:X\r\n"},
+		{"B : Y\t\r\n\tZ  ", "b:Y Z\r\n"},
+	}
+	for _, tt := range headers {
+		if got := dkimRelaxedHeader(tt.field); got != tt.want {
+			t.Errorf("dkimRelaxedHeader(%q) = %q, want %q", tt.field, got, tt.want)
+		}
+	}
+
+	bodies := []struct {
+		body string
+		want string
+	}{
+		{" C \r\nD \t E\r\n\r\n\r\n", " C\r\nD E\r\n"},
+		{"", ""},
+		{"\r\n\r\n", ""},
+		{"no line break", "no line break\r\n"},
+	}
+	for _, tt := range bodies {
+		if got := string(dkimRelaxedBody([]byte(tt.body))); got != tt.want {
+			t.Errorf("dkimRelaxedBody(%q) = %q, want %q", tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestDkimSign(t *testing.T) {
+	for _, algorithm := range []string{emailsRepositoryAdapterPort.DkimRsaSha256, emailsRepositoryAdapterPort.DkimEd25519Sha256} {
+		t.Run(algorithm, func(t *testing.T) {
+			key := testDkimKey(t, algorithm)
+			signer, err := newDkimSigner("example.com", key)
+			if err != nil {
+				t.Fatalf("newDkimSigner() error = %v", err)
+			}
+
+			// Sign message
+			now := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+			signed, err := signer.Sign([]byte(dkimTestMessage), now)
+			if err != nil {
+				t.Fatalf("Sign() error = %v", err)
+			}
+			field, message, ok := strings.Cut(string(signed), "\r\n")
+			if !ok || message != dkimTestMessage {
+				t.Fatalf("Sign() did not prepend a header to the message")
+			}
+			tags := testDkimTags(t, field)
+
+			// Check tags
+			want := map[string]string{
+				"v": "1",
+				"a": algorithm,
+				"c": "relaxed/relaxed",
+				"d": "example.com",
+				"s": key.Selector,
+				"t": "1792404000",
+				"h": "from:to:subject:date:message-id:mime-version:content-type:list-unsubscribe:list-unsubscribe-post",
+			}
+			for tag, value := range want {
+				if tags[tag] != value {
+					t.Errorf("tag %s = %q, want %q", tag, tags[tag], value)
+				}
+			}
+
+			// Check body hash
+			bodyHash := sha256.Sum256([]byte("Hello world\r\n\r\n indented line\r\n"))
+			if tags["bh"] != base64.StdEncoding.EncodeToString(bodyHash[:]) {
+				t.Errorf("tag bh = %q, want hash of the relaxed body", tags["bh"])
+			}
+
+			// Verify signature with the published key
+			signature, err := base64.StdEncoding.DecodeString(tags["b"])
+			if err != nil {
+				t.Fatalf("tag b: %v", err)
+			}
+			hash := testDkimHeaderHash(message, field, strings.Split(tags["h"], ":"))
+			if !testDkimVerify(t, key, hash, signature) {
+				t.Errorf("signature does not verify with the public key")
+			}
+
+			// Changed signed header breaks the signature
+			tampered := strings.Replace(message, "List-Unsubscribe=One-Click", "List-Unsubscribe=Other", 1)
+			if testDkimVerify(t, key, testDkimHeaderHash(tampered, field, strings.Split(tags["h"], ":")), signature) {
+				t.Errorf("signature verifies after List-Unsubscribe-Post changed")
+			}
+		})
+	}
+}
+
+func TestNewDkimSignerAlgorithmMismatch(t *testing.T) {
+	key := testDkimKey(t, emailsRepositoryAdapterPort.DkimEd25519Sha256)
+	key.Algorithm = emailsRepositoryAdapterPort.DkimRsaSha256
+	if _, err := newDkimSigner("example.com", key); err == nil {
+		t.Errorf("newDkimSigner() accepted an Ed25519 key as %s", key.Algorithm)
+	}
+}
+
+// Domain key in the stored form: PKCS #8 private key, base64 public key of the DNS record
+func testDkimKey(t *testing.T, algorithm string) emailsRepositoryAdapterPort.DomainKeyResult {
+	t.Helper()
+	var private any
+	var public []byte
+	switch algorithm {
+	case emailsRepositoryAdapterPort.DkimEd25519Sha256:
+		publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		private, public = privateKey, publicKey
+	default:
+		privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if public, err = x509.MarshalPKIXPublicKey(&privateKey.PublicKey); err != nil {
+			t.Fatal(err)
+		}
+		private = privateKey
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return emailsRepositoryAdapterPort.DomainKeyResult{
+		Selector:   "s1",
+		Algorithm:  algorithm,
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		PublicKey:  base64.StdEncoding.EncodeToString(public),
+	}
+}
+
+// Tags of the DKIM-Signature header field
+func testDkimTags(t *testing.T, field string) map[string]string {
+	t.Helper()
+	value, ok := strings.CutPrefix(field, "DKIM-Signature: ")
+	if !ok {
+		t.Fatalf("first header is not DKIM-Signature: %q", field)
+	}
+	tags := make(map[string]string)
+	for _, tag := range strings.Split(value, ";") {
+		name, value, _ := strings.Cut(tag, "=")
+		tags[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	return tags
+}
+
+var testWspRegexp = regexp.MustCompile(`[ \t]+`)
+
+// Hash of the signed header fields and the signature field without b= value, canonicalized
+// independently of the signer (RFC 6376, 3.4.2 and 3.7)
+func testDkimHeaderHash(message, signatureField string, names []string) []byte {
+	header, _, _ := strings.Cut(message, "\r\n\r\n")
+	fields := strings.Split(regexp.MustCompile(`\r\n([^ \t])`).ReplaceAllString(header, "\x00$1"), "\x00")
+	canonical := func(field string) string {
+		name, value, _ := strings.Cut(field, ":")
+		value = strings.ReplaceAll(value, "\r\n", "")
+		value = strings.TrimSpace(testWspRegexp.ReplaceAllString(value, " "))
+		return strings.ToLower(strings.TrimSpace(name)) + ":" + value
+	}
+	var signed bytes.Buffer
+	for _, name := range names {
+		for i := len(fields) - 1; i >= 0; i-- {
+			if key, _, _ := strings.Cut(fields[i], ":"); strings.EqualFold(strings.TrimSpace(key), name) {
+				signed.WriteString(canonical(fields[i]) + "\r\n")
+				break
+			}
+		}
+	}
+	signed.WriteString(canonical(regexp.MustCompile(`b=[^;]*$`).ReplaceAllString(signatureField, "b=")))
+	hash := sha256.Sum256(signed.Bytes())
+	return hash[:]
+}
+
+// Verify the signature of the header hash with the public key of the DNS record
+func testDkimVerify(t *testing.T, key emailsRepositoryAdapterPort.DomainKeyResult, hash, signature []byte) bool {
+	t.Helper()
+	public, err := base64.StdEncoding.DecodeString(key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key.Algorithm == emailsRepositoryAdapterPort.DkimEd25519Sha256 {
+		return ed25519.Verify(ed25519.PublicKey(public), hash, signature)
+	}
+	parsed, err := x509.ParsePKIXPublicKey(public)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rsa.VerifyPKCS1v15(parsed.(*rsa.PublicKey), crypto.SHA256, hash, signature) == nil
+}
@@ -1,6 +1,10 @@
 package adapter
 
-import "encoding/json"
+import (
+	"encoding/json"
+
+	emailsRepositoryAdapterPort "github.com/flash-go/notifications-service/internal/port/adapter/repository/emails"
+)
 
 // Success codes
 
@@ -10,6 +14,12 @@ const (
 	sendEmailUnautorizedCode = 401
 )
 
+const (
+	messageEventsSuccessCode     = 200
+	messageEventsNotFoundCode    = 404
+	messageEventsRateLimitedCode = 429
+)
+
 // Responses
 
 type sendSuccessResponse struct {
@@ -21,3 +31,31 @@ type sendErrorResponse struct {
 	Result bool            `json:"result"`
 	Errors json.RawMessage `json:"errors"`
 }
+
+type messageEventsResponse struct {
+	Result bool          `json:"result"`
+	Events []smtpBzEvent `json:"events"`
+}
+
+// Events
+
+// Event of a message reported by smtp.bz webhooks and message status API
+type smtpBzEvent struct {
+	MessageId string `json:"messageid"`
+	Event     string `json:"event"`
+	Email     string `json:"email"`
+	Time      int64  `json:"time"`
+	Reason    string `json:"reason"`
+}
+
+// Delivery events of smtp.bz events, hard bounces are permanent failures
+var smtpBzEvents = map[string]emailsRepositoryAdapterPort.DeliveryEventData{
+	"delivered":   {Type: emailsRepositoryAdapterPort.DeliveryEventDelivered},
+	"deferred":    {Type: emailsRepositoryAdapterPort.DeliveryEventDeferred},
+	"soft_bounce": {Type: emailsRepositoryAdapterPort.DeliveryEventBounced},
+	"hard_bounce": {Type: emailsRepositoryAdapterPort.DeliveryEventBounced, Hard: true},
+	"bounce":      {Type: emailsRepositoryAdapterPort.DeliveryEventBounced, Hard: true},
+	"open":        {Type: emailsRepositoryAdapterPort.DeliveryEventOpened},
+	"spam":        {Type: emailsRepositoryAdapterPort.DeliveryEventComplained},
+	"complaint":   {Type: emailsRepositoryAdapterPort.DeliveryEventComplained},
+}
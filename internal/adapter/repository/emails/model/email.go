@@ -3,16 +3,24 @@ package model
 import "time"
 
 type Email struct {
-	Id          uint `gorm:"primarykey"`
-	FolderId    *uint
-	Folder      *EmailFolder `gorm:"foreignKey:FolderId;references:Id"`
-	FromEmail   string       `gorm:"not null"`
-	FromName    string       `gorm:"not null"`
-	Subject     string       `gorm:"not null"`
-	Html        string       `gorm:"not null"`
-	Text        string       `gorm:"not null"`
-	Description string       `gorm:"not null"`
-	SystemFlag  bool         `gorm:"not null"`
-	Updated     time.Time    `gorm:"not null"`
-	Created     time.Time    `gorm:"not null"`
+	Id             uint `gorm:"primarykey"`
+	FolderId       *uint
+	Folder         *EmailFolder `gorm:"foreignKey:FolderId;references:Id"`
+	SenderId       *uint
+	Sender         *EmailSender `gorm:"foreignKey:SenderId;references:Id"`
+	Key            string       `gorm:"not null"`
+	Subject        string       `gorm:"not null"`
+	Html           string       `gorm:"not null"`
+	Text           string       `gorm:"not null"`
+	ReplyTo        *string
+	Headers        StringMap `gorm:"type:jsonb;not null"`
+	PdfAttachments StringMap `gorm:"type:jsonb;not null"`
+	Category       *string
+	TrackOpens     bool   `gorm:"not null"`
+	TrackClicks    bool   `gorm:"not null"`
+	Description    string `gorm:"not null"`
+	SystemFlag     bool   `gorm:"not null"`
+	Deleted        *time.Time
+	Updated        time.Time `gorm:"not null"`
+	Created        time.Time `gorm:"not null"`
 }
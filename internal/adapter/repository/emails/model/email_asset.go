@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+type EmailAsset struct {
+	Id          uint      `gorm:"primaryKey"`
+	Name        string    `gorm:"not null"`
+	ContentType string    `gorm:"not null"`
+	Size        int64     `gorm:"not null"`
+	Sha256      string    `gorm:"not null"`
+	StorageKey  string    `gorm:"not null"`
+	Created     time.Time `gorm:"not null"`
+}
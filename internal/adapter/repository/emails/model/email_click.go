@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+type EmailClick struct {
+	Id        uint      `gorm:"primaryKey"`
+	LogId     uint      `gorm:"not null"`
+	Link      int       `gorm:"not null"`
+	Url       string    `gorm:"not null"`
+	UserAgent string    `gorm:"not null"`
+	IpHash    string    `gorm:"not null"`
+	Created   time.Time `gorm:"not null"`
+}
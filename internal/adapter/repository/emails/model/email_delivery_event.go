@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+type EmailDeliveryEvent struct {
+	Id        uint      `gorm:"primaryKey"`
+	LogId     uint      `gorm:"not null"`
+	Type      string    `gorm:"not null"`
+	Recipient string    `gorm:"not null"`
+	Reason    string    `gorm:"not null"`
+	Hard      bool      `gorm:"not null"`
+	Occurred  time.Time `gorm:"not null"`
+	Created   time.Time `gorm:"not null"`
+}
@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+type EmailDomain struct {
+	Id            uint   `gorm:"primaryKey"`
+	Name          string `gorm:"not null"`
+	SpfVerified   bool   `gorm:"not null"`
+	DkimVerified  bool   `gorm:"not null"`
+	DmarcVerified bool   `gorm:"not null"`
+	Verified      bool   `gorm:"not null"`
+	Checked       *time.Time
+	Updated       time.Time `gorm:"not null"`
+	Created       time.Time `gorm:"not null"`
+}
@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+type EmailDomainKey struct {
+	Id         uint      `gorm:"primaryKey"`
+	DomainId   uint      `gorm:"not null"`
+	Selector   string    `gorm:"not null"`
+	Algorithm  string    `gorm:"not null"`
+	PrivateKey string    `gorm:"not null"`
+	PublicKey  string    `gorm:"not null"`
+	Active     bool      `gorm:"not null"`
+	Verified   bool      `gorm:"not null"`
+	Created    time.Time `gorm:"not null"`
+}
@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+type EmailEvent struct {
+	Uid      string    `gorm:"primarykey"`
+	Sequence uint      `gorm:"not null"`
+	Method   string    `gorm:"not null"`
+	Updated  time.Time `gorm:"not null"`
+	Created  time.Time `gorm:"not null"`
+}
@@ -6,9 +6,12 @@ type EmailFolder struct {
 	Id          uint `gorm:"primaryKey"`
 	ParentId    *uint
 	Parent      *EmailFolder `gorm:"foreignKey:ParentId;references:Id"`
+	SenderId    *uint
+	Sender      *EmailSender `gorm:"foreignKey:SenderId;references:Id"`
 	Name        string       `gorm:"not null"`
 	Description string       `gorm:"not null"`
 	SystemFlag  bool         `gorm:"not null"`
-	Updated     time.Time    `gorm:"not null"`
-	Created     time.Time    `gorm:"not null"`
+	Deleted     *time.Time
+	Updated     time.Time `gorm:"not null"`
+	Created     time.Time `gorm:"not null"`
 }
@@ -3,15 +3,34 @@ package model
 import "time"
 
 type EmailLog struct {
-	Id        uint   `gorm:"primarykey"`
-	FromEmail string `gorm:"not null"`
-	FromName  string `gorm:"not null"`
-	Subject   string `gorm:"not null"`
-	ToEmail   string `gorm:"not null"`
-	Html      string `gorm:"not null"`
-	Text      string `gorm:"not null"`
-	Status    string `gorm:"not null"`
-	MessageId *string
-	Errors    *string
-	Created   time.Time `gorm:"not null"`
+	Id          uint `gorm:"primarykey"`
+	EmailId     *uint
+	VariantId   *uint
+	FromEmail   string   `gorm:"not null"`
+	FromName    string   `gorm:"not null"`
+	Subject     string   `gorm:"not null"`
+	To          []string `gorm:"column:to_emails;serializer:json;not null"`
+	Cc          []string `gorm:"column:cc_emails;serializer:json;not null"`
+	Bcc         []string `gorm:"column:bcc_emails;serializer:json;not null"`
+	Html        string   `gorm:"not null"`
+	Text        string   `gorm:"not null"`
+	Status      string   `gorm:"not null"`
+	MessageId   *string
+	Errors      *string
+	Attachments []EmailLogAttachment `gorm:"serializer:json;not null"`
+	EventUid    *string
+	Links       []string             `gorm:"serializer:json;not null"`
+	Events      []EmailDeliveryEvent `gorm:"foreignKey:LogId"`
+	Transitions []EmailLogTransition `gorm:"foreignKey:LogId"`
+	Opens       []EmailOpen          `gorm:"foreignKey:LogId"`
+	Clicks      []EmailClick         `gorm:"foreignKey:LogId"`
+	Reconciled  *time.Time
+	Created     time.Time `gorm:"not null"`
+}
+
+type EmailLogAttachment struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	Sha256      string `json:"sha256"`
 }
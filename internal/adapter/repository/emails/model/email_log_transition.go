@@ -0,0 +1,12 @@
+package model
+
+import "time"
+
+type EmailLogTransition struct {
+	Id      uint      `gorm:"primaryKey"`
+	LogId   uint      `gorm:"not null"`
+	From    string    `gorm:"column:from_status;not null"`
+	To      string    `gorm:"column:to_status;not null"`
+	Reason  string    `gorm:"not null"`
+	Created time.Time `gorm:"not null"`
+}
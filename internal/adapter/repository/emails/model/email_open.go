@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+type EmailOpen struct {
+	Id        uint      `gorm:"primaryKey"`
+	LogId     uint      `gorm:"not null"`
+	First     bool      `gorm:"not null"`
+	Machine   bool      `gorm:"not null"`
+	UserAgent string    `gorm:"not null"`
+	IpHash    string    `gorm:"not null"`
+	Created   time.Time `gorm:"not null"`
+}
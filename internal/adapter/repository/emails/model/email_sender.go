@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+type EmailSender struct {
+	Id          uint      `gorm:"primaryKey"`
+	Email       string    `gorm:"not null"`
+	Name        string    `gorm:"not null"`
+	ReplyTo     string    `gorm:"not null"`
+	Description string    `gorm:"not null"`
+	Updated     time.Time `gorm:"not null"`
+	Created     time.Time `gorm:"not null"`
+}
@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+type EmailSuppression struct {
+	Id      uint   `gorm:"primaryKey"`
+	Address string `gorm:"not null"`
+	Reason  string `gorm:"not null"`
+	Source  string `gorm:"not null"`
+	Expires *time.Time
+	Updated time.Time `gorm:"not null"`
+	Created time.Time `gorm:"not null"`
+}
@@ -0,0 +1,9 @@
+package model
+
+import "time"
+
+type EmailUnsubscribe struct {
+	Email    string    `gorm:"primarykey"`
+	Category string    `gorm:"primarykey"`
+	Created  time.Time `gorm:"not null"`
+}
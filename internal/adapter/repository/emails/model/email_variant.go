@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+type EmailVariant struct {
+	Id      uint `gorm:"primarykey"`
+	EmailId uint
+	Email   *Email `gorm:"foreignKey:EmailId;references:Id"`
+	Name    string `gorm:"not null"`
+	Subject *string
+	Html    *string
+	Text    *string
+	Weight  uint      `gorm:"not null"`
+	Active  bool      `gorm:"not null"`
+	Updated time.Time `gorm:"not null"`
+	Created time.Time `gorm:"not null"`
+}
@@ -0,0 +1,377 @@
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/flash-go/notifications-service/internal/adapter/repository/emails/model"
+	emailsRepositoryAdapterPort "github.com/flash-go/notifications-service/internal/port/adapter/repository/emails"
+)
+
+// Timeout of a relay session without context deadline
+const smtpTimeout = 30 * time.Second
+
+// Send raw message through the SMTP relay, signed with the active DKIM key of the sender domain
+func (a *adapter) sendSmtp(ctx context.Context, data emailsRepositoryAdapterPort.SendData, result *emailsRepositoryAdapterPort.SendResult) error {
+	domain := strings.ToLower(data.FromEmail[strings.LastIndex(data.FromEmail, "@")+1:])
+	now := time.Now()
+
+	// Generate message id
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return err
+	}
+	messageId := hex.EncodeToString(id) + "@" + domain
+
+	// Build message
+	message, err := buildMessage(data, messageId, now)
+	if err != nil {
+		return err
+	}
+
+	// Sign message
+	signer, err := a.domainSigner(ctx, domain)
+	if err != nil {
+		return err
+	}
+	if signer != nil {
+		if message, err = signer.Sign(message, now); err != nil {
+			return err
+		}
+	}
+
+	// Collect envelope recipients, bcc is not part of the message
+	var rcpt []string
+	for _, list := range [][]string{data.To, data.Cc, data.Bcc} {
+		addresses, err := parseAddresses(list)
+		if err != nil {
+			return err
+		}
+		for _, address := range addresses {
+			rcpt = append(rcpt, address.Address)
+		}
+	}
+
+	// Relay message, rejections are logged as errors
+	if err := a.relay(ctx, data.FromEmail, rcpt, message); err != nil {
+		var smtpErr *textproto.Error
+		if !errors.As(err, &smtpErr) {
+			return fmt.Errorf("smtp relay unavailable: %v", err)
+		}
+		message := smtpErr.Error()
+		result.Errors = &message
+		return nil
+	}
+
+	result.MessageId = &messageId
+
+	return nil
+}
+
+// Get signer of the active key of the domain, domains without key are not signed
+func (a *adapter) domainSigner(ctx context.Context, domain string) (*dkimSigner, error) {
+	var obj model.EmailDomainKey
+	result := a.postgres.WithContext(ctx).
+		Joins("JOIN email_domains ON email_domains.id = email_domain_keys.domain_id").
+		Where("email_domains.name = ? AND email_domain_keys.active", domain).
+		Limit(1).
+		Find(&obj)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return newDkimSigner(domain, emailsRepositoryAdapterPort.DomainKeyResult(obj))
+}
+
+func (a *adapter) relay(ctx context.Context, from string, to []string, message []byte) error {
+	host, _, err := net.SplitHostPort(a.smtpAddr)
+	if err != nil {
+		return err
+	}
+
+	// Connect
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", a.smtpAddr)
+	if err != nil {
+		return err
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(smtpTimeout)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	// Upgrade to TLS when supported
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+
+	// Authenticate
+	if a.smtpUsername != "" {
+		if err := client.Auth(smtp.PlainAuth("", a.smtpUsername, a.smtpPassword, host)); err != nil {
+			return err
+		}
+	}
+
+	// Send envelope and message
+	if err := client.Mail(from); err != nil {
+		return err
+	}
+	for _, rcpt := range to {
+		if err := client.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(message); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// Build MIME message with plain text, html and calendar alternatives
+func buildMessage(data emailsRepositoryAdapterPort.SendData, messageId string, date time.Time) ([]byte, error) {
+	// Write html with its inline parts
+	html, htmlType, err := buildHtml(data.Html, data.Inline)
+	if err != nil {
+		return nil, err
+	}
+
+	// Write alternatives
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	w, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/plain; charset=utf-8"},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := writeQuotedPrintable(w, data.Text); err != nil {
+		return nil, err
+	}
+	htmlHeader := textproto.MIMEHeader{"Content-Type": {htmlType}}
+	if len(data.Inline) == 0 {
+		htmlHeader.Set("Content-Transfer-Encoding", "quoted-printable")
+	}
+	w, err = writer.CreatePart(htmlHeader)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(html); err != nil {
+		return nil, err
+	}
+	// Calendar event is the last alternative, clients show it as an invitation (RFC 6047)
+	if data.Calendar != nil {
+		w, err = writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {mime.FormatMediaType("text/calendar", map[string]string{"method": data.Calendar.Method, "charset": "utf-8"})},
+			"Content-Transfer-Encoding": {"base64"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := writeBase64(w, data.Calendar.Content); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	contentType := "multipart/alternative; boundary=\"" + writer.Boundary() + "\""
+
+	// Wrap alternatives and attachments into a mixed message
+	if len(data.Attachments) > 0 {
+		var mixed bytes.Buffer
+		writer := multipart.NewWriter(&mixed)
+		w, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type": {contentType},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(body.Bytes()); err != nil {
+			return nil, err
+		}
+		for _, attachment := range data.Attachments {
+			mediaType, params, err := mime.ParseMediaType(attachment.ContentType)
+			if err != nil {
+				return nil, fmt.Errorf("attachment %q: %v", attachment.Filename, err)
+			}
+			params["name"] = attachment.Filename
+			w, err := writer.CreatePart(textproto.MIMEHeader{
+				"Content-Type":              {mime.FormatMediaType(mediaType, params)},
+				"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename})},
+				"Content-Transfer-Encoding": {"base64"},
+			})
+			if err != nil {
+				return nil, err
+			}
+			if err := writeBase64(w, attachment.Content); err != nil {
+				return nil, err
+			}
+		}
+		if err := writer.Close(); err != nil {
+			return nil, err
+		}
+		body = mixed
+		contentType = "multipart/mixed; boundary=\"" + writer.Boundary() + "\""
+	}
+
+	// Encode recipients
+	to, err := parseAddresses(data.To)
+	if err != nil {
+		return nil, err
+	}
+	cc, err := parseAddresses(data.Cc)
+	if err != nil {
+		return nil, err
+	}
+
+	// Write headers
+	var message bytes.Buffer
+	header := func(name, value string) {
+		message.WriteString(name + ": " + value + "\r\n")
+	}
+	header("From", (&mail.Address{Name: data.FromName, Address: data.FromEmail}).String())
+	if data.ReplyTo != "" {
+		header("Reply-To", data.ReplyTo)
+	}
+	header("To", joinAddresses(to))
+	if len(cc) > 0 {
+		header("Cc", joinAddresses(cc))
+	}
+	header("Subject", mime.QEncoding.Encode("utf-8", data.Subject))
+	header("Date", date.Format(time.RFC1123Z))
+	header("Message-ID", "<"+messageId+">")
+	for _, name := range slices.Sorted(maps.Keys(data.Headers)) {
+		header(name, mime.QEncoding.Encode("utf-8", data.Headers[name]))
+	}
+	header("MIME-Version", "1.0")
+	header("Content-Type", contentType)
+	message.WriteString("\r\n")
+	message.Write(body.Bytes())
+
+	return message.Bytes(), nil
+}
+
+// Encode html, with inline parts it is wrapped into a related multipart (RFC 2387)
+func buildHtml(html string, inline []emailsRepositoryAdapterPort.InlineData) ([]byte, string, error) {
+	if len(inline) == 0 {
+		var body bytes.Buffer
+		if err := writeQuotedPrintable(&body, html); err != nil {
+			return nil, "", err
+		}
+		return body.Bytes(), "text/html; charset=utf-8", nil
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	w, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/html; charset=utf-8"},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	if err := writeQuotedPrintable(w, html); err != nil {
+		return nil, "", err
+	}
+	for _, part := range inline {
+		w, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.ContentType},
+			"Content-Id":                {"<" + part.ContentId + ">"},
+			"Content-Disposition":       {mime.FormatMediaType("inline", map[string]string{"filename": part.ContentId})},
+			"Content-Transfer-Encoding": {"base64"},
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		if err := writeBase64(w, part.Content); err != nil {
+			return nil, "", err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, "", err
+	}
+	return body.Bytes(), mime.FormatMediaType("multipart/related", map[string]string{"type": "text/html", "boundary": writer.Boundary()}), nil
+}
+
+func parseAddresses(list []string) ([]*mail.Address, error) {
+	addresses := make([]*mail.Address, 0, len(list))
+	for _, item := range list {
+		address, err := mail.ParseAddress(item)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recipient %q: %v", item, err)
+		}
+		addresses = append(addresses, address)
+	}
+	return addresses, nil
+}
+
+// Join encoded addresses, folding the header line between them
+func joinAddresses(addresses []*mail.Address) string {
+	items := make([]string, len(addresses))
+	for i, address := range addresses {
+		items[i] = address.String()
+	}
+	return strings.Join(items, ",\r\n ")
+}
+
+func writeQuotedPrintable(w io.Writer, content string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(content)); err != nil {
+		return err
+	}
+	return qp.Close()
+}
+
+// Write base64 in lines of 76 characters (RFC 2045, 6.8)
+func writeBase64(w io.Writer, data []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 0 {
+		n := min(len(encoded), 76)
+		if _, err := io.WriteString(w, encoded[:n]+"\r\n"); err != nil {
+			return err
+		}
+		encoded = encoded[n:]
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	blobsStorageAdapterPort "github.com/flash-go/notifications-service/internal/port/adapter/storage/blobs"
+)
+
+type Config struct {
+	Dir string
+}
+
+func New(config *Config) blobsStorageAdapterPort.Interface {
+	return &adapter{
+		dir: config.Dir,
+	}
+}
+
+type adapter struct {
+	dir string
+}
+
+func (a *adapter) Put(ctx context.Context, key string, data []byte) error {
+	path, err := a.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	// Write to a temporary file and rename, so readers never see partial blobs
+	file, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return err
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(file.Name())
+		return err
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		os.Remove(file.Name())
+		return err
+	}
+
+	return nil
+}
+
+func (a *adapter) Get(ctx context.Context, key string) ([]byte, error) {
+	path, err := a.path(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, blobsStorageAdapterPort.ErrBlobNotFound
+	}
+	return data, err
+}
+
+func (a *adapter) Delete(ctx context.Context, key string) error {
+	path, err := a.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Resolve key to a path inside the storage directory
+func (a *adapter) path(key string) (string, error) {
+	if key == "" || !fs.ValidPath(key) || strings.Contains(key, "\\") {
+		return "", blobsStorageAdapterPort.ErrBlobInvalidKey
+	}
+	return filepath.Join(a.dir, filepath.FromSlash(key)), nil
+}
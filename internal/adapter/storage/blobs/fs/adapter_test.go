@@ -0,0 +1,66 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	blobsStorageAdapterPort "github.com/flash-go/notifications-service/internal/port/adapter/storage/blobs"
+)
+
+func TestRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	storage := New(
+		&Config{
+			Dir: dir,
+		},
+	)
+	ctx := context.Background()
+
+	for _, key := range []string{"logo.png", "files/2026/a b+c.txt"} {
+		t.Run(key, func(t *testing.T) {
+			// Put, overwrite and get
+			if err := storage.Put(ctx, key, []byte("first")); err != nil {
+				t.Fatalf("Put() error = %v", err)
+			}
+			if err := storage.Put(ctx, key, []byte("second")); err != nil {
+				t.Fatalf("Put() error = %v", err)
+			}
+			got, err := storage.Get(ctx, key)
+			if err != nil || string(got) != "second" {
+				t.Fatalf("Get() = %q, %v, want %q", got, err, "second")
+			}
+			if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(key))); err != nil {
+				t.Errorf("Put() did not write under the storage directory: %v", err)
+			}
+
+			// No temporary files are left
+			temps, _ := filepath.Glob(filepath.Join(filepath.Dir(filepath.Join(dir, filepath.FromSlash(key))), ".tmp-*"))
+			if len(temps) > 0 {
+				t.Errorf("Put() left temporary files %v", temps)
+			}
+
+			// Delete twice and get
+			for range 2 {
+				if err := storage.Delete(ctx, key); err != nil {
+					t.Fatalf("Delete() error = %v", err)
+				}
+			}
+			if _, err := storage.Get(ctx, key); !errors.Is(err, blobsStorageAdapterPort.ErrBlobNotFound) {
+				t.Errorf("Get() after Delete() error = %v, want %v", err, blobsStorageAdapterPort.ErrBlobNotFound)
+			}
+		})
+	}
+
+	// Invalid keys never leave the storage directory
+	for _, key := range []string{"", "../secret", "/abs", "a/../../b", "a\\b"} {
+		if err := storage.Put(ctx, key, []byte("x")); !errors.Is(err, blobsStorageAdapterPort.ErrBlobInvalidKey) {
+			t.Errorf("Put(%q) error = %v, want %v", key, err, blobsStorageAdapterPort.ErrBlobInvalidKey)
+		}
+		if _, err := storage.Get(ctx, key); !errors.Is(err, blobsStorageAdapterPort.ErrBlobInvalidKey) {
+			t.Errorf("Get(%q) error = %v, want %v", key, err, blobsStorageAdapterPort.ErrBlobInvalidKey)
+		}
+	}
+}
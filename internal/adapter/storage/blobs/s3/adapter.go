@@ -0,0 +1,190 @@
+package adapter
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/flash-go/flash/http/client"
+	blobsStorageAdapterPort "github.com/flash-go/notifications-service/internal/port/adapter/storage/blobs"
+)
+
+type Config struct {
+	HttpClient client.Client
+	Endpoint   string
+	Region     string
+	Bucket     string
+	AccessKey  string
+	SecretKey  string
+}
+
+// Objects are addressed path-style ({endpoint}/{bucket}/{key}), which
+// S3-compatible stores such as MinIO accept without DNS setup
+func New(config *Config) blobsStorageAdapterPort.Interface {
+	return &adapter{
+		httpClient: config.HttpClient,
+		endpoint:   strings.TrimSuffix(config.Endpoint, "/"),
+		region:     config.Region,
+		bucket:     config.Bucket,
+		accessKey:  config.AccessKey,
+		secretKey:  config.SecretKey,
+	}
+}
+
+type adapter struct {
+	httpClient client.Client
+	endpoint   string
+	region     string
+	bucket     string
+	accessKey  string
+	secretKey  string
+}
+
+func (a *adapter) Put(ctx context.Context, key string, data []byte) error {
+	status, body, err := a.request(ctx, http.MethodPut, key, data)
+	if err != nil {
+		return err
+	}
+	if status != 200 {
+		return fmt.Errorf("s3 put %q: status %d: %s", key, status, body)
+	}
+	return nil
+}
+
+func (a *adapter) Get(ctx context.Context, key string) ([]byte, error) {
+	status, body, err := a.request(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	switch status {
+	case 200:
+		return body, nil
+	case 404:
+		return nil, blobsStorageAdapterPort.ErrBlobNotFound
+	default:
+		return nil, fmt.Errorf("s3 get %q: status %d: %s", key, status, body)
+	}
+}
+
+func (a *adapter) Delete(ctx context.Context, key string) error {
+	status, body, err := a.request(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	if status != 204 && status != 200 && status != 404 {
+		return fmt.Errorf("s3 delete %q: status %d: %s", key, status, body)
+	}
+	return nil
+}
+
+// Send request signed with AWS Signature Version 4
+func (a *adapter) request(ctx context.Context, method, key string, data []byte) (int, []byte, error) {
+	if key == "" || !fs.ValidPath(key) || strings.Contains(key, "\\") {
+		return 0, nil, blobsStorageAdapterPort.ErrBlobInvalidKey
+	}
+
+	// Build object url
+	endpoint, err := url.Parse(a.endpoint)
+	if err != nil {
+		return 0, nil, fmt.Errorf("s3 endpoint: %v", err)
+	}
+	path := endpoint.Path + "/" + escapePath(a.bucket) + "/" + escapePath(key)
+
+	// Sign request
+	now := time.Now().UTC()
+	payloadHash := sha256Hex(data)
+	authorization := a.authorization(method, endpoint.Host, path, "", payloadHash, now)
+
+	// Send request
+	options := []client.RequestOption{
+		client.WithRequestHeadersOption(
+			client.NewRequestHeader("Authorization", authorization),
+			client.NewRequestHeader("X-Amz-Content-Sha256", payloadHash),
+			client.NewRequestHeader("X-Amz-Date", now.Format(amzDateFormat)),
+		),
+	}
+	if data != nil {
+		options = append(options, client.WithRequestBodyOption(data))
+	}
+	res, err := a.httpClient.Request(
+		ctx,
+		method,
+		endpoint.Scheme+"://"+endpoint.Host+path,
+		options...,
+	)
+	if err != nil {
+		return 0, nil, fmt.Errorf("s3 unavailable: %v", err)
+	}
+
+	return res.StatusCode(), res.Body(), nil
+}
+
+const (
+	signedHeaders = "host;x-amz-content-sha256;x-amz-date"
+	amzDateFormat = "20060102T150405Z"
+)
+
+// Authorization header value of the request signed with AWS Signature Version 4
+func (a *adapter) authorization(method, host, path, query, payloadHash string, now time.Time) string {
+	scope := now.Format("20060102") + "/" + a.region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		now.Format(amzDateFormat),
+		scope,
+		sha256Hex([]byte(canonicalRequest(method, host, path, query, payloadHash, now))),
+	}, "\n")
+	signingKey := []byte("AWS4" + a.secretKey)
+	for _, item := range []string{now.Format("20060102"), a.region, "s3", "aws4_request"} {
+		signingKey = hmacSha256(signingKey, item)
+	}
+	signature := hex.EncodeToString(hmacSha256(signingKey, stringToSign))
+	return "AWS4-HMAC-SHA256 Credential=" + a.accessKey + "/" + scope + ", SignedHeaders=" + signedHeaders + ", Signature=" + signature
+}
+
+// Canonical request of the signed headers, path and query are already escaped
+func canonicalRequest(method, host, path, query, payloadHash string, now time.Time) string {
+	return strings.Join([]string{
+		method,
+		path,
+		query,
+		"host:" + host,
+		"x-amz-content-sha256:" + payloadHash,
+		"x-amz-date:" + now.Format(amzDateFormat),
+		"",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+}
+
+// Escape every byte of the path except unreserved characters and slashes, as S3 expects
+// them in the canonical request (UriEncode of Signature Version 4)
+func escapePath(path string) string {
+	var b strings.Builder
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || strings.IndexByte("-._~/", c) >= 0 {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSha256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -1,7 +1,40 @@
 package config
 
 const (
-	UsersServiceNameOptKey   = "/users/serviceName"
-	UsersAdminRoleOptKey     = "/users/adminRole"
-	ProvidersEmailSmtpBzApiKeyOptKey = "/providers/email/smtp_bz/api_key"
+	UsersServiceNameOptKey             = "/users/serviceName"
+	UsersAdminRoleOptKey               = "/users/adminRole"
+	UsersSystemRoleOptKey              = "/users/systemRole"
+	ProvidersEmailProviderOptKey       = "/providers/email/provider"
+	ProvidersEmailSmtpBzApiKeyOptKey   = "/providers/email/smtp_bz/api_key"
+	ProvidersEmailSmtpBzApiUrlOptKey   = "/providers/email/smtp_bz/api_url"
+	ProvidersEmailSmtpAddrOptKey       = "/providers/email/smtp/addr"
+	ProvidersEmailSmtpUsernameOptKey   = "/providers/email/smtp/username"
+	ProvidersEmailSmtpPasswordOptKey   = "/providers/email/smtp/password"
+	EmailsTrashRetentionDaysOptKey     = "/emails/trash/retention_days"
+	EmailsDnsResolverOptKey            = "/emails/domains/dns_resolver"
+	EmailsDkimSelectorOptKey           = "/emails/domains/dkim_selector"
+	EmailsSpfIncludeOptKey             = "/emails/domains/spf_include"
+	EmailsUnverifiedDomainPolicyOptKey = "/emails/domains/unverified_policy"
+	EmailsMaxRecipientsOptKey          = "/emails/recipients/max"
+	EmailsRecipientModeOptKey          = "/emails/recipients/mode"
+	EmailsAttachmentsMaxSizeOptKey     = "/emails/attachments/max_size"
+	EmailsAttachmentTypesOptKey        = "/emails/attachments/types"
+	EmailsAssetsBaseUrlOptKey          = "/emails/assets/base_url"
+	EmailsAssetsMaxSizeOptKey          = "/emails/assets/max_size"
+	EmailsUnsubscribeBaseUrlOptKey     = "/emails/unsubscribe/base_url"
+	EmailsUnsubscribeSecretOptKey      = "/emails/unsubscribe/secret"
+	EmailsWebhookSecretOptKey          = "/emails/webhooks/secret"
+	EmailsReconcileIntervalOptKey      = "/emails/reconcile/interval"
+	EmailsReconcileWindowOptKey        = "/emails/reconcile/window"
+	EmailsReconcileBatchSizeOptKey     = "/emails/reconcile/batch_size"
+	EmailsReconcileRateOptKey          = "/emails/reconcile/rate"
+	EmailsTrackingBaseUrlOptKey        = "/emails/tracking/base_url"
+	EmailsTrackingSecretOptKey         = "/emails/tracking/secret"
+	StorageBlobsBackendOptKey          = "/storage/blobs/backend"
+	StorageBlobsDirOptKey              = "/storage/blobs/dir"
+	StorageBlobsS3EndpointOptKey       = "/storage/blobs/s3/endpoint"
+	StorageBlobsS3RegionOptKey         = "/storage/blobs/s3/region"
+	StorageBlobsS3BucketOptKey         = "/storage/blobs/s3/bucket"
+	StorageBlobsS3AccessKeyOptKey      = "/storage/blobs/s3/access_key"
+	StorageBlobsS3SecretKeyOptKey      = "/storage/blobs/s3/secret_key"
 )
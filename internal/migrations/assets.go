@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func Migration_notifications_assets() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "notifications_assets",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.Exec(`
+				CREATE TABLE IF NOT EXISTS email_assets (
+					id SERIAL PRIMARY KEY,
+					name TEXT NOT NULL UNIQUE,
+					content_type TEXT NOT NULL,
+					size BIGINT NOT NULL,
+					sha256 TEXT NOT NULL,
+					storage_key TEXT NOT NULL UNIQUE,
+					created TIMESTAMPTZ NOT NULL
+				);
+			`).Error; err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Exec(`DROP TABLE IF EXISTS email_assets;`).Error; err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
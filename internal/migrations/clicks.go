@@ -0,0 +1,59 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func Migration_notifications_clicks() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "notifications_clicks",
+		Migrate: func(tx *gorm.DB) error {
+			// Click tracking of emails
+			if err := tx.Exec(`ALTER TABLE emails ADD COLUMN IF NOT EXISTS track_clicks BOOLEAN NOT NULL DEFAULT false;`).Error; err != nil {
+				return err
+			}
+
+			// Tracked links of sent messages by link index
+			if err := tx.Exec(`ALTER TABLE email_logs ADD COLUMN IF NOT EXISTS links JSONB NOT NULL DEFAULT '[]';`).Error; err != nil {
+				return err
+			}
+
+			// Clicks of tracked links recorded by the redirect
+			if err := tx.Exec(`
+				CREATE TABLE IF NOT EXISTS email_clicks (
+					id SERIAL PRIMARY KEY,
+					log_id INTEGER NOT NULL REFERENCES email_logs(id) ON DELETE CASCADE,
+					link INTEGER NOT NULL,
+					url TEXT NOT NULL,
+					user_agent TEXT NOT NULL,
+					ip_hash TEXT NOT NULL,
+					created TIMESTAMPTZ NOT NULL
+				);
+			`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_email_clicks_log_id ON email_clicks(log_id);`).Error; err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Exec(`DROP TABLE IF EXISTS email_clicks;`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`ALTER TABLE email_logs DROP COLUMN IF EXISTS links;`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`ALTER TABLE emails DROP COLUMN IF EXISTS track_clicks;`).Error; err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,51 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func Migration_notifications_delivery_events() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "notifications_delivery_events",
+		Migrate: func(tx *gorm.DB) error {
+			// Timeline of delivery events reported by the provider for sent messages
+			if err := tx.Exec(`
+				CREATE TABLE IF NOT EXISTS email_delivery_events (
+					id SERIAL PRIMARY KEY,
+					log_id INTEGER NOT NULL REFERENCES email_logs(id) ON DELETE CASCADE,
+					type TEXT NOT NULL,
+					recipient TEXT NOT NULL,
+					reason TEXT NOT NULL,
+					hard BOOLEAN NOT NULL DEFAULT FALSE,
+					occurred TIMESTAMPTZ NOT NULL,
+					created TIMESTAMPTZ NOT NULL
+				);
+			`).Error; err != nil {
+				return err
+			}
+
+			// Repeated callbacks of the same event are recorded once
+			if err := tx.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_email_delivery_events_event ON email_delivery_events(log_id, type, recipient, occurred);`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_email_logs_message_id ON email_logs(message_id);`).Error; err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Exec(`DROP INDEX IF EXISTS idx_email_logs_message_id;`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`DROP TABLE IF EXISTS email_delivery_events;`).Error; err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,80 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func Migration_notifications_dkim_keys() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "notifications_dkim_keys",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.Exec(`
+				CREATE TABLE IF NOT EXISTS email_domain_keys (
+					id SERIAL PRIMARY KEY,
+					domain_id INTEGER NOT NULL REFERENCES email_domains(id) ON UPDATE CASCADE ON DELETE CASCADE,
+					selector TEXT NOT NULL,
+					algorithm TEXT NOT NULL,
+					private_key TEXT NOT NULL,
+					public_key TEXT NOT NULL,
+					active BOOLEAN NOT NULL DEFAULT FALSE,
+					verified BOOLEAN NOT NULL DEFAULT FALSE,
+					created TIMESTAMPTZ NOT NULL
+				);
+			`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_email_domain_keys_selector ON email_domain_keys(domain_id, selector);`).Error; err != nil {
+				return err
+			}
+
+			// A domain signs with a single active key
+			if err := tx.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_email_domain_keys_active ON email_domain_keys(domain_id) WHERE active;`).Error; err != nil {
+				return err
+			}
+
+			// Move existing domain keys
+			if err := tx.Exec(`
+				INSERT INTO email_domain_keys (domain_id, selector, algorithm, private_key, public_key, active, verified, created)
+				SELECT id, dkim_selector, 'rsa-sha256', dkim_private_key, dkim_public_key, TRUE, dkim_verified, created FROM email_domains;
+			`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`ALTER TABLE email_domains DROP COLUMN IF EXISTS dkim_selector, DROP COLUMN IF EXISTS dkim_private_key, DROP COLUMN IF EXISTS dkim_public_key;`).Error; err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Exec(`ALTER TABLE email_domains ADD COLUMN IF NOT EXISTS dkim_selector TEXT, ADD COLUMN IF NOT EXISTS dkim_private_key TEXT, ADD COLUMN IF NOT EXISTS dkim_public_key TEXT;`).Error; err != nil {
+				return err
+			}
+
+			// Restore active RSA keys, domains without one keep no key
+			if err := tx.Exec(`
+				UPDATE email_domains SET dkim_selector = email_domain_keys.selector, dkim_private_key = email_domain_keys.private_key, dkim_public_key = email_domain_keys.public_key
+				FROM email_domain_keys
+				WHERE email_domain_keys.domain_id = email_domains.id AND email_domain_keys.active AND email_domain_keys.algorithm = 'rsa-sha256';
+			`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`UPDATE email_domains SET dkim_selector = '', dkim_private_key = '', dkim_public_key = '' WHERE dkim_selector IS NULL;`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`ALTER TABLE email_domains ALTER COLUMN dkim_selector SET NOT NULL, ALTER COLUMN dkim_private_key SET NOT NULL, ALTER COLUMN dkim_public_key SET NOT NULL;`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`DROP TABLE IF EXISTS email_domain_keys;`).Error; err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,45 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func Migration_notifications_domains() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "notifications_domains",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.Exec(`
+				CREATE TABLE IF NOT EXISTS email_domains (
+					id SERIAL PRIMARY KEY,
+					name TEXT NOT NULL,
+					dkim_selector TEXT NOT NULL,
+					dkim_private_key TEXT NOT NULL,
+					dkim_public_key TEXT NOT NULL,
+					spf_verified BOOLEAN NOT NULL DEFAULT FALSE,
+					dkim_verified BOOLEAN NOT NULL DEFAULT FALSE,
+					dmarc_verified BOOLEAN NOT NULL DEFAULT FALSE,
+					verified BOOLEAN NOT NULL DEFAULT FALSE,
+					checked TIMESTAMPTZ,
+					updated TIMESTAMPTZ NOT NULL,
+					created TIMESTAMPTZ NOT NULL
+				);
+			`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_email_domains_name ON email_domains(name);`).Error; err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Exec(`DROP TABLE IF EXISTS email_domains;`).Error; err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func Migration_notifications_email_headers() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "notifications_email_headers",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.Exec(`
+				ALTER TABLE emails
+					ADD COLUMN IF NOT EXISTS reply_to TEXT,
+					ADD COLUMN IF NOT EXISTS headers JSONB NOT NULL DEFAULT '{}';
+			`).Error; err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Exec(`ALTER TABLE emails DROP COLUMN IF EXISTS reply_to, DROP COLUMN IF EXISTS headers;`).Error; err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
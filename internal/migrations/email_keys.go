@@ -0,0 +1,37 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func Migration_notifications_email_keys() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "notifications_email_keys",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.Exec(`ALTER TABLE emails ADD COLUMN IF NOT EXISTS key TEXT;`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`UPDATE emails SET key = 'email_' || id WHERE key IS NULL;`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`ALTER TABLE emails ALTER COLUMN key SET NOT NULL;`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_emails_key ON emails(key);`).Error; err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Exec(`ALTER TABLE emails DROP COLUMN IF EXISTS key;`).Error; err != nil {
+				return err
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,65 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func Migration_notifications_email_variants() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "notifications_email_variants",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.Exec(`
+				CREATE TABLE IF NOT EXISTS email_variants (
+					id SERIAL PRIMARY KEY,
+					email_id INTEGER NOT NULL REFERENCES emails(id) ON UPDATE CASCADE ON DELETE CASCADE,
+					name TEXT NOT NULL,
+					subject TEXT,
+					html TEXT,
+					text TEXT,
+					weight INTEGER NOT NULL,
+					active BOOLEAN NOT NULL,
+					updated TIMESTAMPTZ NOT NULL,
+					created TIMESTAMPTZ NOT NULL
+				);
+			`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`
+				ALTER TABLE email_logs
+					ADD COLUMN IF NOT EXISTS email_id INTEGER,
+					ADD COLUMN IF NOT EXISTS variant_id INTEGER;
+			`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_email_variants_email_id ON email_variants(email_id);`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_email_logs_email_id ON email_logs(email_id);`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_email_logs_variant_id ON email_logs(variant_id);`).Error; err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Exec(`
+				ALTER TABLE email_logs
+					DROP COLUMN IF EXISTS email_id,
+					DROP COLUMN IF EXISTS variant_id;
+			`).Error; err != nil {
+				return err
+			}
+			if err := tx.Exec(`DROP TABLE IF EXISTS email_variants;`).Error; err != nil {
+				return err
+			}
+			return nil
+		},
+	}
+}
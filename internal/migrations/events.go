@@ -0,0 +1,47 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func Migration_notifications_events() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "notifications_events",
+		Migrate: func(tx *gorm.DB) error {
+			// Sequence of calendar events by UID, increased with every update or cancellation
+			if err := tx.Exec(`
+				CREATE TABLE IF NOT EXISTS email_events (
+					uid TEXT PRIMARY KEY,
+					sequence INTEGER NOT NULL,
+					method TEXT NOT NULL,
+					updated TIMESTAMPTZ NOT NULL,
+					created TIMESTAMPTZ NOT NULL
+				);
+			`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`ALTER TABLE email_logs ADD COLUMN IF NOT EXISTS event_uid TEXT;`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_email_logs_event_uid ON email_logs (event_uid);`).Error; err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Exec(`ALTER TABLE email_logs DROP COLUMN IF EXISTS event_uid;`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`DROP TABLE IF EXISTS email_events;`).Error; err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
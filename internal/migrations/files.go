@@ -0,0 +1,45 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func Migration_notifications_files() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "notifications_files",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.Exec(`
+				CREATE TABLE IF NOT EXISTS email_files (
+					id SERIAL PRIMARY KEY,
+					name TEXT NOT NULL,
+					content_type TEXT NOT NULL,
+					size BIGINT NOT NULL,
+					sha256 TEXT NOT NULL,
+					storage_key TEXT NOT NULL UNIQUE,
+					created TIMESTAMPTZ NOT NULL
+				);
+			`).Error; err != nil {
+				return err
+			}
+
+			// Metadata of attachments, contents are not kept
+			if err := tx.Exec(`ALTER TABLE email_logs ADD COLUMN IF NOT EXISTS attachments JSONB NOT NULL DEFAULT '[]';`).Error; err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Exec(`ALTER TABLE email_logs DROP COLUMN IF EXISTS attachments;`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`DROP TABLE IF EXISTS email_files;`).Error; err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
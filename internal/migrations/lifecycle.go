@@ -0,0 +1,82 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func Migration_notifications_lifecycle() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "notifications_lifecycle",
+		Migrate: func(tx *gorm.DB) error {
+			// Status transitions of email logs with their reasons
+			if err := tx.Exec(`
+				CREATE TABLE IF NOT EXISTS email_log_transitions (
+					id SERIAL PRIMARY KEY,
+					log_id INTEGER NOT NULL REFERENCES email_logs(id) ON DELETE CASCADE,
+					from_status TEXT NOT NULL,
+					to_status TEXT NOT NULL,
+					reason TEXT NOT NULL,
+					created TIMESTAMPTZ NOT NULL
+				);
+			`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_email_log_transitions_log_id ON email_log_transitions(log_id);`).Error; err != nil {
+				return err
+			}
+
+			// Map submission results to lifecycle statuses
+			if err := tx.Exec(`UPDATE email_logs SET status = 'sent' WHERE status = 'success';`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`UPDATE email_logs SET status = 'failed' WHERE status = 'error';`).Error; err != nil {
+				return err
+			}
+
+			// Apply recorded delivery events
+			if err := tx.Exec(`
+				UPDATE email_logs SET status = 'bounced' WHERE status = 'sent' AND EXISTS (
+					SELECT 1 FROM email_delivery_events WHERE log_id = email_logs.id AND type = 'bounced' AND hard
+				);
+			`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`
+				UPDATE email_logs SET status = 'delivered' WHERE status = 'sent' AND EXISTS (
+					SELECT 1 FROM email_delivery_events WHERE log_id = email_logs.id AND type = 'delivered'
+				);
+			`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_email_logs_status ON email_logs(status);`).Error; err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Exec(`DROP INDEX IF EXISTS idx_email_logs_status;`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`UPDATE email_logs SET status = 'success' WHERE status IN ('sent', 'delivered', 'bounced');`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`UPDATE email_logs SET status = 'error' WHERE status NOT IN ('success', 'suppressed');`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`DROP TABLE IF EXISTS email_log_transitions;`).Error; err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
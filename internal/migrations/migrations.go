@@ -5,5 +5,25 @@ import "github.com/go-gormigrate/gormigrate/v2"
 func Get() []*gormigrate.Migration {
 	return []*gormigrate.Migration{
 		Migration_notifications_init(),
+		Migration_notifications_email_variants(),
+		Migration_notifications_email_keys(),
+		Migration_notifications_trash(),
+		Migration_notifications_search(),
+		Migration_notifications_senders(),
+		Migration_notifications_domains(),
+		Migration_notifications_dkim_keys(),
+		Migration_notifications_recipients(),
+		Migration_notifications_email_headers(),
+		Migration_notifications_files(),
+		Migration_notifications_assets(),
+		Migration_notifications_pdf_attachments(),
+		Migration_notifications_events(),
+		Migration_notifications_unsubscribes(),
+		Migration_notifications_suppressions(),
+		Migration_notifications_delivery_events(),
+		Migration_notifications_reconcile(),
+		Migration_notifications_lifecycle(),
+		Migration_notifications_opens(),
+		Migration_notifications_clicks(),
 	}
 }
@@ -0,0 +1,50 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func Migration_notifications_opens() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "notifications_opens",
+		Migrate: func(tx *gorm.DB) error {
+			// Open tracking of emails
+			if err := tx.Exec(`ALTER TABLE emails ADD COLUMN IF NOT EXISTS track_opens BOOLEAN NOT NULL DEFAULT false;`).Error; err != nil {
+				return err
+			}
+
+			// Opens of email logs recorded by the tracking pixel
+			if err := tx.Exec(`
+				CREATE TABLE IF NOT EXISTS email_opens (
+					id SERIAL PRIMARY KEY,
+					log_id INTEGER NOT NULL REFERENCES email_logs(id) ON DELETE CASCADE,
+					first BOOLEAN NOT NULL,
+					machine BOOLEAN NOT NULL,
+					user_agent TEXT NOT NULL,
+					ip_hash TEXT NOT NULL,
+					created TIMESTAMPTZ NOT NULL
+				);
+			`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_email_opens_log_id ON email_opens(log_id);`).Error; err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Exec(`DROP TABLE IF EXISTS email_opens;`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`ALTER TABLE emails DROP COLUMN IF EXISTS track_opens;`).Error; err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
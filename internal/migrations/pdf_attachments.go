@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func Migration_notifications_pdf_attachments() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "notifications_pdf_attachments",
+		Migrate: func(tx *gorm.DB) error {
+			// Templates of generated pdf attachments by file name template
+			if err := tx.Exec(`ALTER TABLE emails ADD COLUMN IF NOT EXISTS pdf_attachments JSONB NOT NULL DEFAULT '{}';`).Error; err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Exec(`ALTER TABLE emails DROP COLUMN IF EXISTS pdf_attachments;`).Error; err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,86 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func Migration_notifications_recipients() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "notifications_recipients",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.Exec(`
+				ALTER TABLE email_logs
+					ADD COLUMN IF NOT EXISTS to_emails JSONB NOT NULL DEFAULT '[]',
+					ADD COLUMN IF NOT EXISTS cc_emails JSONB NOT NULL DEFAULT '[]',
+					ADD COLUMN IF NOT EXISTS bcc_emails JSONB NOT NULL DEFAULT '[]';
+			`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`UPDATE email_logs SET to_emails = jsonb_build_array(to_email);`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`ALTER TABLE email_logs DROP COLUMN IF EXISTS to_email;`).Error; err != nil {
+				return err
+			}
+
+			// Lowercased addresses of all recipients, display names stripped
+			if err := tx.Exec(`
+				CREATE OR REPLACE FUNCTION email_log_recipients(to_emails JSONB, cc_emails JSONB, bcc_emails JSONB)
+				RETURNS TEXT[] LANGUAGE SQL IMMUTABLE AS $$
+					SELECT COALESCE(array_agg(lower(COALESCE(substring(value FROM '<([^<>]*)>\s*$'), value))), '{}')
+					FROM jsonb_array_elements_text(to_emails || cc_emails || bcc_emails) AS value
+				$$;
+			`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`
+				ALTER TABLE email_logs ADD COLUMN IF NOT EXISTS recipients TEXT[] GENERATED ALWAYS AS (
+					email_log_recipients(to_emails, cc_emails, bcc_emails)
+				) STORED;
+			`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_email_logs_recipients ON email_logs USING GIN (recipients);`).Error; err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Exec(`DROP INDEX IF EXISTS idx_email_logs_recipients;`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`ALTER TABLE email_logs DROP COLUMN IF EXISTS recipients;`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`DROP FUNCTION IF EXISTS email_log_recipients(JSONB, JSONB, JSONB);`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`ALTER TABLE email_logs ADD COLUMN IF NOT EXISTS to_email TEXT NOT NULL DEFAULT '';`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`UPDATE email_logs SET to_email = COALESCE(to_emails->>0, '');`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`ALTER TABLE email_logs ALTER COLUMN to_email DROP DEFAULT;`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`ALTER TABLE email_logs DROP COLUMN IF EXISTS to_emails, DROP COLUMN IF EXISTS cc_emails, DROP COLUMN IF EXISTS bcc_emails;`).Error; err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
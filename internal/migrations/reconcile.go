@@ -0,0 +1,35 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func Migration_notifications_reconcile() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "notifications_reconcile",
+		Migrate: func(tx *gorm.DB) error {
+			// Time the delivery status of the message was last polled from the provider
+			if err := tx.Exec(`ALTER TABLE email_logs ADD COLUMN IF NOT EXISTS reconciled TIMESTAMPTZ;`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_email_logs_created ON email_logs(created);`).Error; err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Exec(`DROP INDEX IF EXISTS idx_email_logs_created;`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`ALTER TABLE email_logs DROP COLUMN IF EXISTS reconciled;`).Error; err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,62 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func Migration_notifications_search() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "notifications_search",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.Exec(`CREATE EXTENSION IF NOT EXISTS pg_trgm;`).Error; err != nil {
+				return err
+			}
+
+			// Weighted search document: subject, then key and description, then text and html content
+			if err := tx.Exec(`
+				ALTER TABLE emails ADD COLUMN IF NOT EXISTS search TSVECTOR GENERATED ALWAYS AS (
+					setweight(to_tsvector('simple', subject), 'A') ||
+					setweight(to_tsvector('simple', replace(key, '_', ' ') || ' ' || description), 'B') ||
+					setweight(to_tsvector('simple', text), 'C') ||
+					setweight(to_tsvector('simple', regexp_replace(html, '<[^>]*>', ' ', 'g')), 'D')
+				) STORED;
+			`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_emails_search ON emails USING GIN (search);`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_emails_subject_trgm ON emails USING GIN (subject gin_trgm_ops);`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_email_folders_name_trgm ON email_folders USING GIN (name gin_trgm_ops);`).Error; err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Exec(`DROP INDEX IF EXISTS idx_email_folders_name_trgm;`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`DROP INDEX IF EXISTS idx_emails_subject_trgm;`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`DROP INDEX IF EXISTS idx_emails_search;`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`ALTER TABLE emails DROP COLUMN IF EXISTS search;`).Error; err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
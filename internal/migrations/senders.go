@@ -0,0 +1,105 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func Migration_notifications_senders() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "notifications_senders",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.Exec(`
+				CREATE TABLE IF NOT EXISTS email_senders (
+					id SERIAL PRIMARY KEY,
+					email TEXT NOT NULL,
+					name TEXT NOT NULL,
+					reply_to TEXT NOT NULL,
+					description TEXT NOT NULL,
+					updated TIMESTAMPTZ NOT NULL,
+					created TIMESTAMPTZ NOT NULL
+				);
+			`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_email_senders_identity ON email_senders(email, name);`).Error; err != nil {
+				return err
+			}
+
+			// Approve identities already used by emails
+			if err := tx.Exec(`
+				INSERT INTO email_senders (email, name, reply_to, description, updated, created)
+				SELECT DISTINCT from_email, from_name, '', '', NOW(), NOW() FROM emails
+				ON CONFLICT DO NOTHING;
+			`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`ALTER TABLE email_folders ADD COLUMN IF NOT EXISTS sender_id INTEGER REFERENCES email_senders(id) ON UPDATE CASCADE ON DELETE NO ACTION;`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`ALTER TABLE emails ADD COLUMN IF NOT EXISTS sender_id INTEGER REFERENCES email_senders(id) ON UPDATE CASCADE ON DELETE NO ACTION;`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`
+				UPDATE emails SET sender_id = email_senders.id
+				FROM email_senders
+				WHERE email_senders.email = emails.from_email AND email_senders.name = emails.from_name;
+			`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`ALTER TABLE emails DROP COLUMN IF EXISTS from_email, DROP COLUMN IF EXISTS from_name;`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_email_folders_sender_id ON email_folders(sender_id);`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_emails_sender_id ON emails(sender_id);`).Error; err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Exec(`ALTER TABLE emails ADD COLUMN IF NOT EXISTS from_email TEXT, ADD COLUMN IF NOT EXISTS from_name TEXT;`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`
+				UPDATE emails SET from_email = email_senders.email, from_name = email_senders.name
+				FROM email_senders
+				WHERE email_senders.id = emails.sender_id;
+			`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`UPDATE emails SET from_email = '', from_name = '' WHERE from_email IS NULL;`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`ALTER TABLE emails ALTER COLUMN from_email SET NOT NULL, ALTER COLUMN from_name SET NOT NULL;`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`ALTER TABLE emails DROP COLUMN IF EXISTS sender_id;`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`ALTER TABLE email_folders DROP COLUMN IF EXISTS sender_id;`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`DROP TABLE IF EXISTS email_senders;`).Error; err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,41 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func Migration_notifications_suppressions() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "notifications_suppressions",
+		Migrate: func(tx *gorm.DB) error {
+			// Addresses that messages are not sent to, until the expiry if set
+			if err := tx.Exec(`
+				CREATE TABLE IF NOT EXISTS email_suppressions (
+					id SERIAL PRIMARY KEY,
+					address TEXT NOT NULL,
+					reason TEXT NOT NULL,
+					source TEXT NOT NULL,
+					expires TIMESTAMPTZ,
+					updated TIMESTAMPTZ NOT NULL,
+					created TIMESTAMPTZ NOT NULL
+				);
+			`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_email_suppressions_address ON email_suppressions(address);`).Error; err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Exec(`DROP TABLE IF EXISTS email_suppressions;`).Error; err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
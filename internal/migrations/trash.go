@@ -0,0 +1,100 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func Migration_notifications_trash() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "notifications_trash",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.Exec(`ALTER TABLE email_folders ADD COLUMN IF NOT EXISTS deleted TIMESTAMPTZ;`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`ALTER TABLE emails ADD COLUMN IF NOT EXISTS deleted TIMESTAMPTZ;`).Error; err != nil {
+				return err
+			}
+
+			// Hard deletes must no longer cascade to nested folders and emails
+			if err := tx.Exec(`
+				ALTER TABLE email_folders
+					DROP CONSTRAINT IF EXISTS email_folders_parent_id_fkey,
+					ADD CONSTRAINT email_folders_parent_id_fkey FOREIGN KEY (parent_id) REFERENCES email_folders(id) ON UPDATE CASCADE ON DELETE NO ACTION;
+			`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`
+				ALTER TABLE emails
+					DROP CONSTRAINT IF EXISTS emails_folder_id_fkey,
+					ADD CONSTRAINT emails_folder_id_fkey FOREIGN KEY (folder_id) REFERENCES email_folders(id) ON UPDATE CASCADE ON DELETE NO ACTION;
+			`).Error; err != nil {
+				return err
+			}
+
+			// Keys of deleted emails can be reused
+			if err := tx.Exec(`DROP INDEX IF EXISTS idx_emails_key;`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_emails_key ON emails(key) WHERE deleted IS NULL;`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_email_folders_deleted ON email_folders(deleted) WHERE deleted IS NOT NULL;`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_emails_deleted ON emails(deleted) WHERE deleted IS NOT NULL;`).Error; err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Exec(`DELETE FROM emails WHERE deleted IS NOT NULL;`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`DELETE FROM email_folders WHERE deleted IS NOT NULL;`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`
+				ALTER TABLE email_folders
+					DROP CONSTRAINT IF EXISTS email_folders_parent_id_fkey,
+					ADD CONSTRAINT email_folders_parent_id_fkey FOREIGN KEY (parent_id) REFERENCES email_folders(id) ON UPDATE CASCADE ON DELETE CASCADE;
+			`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`
+				ALTER TABLE emails
+					DROP CONSTRAINT IF EXISTS emails_folder_id_fkey,
+					ADD CONSTRAINT emails_folder_id_fkey FOREIGN KEY (folder_id) REFERENCES email_folders(id) ON UPDATE CASCADE ON DELETE CASCADE;
+			`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`DROP INDEX IF EXISTS idx_emails_key;`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_emails_key ON emails(key);`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`ALTER TABLE email_folders DROP COLUMN IF EXISTS deleted;`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`ALTER TABLE emails DROP COLUMN IF EXISTS deleted;`).Error; err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
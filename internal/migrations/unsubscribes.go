@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+func Migration_notifications_unsubscribes() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "notifications_unsubscribes",
+		Migrate: func(tx *gorm.DB) error {
+			// Category of non-transactional emails, recipients may opt out of it
+			if err := tx.Exec(`ALTER TABLE emails ADD COLUMN IF NOT EXISTS category TEXT;`).Error; err != nil {
+				return err
+			}
+
+			// Opt-outs of recipients by category
+			if err := tx.Exec(`
+				CREATE TABLE IF NOT EXISTS email_unsubscribes (
+					email TEXT NOT NULL,
+					category TEXT NOT NULL,
+					created TIMESTAMPTZ NOT NULL,
+					PRIMARY KEY (email, category)
+				);
+			`).Error; err != nil {
+				return err
+			}
+
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Exec(`DROP TABLE IF EXISTS email_unsubscribes;`).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(`ALTER TABLE emails DROP COLUMN IF EXISTS category;`).Error; err != nil {
+				return err
+			}
+
+			return nil
+		},
+	}
+}
@@ -3,395 +3,1559 @@ package port
 import (
 	"encoding/json"
 	"net/mail"
+	"regexp"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/flash-go/sdk/types"
 )
 
+var (
+	emailKeyRegexp   = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]{0,127}$`)
+	keySuffixRegexp  = regexp.MustCompile(`^[a-z0-9_.-]{1,32}$`)
+	domainRegexp     = regexp.MustCompile(`^(?i)([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z]{2,63}\.?$`)
+	selectorRegexp   = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+	assetNameRegexp  = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,127}$`)
+	categoryRegexp   = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]{0,63}$`)
+	bundleFormats    = []string{"json", "zip"}
+	importStrategies = []string{"skip", "overwrite", "rename"}
+	dkimAlgorithms   = []string{"rsa-sha256", "ed25519-sha256"}
+	eventMethods     = []string{"REQUEST", "CANCEL"}
+	suppressReasons  = []string{"hard_bounce", "complaint", "unsubscribe", "manual"}
+	emailStatuses    = []string{"accepted", "queued", "sending", "sent", "delivered", "bounced", "failed", "suppressed", "cancelled"}
+)
+
+const searchEmailsMaxLimit = 100
+
+// Maximum number of suppressions per import
+const importSuppressionsMaxItems = 10000
+
 // Data
 
-// Folders
+// Senders
 
-type CreateFolderData struct {
-	ParentId    *uint  `json:"parent_id"`
+type CreateSenderData struct {
+	Email       string `json:"email"`
 	Name        string `json:"name"`
+	ReplyTo     string `json:"reply_to"`
 	Description string `json:"description"`
-	SystemFlag  bool   `json:"system_flag"`
 }
 
-func (r *CreateFolderData) Validate() error {
-	if err := r.ValidateParentId(); err != nil {
+func (r *CreateSenderData) Validate() error {
+	if err := r.ValidateEmail(); err != nil {
 		return err
 	}
 	if err := r.ValidateName(); err != nil {
 		return err
 	}
+	if err := r.ValidateReplyTo(); err != nil {
+		return err
+	}
 	return nil
 }
-func (r *CreateFolderData) ValidateParentId() error {
-	if r.ParentId != nil && *r.ParentId <= 0 {
-		return ErrFolderInvalidParent
+func (r *CreateSenderData) ValidateEmail() error {
+	if _, err := mail.ParseAddress(r.Email); err != nil {
+		return ErrSenderInvalidEmail
 	}
 	return nil
 }
-func (r *CreateFolderData) ValidateName() error {
+func (r *CreateSenderData) ValidateName() error {
 	if r.Name == "" {
-		return ErrFolderInvalidName
+		return ErrSenderInvalidName
+	}
+	return nil
+}
+func (r *CreateSenderData) ValidateReplyTo() error {
+	if r.ReplyTo == "" {
+		return nil
+	}
+	if _, err := mail.ParseAddress(r.ReplyTo); err != nil {
+		return ErrSenderInvalidReplyTo
 	}
 	return nil
 }
 
-type FilterFoldersData struct {
-	Id         *[]uint   `json:"id"`
-	ParentId   *[]*uint  `json:"parent_id"`
-	Name       *[]string `json:"name"`
-	SystemFlag *bool     `json:"system_flag"`
+type FilterSendersData struct {
+	Id    *[]uint   `json:"id"`
+	Email *[]string `json:"email"`
+	Name  *[]string `json:"name"`
 }
 
-func (r *FilterFoldersData) Validate() error {
+func (r *FilterSendersData) Validate() error {
 	return nil
 }
 
-//lint:ignore U1000 Need for @Param request body in httpEmailsHandlerAdapterPort.AdminUpdateFolder
-type _UpdateFolderData struct {
-	ParentId    uint   `json:"parent_id"`
+//lint:ignore U1000 Need for @Param request body in httpEmailsHandlerAdapterPort.AdminUpdateSender
+type _UpdateSenderData struct {
+	Email       string `json:"email"`
 	Name        string `json:"name"`
+	ReplyTo     string `json:"reply_to"`
 	Description string `json:"description"`
 }
 
-type UpdateFolderData struct {
-	ParentId    types.Nullable[uint]   `json:"parent_id"`
+type UpdateSenderData struct {
+	Email       types.Nullable[string] `json:"email"`
 	Name        types.Nullable[string] `json:"name"`
+	ReplyTo     types.Nullable[string] `json:"reply_to"`
 	Description types.Nullable[string] `json:"description"`
 }
 
-func (r *UpdateFolderData) Validate() error {
-	if err := r.ValidateParentId(); err != nil {
+func (r *UpdateSenderData) Validate() error {
+	if err := r.ValidateEmail(); err != nil {
 		return err
 	}
 	if err := r.ValidateName(); err != nil {
 		return err
 	}
+	if err := r.ValidateReplyTo(); err != nil {
+		return err
+	}
 	if err := r.ValidateDescription(); err != nil {
 		return err
 	}
 	return nil
 }
-func (r *UpdateFolderData) ValidateParentId() error {
-	if r.ParentId.Set && r.ParentId.Value != nil && *r.ParentId.Value < 1 {
-		return ErrFolderInvalidParent
+func (r *UpdateSenderData) ValidateEmail() error {
+	if r.Email.Set {
+		if r.Email.Value == nil {
+			return ErrSenderInvalidEmail
+		}
+		if _, err := mail.ParseAddress(*r.Email.Value); err != nil {
+			return ErrSenderInvalidEmail
+		}
 	}
 	return nil
 }
-func (r *UpdateFolderData) ValidateName() error {
+func (r *UpdateSenderData) ValidateName() error {
 	if r.Name.Set && (r.Name.Value == nil || *r.Name.Value == "") {
-		return ErrFolderInvalidName
+		return ErrSenderInvalidName
 	}
 	return nil
 }
-func (r *UpdateFolderData) ValidateDescription() error {
-	if r.Description.Set && (r.Description.Value == nil || *r.Description.Value == "") {
-		return ErrFolderInvalidDescription
+func (r *UpdateSenderData) ValidateReplyTo() error {
+	if r.ReplyTo.Set {
+		if r.ReplyTo.Value == nil {
+			return ErrSenderInvalidReplyTo
+		}
+		if *r.ReplyTo.Value == "" {
+			return nil
+		}
+		if _, err := mail.ParseAddress(*r.ReplyTo.Value); err != nil {
+			return ErrSenderInvalidReplyTo
+		}
+	}
+	return nil
+}
+func (r *UpdateSenderData) ValidateDescription() error {
+	if r.Description.Set && r.Description.Value == nil {
+		return ErrSenderInvalidDescription
 	}
 	return nil
 }
 
-// Emails
+// Domains
 
-type CreateEmailData struct {
-	FolderId    *uint  `json:"folder_id"`
-	FromEmail   string `json:"from_email"`
-	FromName    string `json:"from_name"`
-	Subject     string `json:"subject"`
-	Html        string `json:"html"`
-	Text        string `json:"text"`
-	Description string `json:"description"`
-	SystemFlag  bool   `json:"system_flag"`
+type CreateDomainData struct {
+	Name          string `json:"name"`
+	DkimSelector  string `json:"dkim_selector"`
+	DkimAlgorithm string `json:"dkim_algorithm"`
 }
 
-func (r *CreateEmailData) Validate() error {
-	if err := r.ValidateFolderId(); err != nil {
-		return err
-	}
-	if err := r.ValidateFromEmail(); err != nil {
-		return err
-	}
-	if err := r.ValidateFromName(); err != nil {
-		return err
-	}
-	if err := r.ValidateSubject(); err != nil {
+func (r *CreateDomainData) Validate() error {
+	if err := r.ValidateName(); err != nil {
 		return err
 	}
-	if err := r.ValidateHtml(); err != nil {
+	if err := r.ValidateDkimSelector(); err != nil {
 		return err
 	}
-	if err := r.ValidateText(); err != nil {
+	if err := r.ValidateDkimAlgorithm(); err != nil {
 		return err
 	}
 	return nil
 }
-func (r *CreateEmailData) ValidateFolderId() error {
-	if r.FolderId != nil && *r.FolderId <= 0 {
-		return ErrEmailInvalidFolderId
+func (r *CreateDomainData) ValidateName() error {
+	if len(r.Name) > 253 || !domainRegexp.MatchString(r.Name) {
+		return ErrDomainInvalidName
 	}
 	return nil
 }
-func (r *CreateEmailData) ValidateFromEmail() error {
-	if r.FromEmail == "" {
-		return ErrEmailInvalidFromEmail
-	}
-	if _, err := mail.ParseAddress(r.FromEmail); err != nil {
-		return ErrEmailInvalidToEmail
+func (r *CreateDomainData) ValidateDkimSelector() error {
+	if r.DkimSelector != "" && !selectorRegexp.MatchString(r.DkimSelector) {
+		return ErrDomainInvalidDkimSelector
 	}
 	return nil
 }
-func (r *CreateEmailData) ValidateFromName() error {
-	if r.FromName == "" {
-		return ErrEmailInvalidFromName
+func (r *CreateDomainData) ValidateDkimAlgorithm() error {
+	if r.DkimAlgorithm != "" && !slices.Contains(dkimAlgorithms, r.DkimAlgorithm) {
+		return ErrDomainInvalidDkimAlgorithm
 	}
 	return nil
 }
-func (r *CreateEmailData) ValidateSubject() error {
-	if r.Subject == "" {
-		return ErrEmailInvalidSubject
+
+type CreateDomainKeyData struct {
+	Selector  string `json:"selector"`
+	Algorithm string `json:"algorithm"`
+}
+
+func (r *CreateDomainKeyData) Validate() error {
+	if err := r.ValidateSelector(); err != nil {
+		return err
+	}
+	if err := r.ValidateAlgorithm(); err != nil {
+		return err
 	}
 	return nil
 }
-func (r *CreateEmailData) ValidateHtml() error {
-	if r.Html == "" {
-		return ErrEmailInvalidHtml
+func (r *CreateDomainKeyData) ValidateSelector() error {
+	if !selectorRegexp.MatchString(r.Selector) {
+		return ErrDomainInvalidDkimSelector
 	}
 	return nil
 }
-func (r *CreateEmailData) ValidateText() error {
-	if r.Text == "" {
-		return ErrEmailInvalidText
+func (r *CreateDomainKeyData) ValidateAlgorithm() error {
+	if r.Algorithm != "" && !slices.Contains(dkimAlgorithms, r.Algorithm) {
+		return ErrDomainInvalidDkimAlgorithm
 	}
 	return nil
 }
 
-type FilterEmailsData struct {
-	Id         *[]uint  `json:"id"`
-	FolderId   *[]*uint `json:"folder_id"`
-	SystemFlag *bool    `json:"system_flag"`
+type FilterDomainsData struct {
+	Id       *[]uint   `json:"id"`
+	Name     *[]string `json:"name"`
+	Verified *bool     `json:"verified"`
 }
 
-func (r *FilterEmailsData) Validate() error {
+func (r *FilterDomainsData) Validate() error {
 	return nil
 }
 
-//lint:ignore U1000 Need for @Param request body in httpEmailsHandlerAdapterPort.AdminUpdateEmail
-type _UpdateEmailData struct {
-	FolderId    uint   `json:"folder_id"`
-	FromEmail   string `json:"from_email"`
-	FromName    string `json:"from_name"`
-	Subject     string `json:"subject"`
-	Html        string `json:"html"`
-	Text        string `json:"text"`
-	Description string `json:"description"`
-}
+// Files
 
-type UpdateEmailData struct {
-	FolderId    types.Nullable[uint]   `json:"folder_id"`
-	FromEmail   types.Nullable[string] `json:"from_email"`
-	FromName    types.Nullable[string] `json:"from_name"`
-	Subject     types.Nullable[string] `json:"subject"`
-	Html        types.Nullable[string] `json:"html"`
-	Text        types.Nullable[string] `json:"text"`
-	Description types.Nullable[string] `json:"description"`
+type CreateFileData struct {
+	Name        string `json:"name"`
+	ContentType string `json:"content_type"`
+	Content     []byte `json:"content" swaggertype:"string" format:"base64"`
 }
 
-func (r *UpdateEmailData) Validate() error {
-	if err := r.ValidateFolderId(); err != nil {
+func (r *CreateFileData) Validate() error {
+	if err := r.ValidateName(); err != nil {
 		return err
 	}
-	if err := r.ValidateFromEmail(); err != nil {
+	if err := r.ValidateContentType(); err != nil {
 		return err
 	}
-	if err := r.ValidateFromName(); err != nil {
+	if err := r.ValidateContent(); err != nil {
 		return err
 	}
-	if err := r.ValidateSubject(); err != nil {
-		return err
+	return nil
+}
+func (r *CreateFileData) ValidateName() error {
+	if !validFilename(r.Name) {
+		return ErrFileInvalidName
 	}
-	if err := r.ValidateHtml(); err != nil {
+	return nil
+}
+func (r *CreateFileData) ValidateContentType() error {
+	if r.ContentType == "" {
+		return ErrFileInvalidContentType
+	}
+	return nil
+}
+func (r *CreateFileData) ValidateContent() error {
+	if len(r.Content) == 0 {
+		return ErrFileInvalidContent
+	}
+	return nil
+}
+
+type FilterFilesData struct {
+	Id   *[]uint   `json:"id"`
+	Name *[]string `json:"name"`
+}
+
+func (r *FilterFilesData) Validate() error {
+	return nil
+}
+
+// Assets
+
+type CreateAssetData struct {
+	Name        string `json:"name"`
+	ContentType string `json:"content_type"`
+	Content     []byte `json:"content" swaggertype:"string" format:"base64"`
+}
+
+func (r *CreateAssetData) Validate() error {
+	if err := r.ValidateName(); err != nil {
 		return err
 	}
-	if err := r.ValidateText(); err != nil {
+	if err := r.ValidateContentType(); err != nil {
 		return err
 	}
-	if err := r.ValidateDescription(); err != nil {
+	if err := r.ValidateContent(); err != nil {
 		return err
 	}
 	return nil
 }
-func (r *UpdateEmailData) ValidateFolderId() error {
-	if r.FolderId.Set && r.FolderId.Value != nil && *r.FolderId.Value < 1 {
-		return ErrEmailInvalidFolderId
+func (r *CreateAssetData) ValidateName() error {
+	if !assetNameRegexp.MatchString(r.Name) {
+		return ErrAssetInvalidName
 	}
 	return nil
 }
-func (r *UpdateEmailData) ValidateFromEmail() error {
-	if r.FromEmail.Set {
-		if r.FromEmail.Value == nil || *r.FromEmail.Value == "" {
-			return ErrEmailInvalidFromEmail
-		}
-		if _, err := mail.ParseAddress(*r.FromEmail.Value); err != nil {
-			return ErrEmailInvalidToEmail
-		}
+func (r *CreateAssetData) ValidateContentType() error {
+	if r.ContentType == "" {
+		return ErrAssetInvalidContentType
 	}
 	return nil
 }
-func (r *UpdateEmailData) ValidateFromName() error {
-	if r.FromName.Set && (r.FromName.Value == nil || *r.FromName.Value == "") {
-		return ErrEmailInvalidFromName
+func (r *CreateAssetData) ValidateContent() error {
+	if len(r.Content) == 0 {
+		return ErrAssetInvalidContent
 	}
 	return nil
 }
-func (r *UpdateEmailData) ValidateSubject() error {
-	if r.Subject.Set && (r.Subject.Value == nil || *r.Subject.Value == "") {
-		return ErrEmailInvalidSubject
-	}
+
+type FilterAssetsData struct {
+	Id   *[]uint   `json:"id"`
+	Name *[]string `json:"name"`
+}
+
+func (r *FilterAssetsData) Validate() error {
 	return nil
 }
-func (r *UpdateEmailData) ValidateHtml() error {
-	if r.Html.Set && (r.Html.Value == nil || *r.Html.Value == "") {
-		return ErrEmailInvalidHtml
+
+// Suppressions
+
+type CreateSuppressionData struct {
+	Address string     `json:"address"`
+	Reason  string     `json:"reason" enums:"hard_bounce,complaint,unsubscribe,manual"`
+	Expires *time.Time `json:"expires"`
+}
+
+func (r *CreateSuppressionData) Validate() error {
+	if err := r.ValidateAddress(); err != nil {
+		return err
+	}
+	if err := r.ValidateReason(); err != nil {
+		return err
 	}
 	return nil
 }
-func (r *UpdateEmailData) ValidateText() error {
-	if r.Text.Set && (r.Text.Value == nil || *r.Text.Value == "") {
-		return ErrEmailInvalidText
+func (r *CreateSuppressionData) ValidateAddress() error {
+	if _, err := mail.ParseAddress(r.Address); err != nil {
+		return ErrSuppressionInvalidAddress
 	}
 	return nil
 }
-func (r *UpdateEmailData) ValidateDescription() error {
-	if r.Description.Set && (r.Description.Value == nil || *r.Description.Value == "") {
-		return ErrEmailInvalidDescription
+func (r *CreateSuppressionData) ValidateReason() error {
+	if !slices.Contains(suppressReasons, r.Reason) {
+		return ErrSuppressionInvalidReason
 	}
 	return nil
 }
 
-type SendCustomData struct {
-	FromEmail string `json:"from_email"`
-	FromName  string `json:"from_name"`
-	Subject   string `json:"subject"`
-	ToEmail   string `json:"to_email"`
-	Html      string `json:"html"`
-	Text      string `json:"text"`
+type FilterSuppressionsData struct {
+	Id      *[]uint   `json:"id"`
+	Address *[]string `json:"address"`
+	Reason  *[]string `json:"reason"`
+	Source  *[]string `json:"source"`
+	Active  *bool     `json:"active"`
 }
 
-func (r *SendCustomData) Validate() error {
-	if err := r.ValidateFromEmail(); err != nil {
+func (r *FilterSuppressionsData) Validate() error {
+	return nil
+}
+
+//lint:ignore U1000 Need for @Param request body in httpEmailsHandlerAdapterPort.AdminUpdateSuppression
+type _UpdateSuppressionData struct {
+	Reason  string    `json:"reason" enums:"hard_bounce,complaint,unsubscribe,manual"`
+	Expires time.Time `json:"expires"`
+}
+
+type UpdateSuppressionData struct {
+	Reason  types.Nullable[string]    `json:"reason"`
+	Expires types.Nullable[time.Time] `json:"expires"`
+}
+
+func (r *UpdateSuppressionData) Validate() error {
+	if err := r.ValidateReason(); err != nil {
 		return err
 	}
-	if err := r.ValidateFromName(); err != nil {
-		return err
+	return nil
+}
+func (r *UpdateSuppressionData) ValidateReason() error {
+	if r.Reason.Set && (r.Reason.Value == nil || !slices.Contains(suppressReasons, *r.Reason.Value)) {
+		return ErrSuppressionInvalidReason
 	}
-	if err := r.ValidateSubject(); err != nil {
+	return nil
+}
+
+type ImportSuppressionsData struct {
+	Suppressions []CreateSuppressionData `json:"suppressions"`
+}
+
+func (r *ImportSuppressionsData) Validate() error {
+	if err := r.ValidateSuppressions(); err != nil {
 		return err
 	}
-	if err := r.ValidateToEmail(); err != nil {
+	return nil
+}
+func (r *ImportSuppressionsData) ValidateSuppressions() error {
+	if len(r.Suppressions) == 0 || len(r.Suppressions) > importSuppressionsMaxItems {
+		return ErrSuppressionInvalidSuppressions
+	}
+	for _, item := range r.Suppressions {
+		if err := item.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Folders
+
+type CreateFolderData struct {
+	ParentId    *uint  `json:"parent_id"`
+	SenderId    *uint  `json:"sender_id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	SystemFlag  bool   `json:"system_flag"`
+}
+
+func (r *CreateFolderData) Validate() error {
+	if err := r.ValidateParentId(); err != nil {
 		return err
 	}
-	if err := r.ValidateHtml(); err != nil {
+	if err := r.ValidateSenderId(); err != nil {
 		return err
 	}
-	if err := r.ValidateText(); err != nil {
+	if err := r.ValidateName(); err != nil {
 		return err
 	}
 	return nil
 }
-func (r *SendCustomData) ValidateFromEmail() error {
-	if r.FromEmail == "" {
-		return ErrEmailInvalidFromEmail
+func (r *CreateFolderData) ValidateParentId() error {
+	if r.ParentId != nil && *r.ParentId <= 0 {
+		return ErrFolderInvalidParent
 	}
 	return nil
 }
-func (r *SendCustomData) ValidateFromName() error {
-	if r.FromName == "" {
-		return ErrEmailInvalidFromName
+func (r *CreateFolderData) ValidateSenderId() error {
+	if r.SenderId != nil && *r.SenderId <= 0 {
+		return ErrFolderInvalidSenderId
 	}
 	return nil
 }
-func (r *SendCustomData) ValidateSubject() error {
-	if r.Subject == "" {
-		return ErrEmailInvalidSubject
+func (r *CreateFolderData) ValidateName() error {
+	if r.Name == "" {
+		return ErrFolderInvalidName
 	}
 	return nil
 }
-func (r *SendCustomData) ValidateToEmail() error {
-	if r.ToEmail == "" {
-		return ErrEmailInvalidToEmail
-	}
-	if _, err := mail.ParseAddress(r.ToEmail); err != nil {
-		return ErrEmailInvalidToEmail
-	}
+
+type FilterFoldersData struct {
+	Id         *[]uint   `json:"id"`
+	ParentId   *[]*uint  `json:"parent_id"`
+	Name       *[]string `json:"name"`
+	SystemFlag *bool     `json:"system_flag"`
+}
+
+func (r *FilterFoldersData) Validate() error {
 	return nil
 }
-func (r *SendCustomData) ValidateHtml() error {
-	if r.Html == "" {
-		return ErrEmailInvalidHtml
+
+type FolderTreeData struct {
+	RootId *uint
+}
+
+func (r *FolderTreeData) Validate() error {
+	if err := r.ValidateRootId(); err != nil {
+		return err
 	}
 	return nil
 }
-func (r *SendCustomData) ValidateText() error {
-	if r.Text == "" {
-		return ErrEmailInvalidText
+func (r *FolderTreeData) ValidateRootId() error {
+	if r.RootId != nil && *r.RootId <= 0 {
+		return ErrFolderInvalidRootId
 	}
 	return nil
 }
 
-type SendData struct {
-	EmailId uint             `json:"email_id"`
-	ToEmail string           `json:"to_email"`
-	Vars    *json.RawMessage `json:"vars"`
+type CloneFolderData struct {
+	ParentId  *uint   `json:"parent_id"`
+	Name      *string `json:"name"`
+	KeySuffix *string `json:"key_suffix"`
 }
 
-func (r *SendData) Validate() error {
-	if err := r.ValidateEmailId(); err != nil {
+func (r *CloneFolderData) Validate() error {
+	if err := r.ValidateParentId(); err != nil {
+		return err
+	}
+	if err := r.ValidateName(); err != nil {
 		return err
 	}
-	if err := r.ValidateToEmail(); err != nil {
+	if err := r.ValidateKeySuffix(); err != nil {
 		return err
 	}
 	return nil
 }
-func (r *SendData) ValidateEmailId() error {
-	if r.EmailId <= 0 {
-		return ErrEmailInvalidId
+func (r *CloneFolderData) ValidateParentId() error {
+	if r.ParentId != nil && *r.ParentId <= 0 {
+		return ErrFolderInvalidParent
 	}
 	return nil
 }
-func (r *SendData) ValidateToEmail() error {
-	if r.ToEmail == "" {
-		return ErrEmailInvalidToEmail
+func (r *CloneFolderData) ValidateName() error {
+	if r.Name != nil && *r.Name == "" {
+		return ErrFolderInvalidName
 	}
-	if _, err := mail.ParseAddress(r.ToEmail); err != nil {
-		return ErrEmailInvalidToEmail
+	return nil
+}
+func (r *CloneFolderData) ValidateKeySuffix() error {
+	if r.KeySuffix != nil && !keySuffixRegexp.MatchString(*r.KeySuffix) {
+		return ErrFolderInvalidKeySuffix
 	}
 	return nil
 }
 
-type FilterEmailLogsData struct {
-	Id        *[]uint   `json:"id"`
-	FromEmail *[]string `json:"from_email"`
-	FromName  *[]string `json:"from_name"`
-	ToEmail   *[]string `json:"to_email"`
-	Status    *[]string `json:"status"`
-	MessageId *[]string `json:"message_id"`
+type MoveFolderData struct {
+	ParentId *uint `json:"parent_id"`
 }
 
-func (r *FilterEmailLogsData) Validate() error {
+func (r *MoveFolderData) Validate() error {
+	if err := r.ValidateParentId(); err != nil {
+		return err
+	}
+	return nil
+}
+func (r *MoveFolderData) ValidateParentId() error {
+	if r.ParentId != nil && *r.ParentId <= 0 {
+		return ErrFolderInvalidParent
+	}
+	return nil
+}
+
+//lint:ignore U1000 Need for @Param request body in httpEmailsHandlerAdapterPort.AdminUpdateFolder
+type _UpdateFolderData struct {
+	ParentId    uint   `json:"parent_id"`
+	SenderId    uint   `json:"sender_id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type UpdateFolderData struct {
+	ParentId    types.Nullable[uint]   `json:"parent_id"`
+	SenderId    types.Nullable[uint]   `json:"sender_id"`
+	Name        types.Nullable[string] `json:"name"`
+	Description types.Nullable[string] `json:"description"`
+}
+
+func (r *UpdateFolderData) Validate() error {
+	if err := r.ValidateParentId(); err != nil {
+		return err
+	}
+	if err := r.ValidateSenderId(); err != nil {
+		return err
+	}
+	if err := r.ValidateName(); err != nil {
+		return err
+	}
+	if err := r.ValidateDescription(); err != nil {
+		return err
+	}
+	return nil
+}
+func (r *UpdateFolderData) ValidateParentId() error {
+	if r.ParentId.Set && r.ParentId.Value != nil && *r.ParentId.Value < 1 {
+		return ErrFolderInvalidParent
+	}
+	return nil
+}
+func (r *UpdateFolderData) ValidateSenderId() error {
+	if r.SenderId.Set && r.SenderId.Value != nil && *r.SenderId.Value < 1 {
+		return ErrFolderInvalidSenderId
+	}
+	return nil
+}
+func (r *UpdateFolderData) ValidateName() error {
+	if r.Name.Set && (r.Name.Value == nil || *r.Name.Value == "") {
+		return ErrFolderInvalidName
+	}
+	return nil
+}
+func (r *UpdateFolderData) ValidateDescription() error {
+	if r.Description.Set && (r.Description.Value == nil || *r.Description.Value == "") {
+		return ErrFolderInvalidDescription
+	}
+	return nil
+}
+
+// Emails
+
+type CreateEmailData struct {
+	FolderId       *uint             `json:"folder_id"`
+	Key            string            `json:"key"`
+	SenderId       *uint             `json:"sender_id"`
+	Subject        string            `json:"subject"`
+	Html           string            `json:"html"`
+	Text           string            `json:"text"`
+	ReplyTo        *string           `json:"reply_to"`
+	Headers        map[string]string `json:"headers"`
+	PdfAttachments map[string]string `json:"pdf_attachments"`
+	Category       *string           `json:"category"`
+	TrackOpens     bool              `json:"track_opens"`
+	TrackClicks    bool              `json:"track_clicks"`
+	Description    string            `json:"description"`
+	SystemFlag     bool              `json:"system_flag"`
+}
+
+func (r *CreateEmailData) Validate() error {
+	if err := r.ValidateFolderId(); err != nil {
+		return err
+	}
+	if err := r.ValidateKey(); err != nil {
+		return err
+	}
+	if err := r.ValidateSenderId(); err != nil {
+		return err
+	}
+	if err := r.ValidateSubject(); err != nil {
+		return err
+	}
+	if err := r.ValidateHtml(); err != nil {
+		return err
+	}
+	if err := r.ValidateReplyTo(); err != nil {
+		return err
+	}
+	if err := r.ValidateCategory(); err != nil {
+		return err
+	}
+	return nil
+}
+func (r *CreateEmailData) ValidateFolderId() error {
+	if r.FolderId != nil && *r.FolderId <= 0 {
+		return ErrEmailInvalidFolderId
+	}
+	return nil
+}
+func (r *CreateEmailData) ValidateKey() error {
+	if !emailKeyRegexp.MatchString(r.Key) {
+		return ErrEmailInvalidKey
+	}
+	return nil
+}
+func (r *CreateEmailData) ValidateSenderId() error {
+	if r.SenderId != nil && *r.SenderId <= 0 {
+		return ErrEmailInvalidSenderId
+	}
+	return nil
+}
+func (r *CreateEmailData) ValidateSubject() error {
+	if r.Subject == "" {
+		return ErrEmailInvalidSubject
+	}
+	return nil
+}
+func (r *CreateEmailData) ValidateHtml() error {
+	if r.Html == "" {
+		return ErrEmailInvalidHtml
+	}
+	return nil
+}
+func (r *CreateEmailData) ValidateReplyTo() error {
+	if r.ReplyTo != nil && *r.ReplyTo == "" {
+		return ErrEmailInvalidReplyTo
+	}
+	return nil
+}
+func (r *CreateEmailData) ValidateCategory() error {
+	if r.Category != nil && !categoryRegexp.MatchString(*r.Category) {
+		return ErrEmailInvalidCategory
+	}
+	return nil
+}
+
+type FilterEmailsData struct {
+	Id         *[]uint   `json:"id"`
+	FolderId   *[]*uint  `json:"folder_id"`
+	Key        *[]string `json:"key"`
+	SystemFlag *bool     `json:"system_flag"`
+}
+
+func (r *FilterEmailsData) Validate() error {
+	return nil
+}
+
+type CloneEmailData struct {
+	FolderId *uint  `json:"folder_id"`
+	Key      string `json:"key"`
+}
+
+func (r *CloneEmailData) Validate() error {
+	if err := r.ValidateFolderId(); err != nil {
+		return err
+	}
+	if err := r.ValidateKey(); err != nil {
+		return err
+	}
+	return nil
+}
+func (r *CloneEmailData) ValidateFolderId() error {
+	if r.FolderId != nil && *r.FolderId <= 0 {
+		return ErrEmailInvalidFolderId
+	}
+	return nil
+}
+func (r *CloneEmailData) ValidateKey() error {
+	if !emailKeyRegexp.MatchString(r.Key) {
+		return ErrEmailInvalidKey
+	}
+	return nil
+}
+
+type SearchEmailsData struct {
+	Query      string    `json:"query"`
+	Id         *[]uint   `json:"id"`
+	FolderId   *[]*uint  `json:"folder_id"`
+	Key        *[]string `json:"key"`
+	SystemFlag *bool     `json:"system_flag"`
+	Limit      *uint     `json:"limit"`
+}
+
+func (r *SearchEmailsData) Validate() error {
+	if err := r.ValidateQuery(); err != nil {
+		return err
+	}
+	if err := r.ValidateLimit(); err != nil {
+		return err
+	}
+	return nil
+}
+func (r *SearchEmailsData) ValidateQuery() error {
+	if strings.TrimSpace(r.Query) == "" {
+		return ErrEmailInvalidQuery
+	}
+	return nil
+}
+func (r *SearchEmailsData) ValidateLimit() error {
+	if r.Limit != nil && (*r.Limit == 0 || *r.Limit > searchEmailsMaxLimit) {
+		return ErrEmailInvalidLimit
+	}
+	return nil
+}
+
+type MoveEmailsData struct {
+	Ids      []uint `json:"ids"`
+	FolderId *uint  `json:"folder_id"`
+}
+
+func (r *MoveEmailsData) Validate() error {
+	if err := r.ValidateIds(); err != nil {
+		return err
+	}
+	if err := r.ValidateFolderId(); err != nil {
+		return err
+	}
+	return nil
+}
+func (r *MoveEmailsData) ValidateIds() error {
+	if len(r.Ids) == 0 || slices.Contains(r.Ids, 0) {
+		return ErrEmailInvalidId
+	}
+	return nil
+}
+func (r *MoveEmailsData) ValidateFolderId() error {
+	if r.FolderId != nil && *r.FolderId <= 0 {
+		return ErrEmailInvalidFolderId
+	}
+	return nil
+}
+
+//lint:ignore U1000 Need for @Param request body in httpEmailsHandlerAdapterPort.AdminUpdateEmail
+type _UpdateEmailData struct {
+	FolderId       uint              `json:"folder_id"`
+	Key            string            `json:"key"`
+	SenderId       uint              `json:"sender_id"`
+	Subject        string            `json:"subject"`
+	Html           string            `json:"html"`
+	Text           string            `json:"text"`
+	ReplyTo        string            `json:"reply_to"`
+	Headers        map[string]string `json:"headers"`
+	PdfAttachments map[string]string `json:"pdf_attachments"`
+	Category       string            `json:"category"`
+	TrackOpens     bool              `json:"track_opens"`
+	TrackClicks    bool              `json:"track_clicks"`
+	Description    string            `json:"description"`
+}
+
+type UpdateEmailData struct {
+	FolderId       types.Nullable[uint]              `json:"folder_id"`
+	Key            types.Nullable[string]            `json:"key"`
+	SenderId       types.Nullable[uint]              `json:"sender_id"`
+	Subject        types.Nullable[string]            `json:"subject"`
+	Html           types.Nullable[string]            `json:"html"`
+	Text           types.Nullable[string]            `json:"text"`
+	ReplyTo        types.Nullable[string]            `json:"reply_to"`
+	Headers        types.Nullable[map[string]string] `json:"headers"`
+	PdfAttachments types.Nullable[map[string]string] `json:"pdf_attachments"`
+	Category       types.Nullable[string]            `json:"category"`
+	TrackOpens     types.Nullable[bool]              `json:"track_opens"`
+	TrackClicks    types.Nullable[bool]              `json:"track_clicks"`
+	Description    types.Nullable[string]            `json:"description"`
+}
+
+func (r *UpdateEmailData) Validate() error {
+	if err := r.ValidateFolderId(); err != nil {
+		return err
+	}
+	if err := r.ValidateKey(); err != nil {
+		return err
+	}
+	if err := r.ValidateSenderId(); err != nil {
+		return err
+	}
+	if err := r.ValidateSubject(); err != nil {
+		return err
+	}
+	if err := r.ValidateHtml(); err != nil {
+		return err
+	}
+	if err := r.ValidateText(); err != nil {
+		return err
+	}
+	if err := r.ValidateReplyTo(); err != nil {
+		return err
+	}
+	if err := r.ValidateCategory(); err != nil {
+		return err
+	}
+	if err := r.ValidateTrackOpens(); err != nil {
+		return err
+	}
+	if err := r.ValidateTrackClicks(); err != nil {
+		return err
+	}
+	if err := r.ValidateDescription(); err != nil {
+		return err
+	}
+	return nil
+}
+func (r *UpdateEmailData) ValidateFolderId() error {
+	if r.FolderId.Set && r.FolderId.Value != nil && *r.FolderId.Value < 1 {
+		return ErrEmailInvalidFolderId
+	}
+	return nil
+}
+func (r *UpdateEmailData) ValidateKey() error {
+	if r.Key.Set && (r.Key.Value == nil || !emailKeyRegexp.MatchString(*r.Key.Value)) {
+		return ErrEmailInvalidKey
+	}
+	return nil
+}
+func (r *UpdateEmailData) ValidateSenderId() error {
+	if r.SenderId.Set && r.SenderId.Value != nil && *r.SenderId.Value < 1 {
+		return ErrEmailInvalidSenderId
+	}
+	return nil
+}
+func (r *UpdateEmailData) ValidateSubject() error {
+	if r.Subject.Set && (r.Subject.Value == nil || *r.Subject.Value == "") {
+		return ErrEmailInvalidSubject
+	}
+	return nil
+}
+func (r *UpdateEmailData) ValidateHtml() error {
+	if r.Html.Set && (r.Html.Value == nil || *r.Html.Value == "") {
+		return ErrEmailInvalidHtml
+	}
+	return nil
+}
+func (r *UpdateEmailData) ValidateText() error {
+	if r.Text.Set && r.Text.Value == nil {
+		return ErrEmailInvalidText
+	}
+	return nil
+}
+func (r *UpdateEmailData) ValidateReplyTo() error {
+	if r.ReplyTo.Set && r.ReplyTo.Value != nil && *r.ReplyTo.Value == "" {
+		return ErrEmailInvalidReplyTo
+	}
+	return nil
+}
+func (r *UpdateEmailData) ValidateCategory() error {
+	if r.Category.Set && r.Category.Value != nil && !categoryRegexp.MatchString(*r.Category.Value) {
+		return ErrEmailInvalidCategory
+	}
+	return nil
+}
+func (r *UpdateEmailData) ValidateTrackOpens() error {
+	if r.TrackOpens.Set && r.TrackOpens.Value == nil {
+		return ErrEmailInvalidTrackOpens
+	}
+	return nil
+}
+func (r *UpdateEmailData) ValidateTrackClicks() error {
+	if r.TrackClicks.Set && r.TrackClicks.Value == nil {
+		return ErrEmailInvalidTrackClicks
+	}
+	return nil
+}
+func (r *UpdateEmailData) ValidateDescription() error {
+	if r.Description.Set && (r.Description.Value == nil || *r.Description.Value == "") {
+		return ErrEmailInvalidDescription
+	}
+	return nil
+}
+
+type SendCustomData struct {
+	FromEmail   string            `json:"from_email"`
+	FromName    string            `json:"from_name"`
+	ReplyTo     string            `json:"reply_to"`
+	Headers     map[string]string `json:"headers"`
+	Subject     string            `json:"subject"`
+	To          []string          `json:"to"`
+	Cc          []string          `json:"cc"`
+	Bcc         []string          `json:"bcc"`
+	Html        string            `json:"html"`
+	Text        string            `json:"text"`
+	Attachments []AttachmentData  `json:"attachments"`
+}
+
+func (r *SendCustomData) Validate() error {
+	if err := r.ValidateFromEmail(); err != nil {
+		return err
+	}
+	if err := r.ValidateReplyTo(); err != nil {
+		return err
+	}
+	if err := r.ValidateSubject(); err != nil {
+		return err
+	}
+	if err := r.ValidateTo(); err != nil {
+		return err
+	}
+	if err := r.ValidateCc(); err != nil {
+		return err
+	}
+	if err := r.ValidateBcc(); err != nil {
+		return err
+	}
+	if err := r.ValidateHtml(); err != nil {
+		return err
+	}
+	if err := r.ValidateAttachments(); err != nil {
+		return err
+	}
+	return nil
+}
+func (r *SendCustomData) ValidateFromEmail() error {
+	if r.FromEmail == "" {
+		return ErrEmailInvalidFromEmail
+	}
+	return nil
+}
+func (r *SendCustomData) ValidateReplyTo() error {
+	if r.ReplyTo == "" {
+		return nil
+	}
+	if _, err := mail.ParseAddress(r.ReplyTo); err != nil {
+		return ErrEmailInvalidReplyTo
+	}
+	return nil
+}
+func (r *SendCustomData) ValidateSubject() error {
+	if r.Subject == "" {
+		return ErrEmailInvalidSubject
+	}
+	return nil
+}
+func (r *SendCustomData) ValidateTo() error {
+	if len(r.To) == 0 || !validAddressList(r.To) {
+		return ErrEmailInvalidTo
+	}
+	return nil
+}
+func (r *SendCustomData) ValidateCc() error {
+	if !validAddressList(r.Cc) {
+		return ErrEmailInvalidCc
+	}
+	return nil
+}
+func (r *SendCustomData) ValidateBcc() error {
+	if !validAddressList(r.Bcc) {
+		return ErrEmailInvalidBcc
+	}
+	return nil
+}
+func (r *SendCustomData) ValidateHtml() error {
+	if r.Html == "" {
+		return ErrEmailInvalidHtml
+	}
+	return nil
+}
+func (r *SendCustomData) ValidateAttachments() error {
+	if !validAttachments(r.Attachments) {
+		return ErrEmailInvalidAttachments
+	}
+	return nil
+}
+
+type SendData struct {
+	EmailId     uint             `json:"email_id"`
+	To          []string         `json:"to"`
+	Cc          []string         `json:"cc"`
+	Bcc         []string         `json:"bcc"`
+	Vars        *json.RawMessage `json:"vars"`
+	Attachments []AttachmentData `json:"attachments"`
+	Event       *EventData       `json:"event"`
+}
+
+func (r *SendData) Validate() error {
+	if err := r.ValidateEmailId(); err != nil {
+		return err
+	}
+	if err := r.ValidateTo(); err != nil {
+		return err
+	}
+	if err := r.ValidateCc(); err != nil {
+		return err
+	}
+	if err := r.ValidateBcc(); err != nil {
+		return err
+	}
+	if err := r.ValidateAttachments(); err != nil {
+		return err
+	}
+	if err := r.ValidateEvent(); err != nil {
+		return err
+	}
+	return nil
+}
+func (r *SendData) ValidateEmailId() error {
+	if r.EmailId <= 0 {
+		return ErrEmailInvalidId
+	}
+	return nil
+}
+func (r *SendData) ValidateTo() error {
+	if len(r.To) == 0 || !validAddressList(r.To) {
+		return ErrEmailInvalidTo
+	}
+	return nil
+}
+func (r *SendData) ValidateCc() error {
+	if !validAddressList(r.Cc) {
+		return ErrEmailInvalidCc
+	}
+	return nil
+}
+func (r *SendData) ValidateBcc() error {
+	if !validAddressList(r.Bcc) {
+		return ErrEmailInvalidBcc
+	}
+	return nil
+}
+func (r *SendData) ValidateAttachments() error {
+	if !validAttachments(r.Attachments) {
+		return ErrEmailInvalidAttachments
+	}
+	// Stored files are attached only by authenticated senders
+	for _, item := range r.Attachments {
+		if item.FileId != nil {
+			return ErrEmailFileAttachments
+		}
+	}
+	return nil
+}
+func (r *SendData) ValidateEvent() error {
+	if r.Event != nil && !r.Event.valid() {
+		return ErrEmailInvalidEvent
+	}
+	return nil
+}
+
+// Calendar event, start and end are RFC 3339 times or local times ("2006-01-02T15:04:05") in the timezone
+type EventData struct {
+	Uid         string `json:"uid"`
+	Method      string `json:"method" enums:"REQUEST,CANCEL"`
+	Start       string `json:"start"`
+	End         string `json:"end"`
+	Timezone    string `json:"timezone" example:"Europe/Berlin"`
+	Location    string `json:"location"`
+	Organizer   string `json:"organizer"`
+	Summary     string `json:"summary"`
+	Description string `json:"description"`
+}
+
+// Parse start and end of the event, local times are in the timezone, UTC by default
+func (r *EventData) Times() (time.Time, time.Time, error) {
+	location, err := time.LoadLocation(r.Timezone)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	start, err := eventTime(r.Start, location)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	end, err := eventTime(r.End, location)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return start, end, nil
+}
+
+// Cancellations require the UID of the event, the event must end after it starts
+func (r *EventData) valid() bool {
+	if !slices.Contains(eventMethods, strings.ToUpper(r.Method)) && r.Method != "" {
+		return false
+	}
+	if strings.EqualFold(r.Method, "CANCEL") && r.Uid == "" {
+		return false
+	}
+	if len(r.Uid) > 255 || strings.ContainsFunc(r.Uid, func(c rune) bool { return c < 33 || c == 127 }) {
+		return false
+	}
+	if r.Organizer != "" {
+		if _, err := mail.ParseAddress(r.Organizer); err != nil {
+			return false
+		}
+	}
+	start, end, err := r.Times()
+	if err != nil {
+		return false
+	}
+	return end.After(start)
+}
+
+func eventTime(value string, location *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation("2006-01-02T15:04:05", value, location)
+}
+
+type PreviewEmailData struct {
+	EmailId   uint             `json:"email_id"`
+	VariantId *uint            `json:"variant_id"`
+	Vars      *json.RawMessage `json:"vars"`
+}
+
+func (r *PreviewEmailData) Validate() error {
+	if err := r.ValidateEmailId(); err != nil {
+		return err
+	}
+	if err := r.ValidateVariantId(); err != nil {
+		return err
+	}
+	return nil
+}
+func (r *PreviewEmailData) ValidateEmailId() error {
+	if r.EmailId <= 0 {
+		return ErrEmailInvalidId
+	}
+	return nil
+}
+func (r *PreviewEmailData) ValidateVariantId() error {
+	if r.VariantId != nil && *r.VariantId <= 0 {
+		return ErrEmailInvalidVariantId
+	}
+	return nil
+}
+
+// Attachment with inline base64 content or a reference to a stored file
+type AttachmentData struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Content     []byte `json:"content" swaggertype:"string" format:"base64"`
+	FileId      *uint  `json:"file_id"`
+}
+
+// Inline content requires name and type, stored files default to their own
+func validAttachments(list []AttachmentData) bool {
+	for _, item := range list {
+		if item.FileId != nil {
+			if *item.FileId < 1 || len(item.Content) > 0 || item.Filename != "" && !validFilename(item.Filename) {
+				return false
+			}
+			continue
+		}
+		if len(item.Content) == 0 || !validFilename(item.Filename) || item.ContentType == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// Plain file name without path and line breaks
+func validFilename(name string) bool {
+	return name != "" && name != "." && name != ".." && !strings.ContainsAny(name, "/\\\r\n")
+}
+
+// Every item is a single address, optionally with a display name
+func validAddressList(list []string) bool {
+	for _, item := range list {
+		if _, err := mail.ParseAddress(item); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+type FilterEmailLogsData struct {
+	Id        *[]uint   `json:"id"`
+	EmailId   *[]uint   `json:"email_id"`
+	VariantId *[]uint   `json:"variant_id"`
+	FromEmail *[]string `json:"from_email"`
+	FromName  *[]string `json:"from_name"`
+	Recipient *[]string `json:"recipient"`
+	Status    *[]string `json:"status"`
+	MessageId *[]string `json:"message_id"`
+	EventUid  *[]string `json:"event_uid"`
+}
+
+func (r *FilterEmailLogsData) Validate() error {
+	if err := r.ValidateStatus(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *FilterEmailLogsData) ValidateStatus() error {
+	if r.Status == nil {
+		return nil
+	}
+	for _, status := range *r.Status {
+		if !slices.Contains(emailStatuses, status) {
+			return ErrEmailLogInvalidStatus
+		}
+	}
+	return nil
+}
+
+// Variants
+
+type CreateVariantData struct {
+	EmailId uint    `json:"email_id"`
+	Name    string  `json:"name"`
+	Subject *string `json:"subject"`
+	Html    *string `json:"html"`
+	Text    *string `json:"text"`
+	Weight  uint    `json:"weight"`
+}
+
+func (r *CreateVariantData) Validate() error {
+	if err := r.ValidateEmailId(); err != nil {
+		return err
+	}
+	if err := r.ValidateName(); err != nil {
+		return err
+	}
+	if err := r.ValidateSubject(); err != nil {
+		return err
+	}
+	if err := r.ValidateHtml(); err != nil {
+		return err
+	}
+	if err := r.ValidateWeight(); err != nil {
+		return err
+	}
+	return nil
+}
+func (r *CreateVariantData) ValidateEmailId() error {
+	if r.EmailId <= 0 {
+		return ErrVariantInvalidEmailId
+	}
+	return nil
+}
+func (r *CreateVariantData) ValidateName() error {
+	if r.Name == "" {
+		return ErrVariantInvalidName
+	}
+	return nil
+}
+func (r *CreateVariantData) ValidateSubject() error {
+	if r.Subject != nil && *r.Subject == "" {
+		return ErrVariantInvalidSubject
+	}
+	return nil
+}
+func (r *CreateVariantData) ValidateHtml() error {
+	if r.Html != nil && *r.Html == "" {
+		return ErrVariantInvalidHtml
+	}
+	return nil
+}
+func (r *CreateVariantData) ValidateWeight() error {
+	if r.Weight < 1 {
+		return ErrVariantInvalidWeight
+	}
+	return nil
+}
+
+type FilterVariantsData struct {
+	Id      *[]uint `json:"id"`
+	EmailId *[]uint `json:"email_id"`
+	Active  *bool   `json:"active"`
+}
+
+func (r *FilterVariantsData) Validate() error {
+	return nil
+}
+
+//lint:ignore U1000 Need for @Param request body in httpEmailsHandlerAdapterPort.AdminUpdateVariant
+type _UpdateVariantData struct {
+	Name    string `json:"name"`
+	Subject string `json:"subject"`
+	Html    string `json:"html"`
+	Text    string `json:"text"`
+	Weight  uint   `json:"weight"`
+	Active  bool   `json:"active"`
+}
+
+type UpdateVariantData struct {
+	Name    types.Nullable[string] `json:"name"`
+	Subject types.Nullable[string] `json:"subject"`
+	Html    types.Nullable[string] `json:"html"`
+	Text    types.Nullable[string] `json:"text"`
+	Weight  types.Nullable[uint]   `json:"weight"`
+	Active  types.Nullable[bool]   `json:"active"`
+}
+
+func (r *UpdateVariantData) Validate() error {
+	if err := r.ValidateName(); err != nil {
+		return err
+	}
+	if err := r.ValidateSubject(); err != nil {
+		return err
+	}
+	if err := r.ValidateHtml(); err != nil {
+		return err
+	}
+	if err := r.ValidateWeight(); err != nil {
+		return err
+	}
+	if err := r.ValidateActive(); err != nil {
+		return err
+	}
+	return nil
+}
+func (r *UpdateVariantData) ValidateName() error {
+	if r.Name.Set && (r.Name.Value == nil || *r.Name.Value == "") {
+		return ErrVariantInvalidName
+	}
+	return nil
+}
+func (r *UpdateVariantData) ValidateSubject() error {
+	if r.Subject.Set && r.Subject.Value != nil && *r.Subject.Value == "" {
+		return ErrVariantInvalidSubject
+	}
+	return nil
+}
+func (r *UpdateVariantData) ValidateHtml() error {
+	if r.Html.Set && r.Html.Value != nil && *r.Html.Value == "" {
+		return ErrVariantInvalidHtml
+	}
+	return nil
+}
+func (r *UpdateVariantData) ValidateWeight() error {
+	if r.Weight.Set && (r.Weight.Value == nil || *r.Weight.Value < 1) {
+		return ErrVariantInvalidWeight
+	}
+	return nil
+}
+func (r *UpdateVariantData) ValidateActive() error {
+	if r.Active.Set && r.Active.Value == nil {
+		return ErrVariantInvalidActive
+	}
+	return nil
+}
+
+type EndExperimentData struct {
+	WinnerId *uint `json:"winner_id"`
+}
+
+func (r *EndExperimentData) Validate() error {
+	if err := r.ValidateWinnerId(); err != nil {
+		return err
+	}
+	return nil
+}
+func (r *EndExperimentData) ValidateWinnerId() error {
+	if r.WinnerId != nil && *r.WinnerId <= 0 {
+		return ErrVariantInvalidWinnerId
+	}
+	return nil
+}
+
+// Bundles
+
+type ExportBundleData struct {
+	FolderId *uint
+	Format   string
+}
+
+func (r *ExportBundleData) Validate() error {
+	if err := r.ValidateFolderId(); err != nil {
+		return err
+	}
+	if err := r.ValidateFormat(); err != nil {
+		return err
+	}
+	return nil
+}
+func (r *ExportBundleData) ValidateFolderId() error {
+	if r.FolderId != nil && *r.FolderId <= 0 {
+		return ErrBundleInvalidFolderId
+	}
+	return nil
+}
+func (r *ExportBundleData) ValidateFormat() error {
+	if !slices.Contains(bundleFormats, r.Format) {
+		return ErrBundleInvalidFormat
+	}
+	return nil
+}
+
+type ImportBundleData struct {
+	ParentId *uint
+	Strategy string
+	Prune    bool
+	DryRun   bool
+	Format   string
+	Bundle   []byte
+}
+
+func (r *ImportBundleData) Validate() error {
+	if err := r.ValidateParentId(); err != nil {
+		return err
+	}
+	if err := r.ValidateStrategy(); err != nil {
+		return err
+	}
+	if err := r.ValidatePrune(); err != nil {
+		return err
+	}
+	if err := r.ValidateFormat(); err != nil {
+		return err
+	}
+	if err := r.ValidateBundle(); err != nil {
+		return err
+	}
+	return nil
+}
+func (r *ImportBundleData) ValidateParentId() error {
+	if r.ParentId != nil && *r.ParentId <= 0 {
+		return ErrBundleInvalidParentId
+	}
+	return nil
+}
+func (r *ImportBundleData) ValidateStrategy() error {
+	if !slices.Contains(importStrategies, r.Strategy) {
+		return ErrBundleInvalidStrategy
+	}
+	return nil
+}
+func (r *ImportBundleData) ValidatePrune() error {
+	if r.Prune && r.Strategy != "overwrite" {
+		return ErrBundleInvalidPrune
+	}
+	return nil
+}
+func (r *ImportBundleData) ValidateFormat() error {
+	if !slices.Contains(bundleFormats, r.Format) {
+		return ErrBundleInvalidFormat
+	}
+	return nil
+}
+func (r *ImportBundleData) ValidateBundle() error {
+	if len(r.Bundle) == 0 {
+		return ErrBundleInvalidBundle
+	}
 	return nil
 }
 
 // Responses
 
+type SenderResponse struct {
+	Id          uint      `json:"id"`
+	Email       string    `json:"email"`
+	Name        string    `json:"name"`
+	ReplyTo     string    `json:"reply_to"`
+	Description string    `json:"description"`
+	Updated     time.Time `json:"updated"`
+	Created     time.Time `json:"created"`
+}
+
+type FileResponse struct {
+	Id          uint      `json:"id"`
+	Name        string    `json:"name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	Sha256      string    `json:"sha256"`
+	Created     time.Time `json:"created"`
+}
+
+type AssetResponse struct {
+	Id          uint      `json:"id"`
+	Name        string    `json:"name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	Sha256      string    `json:"sha256"`
+	Url         string    `json:"url"`
+	Created     time.Time `json:"created"`
+}
+
+type SuppressionResponse struct {
+	Id      uint       `json:"id"`
+	Address string     `json:"address"`
+	Reason  string     `json:"reason"`
+	Source  string     `json:"source"`
+	Expires *time.Time `json:"expires"`
+	Updated time.Time  `json:"updated"`
+	Created time.Time  `json:"created"`
+}
+
+type ImportSuppressionsResponse struct {
+	Imported uint `json:"imported"`
+}
+
+type DomainResponse struct {
+	Id            uint                `json:"id"`
+	Name          string              `json:"name"`
+	SpfVerified   bool                `json:"spf_verified"`
+	DkimVerified  bool                `json:"dkim_verified"`
+	DmarcVerified bool                `json:"dmarc_verified"`
+	Verified      bool                `json:"verified"`
+	Keys          []DomainKeyResponse `json:"keys"`
+	Records       []DnsRecordResponse `json:"records"`
+	Checked       *time.Time          `json:"checked"`
+	Updated       time.Time           `json:"updated"`
+	Created       time.Time           `json:"created"`
+}
+
+type DomainKeyResponse struct {
+	Id        uint      `json:"id"`
+	Selector  string    `json:"selector"`
+	Algorithm string    `json:"algorithm"`
+	Active    bool      `json:"active"`
+	Verified  bool      `json:"verified"`
+	Created   time.Time `json:"created"`
+}
+
+type DnsRecordResponse struct {
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+	Verified bool   `json:"verified"`
+}
+
 type FolderResponse struct {
 	Id          uint      `json:"id"`
 	ParentId    *uint     `json:"parent_id"`
+	SenderId    *uint     `json:"sender_id"`
 	Name        string    `json:"name"`
 	Description string    `json:"description"`
 	SystemFlag  bool      `json:"system_flag"`
@@ -399,30 +1563,219 @@ type FolderResponse struct {
 	Created     time.Time `json:"created"`
 }
 
+type FolderTreeResponse struct {
+	Id          uint                       `json:"id"`
+	ParentId    *uint                      `json:"parent_id"`
+	Name        string                     `json:"name"`
+	Description string                     `json:"description"`
+	SystemFlag  bool                       `json:"system_flag"`
+	Path        string                     `json:"path"`
+	Breadcrumbs []FolderBreadcrumbResponse `json:"breadcrumbs"`
+	Emails      uint                       `json:"emails"`
+	TotalEmails uint                       `json:"total_emails"`
+	Children    []FolderTreeResponse       `json:"children"`
+	Updated     time.Time                  `json:"updated"`
+	Created     time.Time                  `json:"created"`
+}
+
+type FolderBreadcrumbResponse struct {
+	Id   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
 type EmailResponse struct {
+	Id             uint              `json:"id"`
+	FolderId       *uint             `json:"folder_id"`
+	Key            string            `json:"key"`
+	SenderId       *uint             `json:"sender_id"`
+	Subject        string            `json:"subject"`
+	Html           string            `json:"html"`
+	Text           string            `json:"text"`
+	ReplyTo        *string           `json:"reply_to"`
+	Headers        map[string]string `json:"headers"`
+	PdfAttachments map[string]string `json:"pdf_attachments"`
+	Category       *string           `json:"category"`
+	TrackOpens     bool              `json:"track_opens"`
+	TrackClicks    bool              `json:"track_clicks"`
+	Description    string            `json:"description"`
+	SystemFlag     bool              `json:"system_flag"`
+	Updated        time.Time         `json:"updated"`
+	Created        time.Time         `json:"created"`
+}
+
+type SearchEmailResponse struct {
 	Id          uint      `json:"id"`
 	FolderId    *uint     `json:"folder_id"`
-	FromEmail   string    `json:"from_email"`
-	FromName    string    `json:"from_name"`
+	FolderName  *string   `json:"folder_name"`
+	Key         string    `json:"key"`
 	Subject     string    `json:"subject"`
-	Html        string    `json:"html"`
-	Text        string    `json:"text"`
 	Description string    `json:"description"`
 	SystemFlag  bool      `json:"system_flag"`
+	Rank        float64   `json:"rank"`
+	Highlight   string    `json:"highlight"`
+	Snippet     string    `json:"snippet"`
 	Updated     time.Time `json:"updated"`
 	Created     time.Time `json:"created"`
 }
 
+type VariantResponse struct {
+	Id      uint      `json:"id"`
+	EmailId uint      `json:"email_id"`
+	Name    string    `json:"name"`
+	Subject *string   `json:"subject"`
+	Html    *string   `json:"html"`
+	Text    *string   `json:"text"`
+	Weight  uint      `json:"weight"`
+	Active  bool      `json:"active"`
+	Updated time.Time `json:"updated"`
+	Created time.Time `json:"created"`
+}
+
+type VariantReportResponse struct {
+	VariantId    uint    `json:"variant_id"`
+	Name         string  `json:"name"`
+	Weight       uint    `json:"weight"`
+	Active       bool    `json:"active"`
+	Sent         uint    `json:"sent"`
+	Delivered    uint    `json:"delivered"`
+	Failed       uint    `json:"failed"`
+	UniqueOpens  uint    `json:"unique_opens"`
+	UniqueClicks uint    `json:"unique_clicks"`
+	DeliveryRate float64 `json:"delivery_rate"`
+	OpenRate     float64 `json:"open_rate"`
+	ClickRate    float64 `json:"click_rate"`
+}
+
+type ImportActionResponse struct {
+	Entity  string   `json:"entity"`
+	Key     string   `json:"key"`
+	Action  string   `json:"action"`
+	Target  *string  `json:"target"`
+	Changes []string `json:"changes"`
+}
+
 type EmailLogResponse struct {
+	Id          uint                         `json:"id"`
+	EmailId     *uint                        `json:"email_id"`
+	VariantId   *uint                        `json:"variant_id"`
+	FromEmail   string                       `json:"from_email"`
+	FromName    string                       `json:"from_name"`
+	Subject     string                       `json:"subject"`
+	To          []string                     `json:"to"`
+	Cc          []string                     `json:"cc"`
+	Bcc         []string                     `json:"bcc"`
+	Html        string                       `json:"html"`
+	Text        string                       `json:"text"`
+	Status      string                       `json:"status"`
+	MessageId   *string                      `json:"message_id"`
+	Errors      *string                      `json:"errors"`
+	Attachments []EmailLogAttachmentResponse `json:"attachments"`
+	EventUid    *string                      `json:"event_uid"`
+	Links       []string                     `json:"links"`
+	Events      []DeliveryEventResponse      `json:"events"`
+	Transitions []EmailLogTransitionResponse `json:"transitions"`
+	Opens       []EmailOpenResponse          `json:"opens"`
+	Clicks      []EmailClickResponse         `json:"clicks"`
+	Created     time.Time                    `json:"created"`
+}
+
+type PreviewResponse struct {
+	FromEmail   string                      `json:"from_email"`
+	FromName    string                      `json:"from_name"`
+	ReplyTo     string                      `json:"reply_to"`
+	Headers     map[string]string           `json:"headers"`
+	Subject     string                      `json:"subject"`
+	Html        string                      `json:"html"`
+	Text        string                      `json:"text"`
+	Attachments []PreviewAttachmentResponse `json:"attachments"`
+}
+
+type PreviewAttachmentResponse struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	Content     []byte `json:"content" swaggertype:"string" format:"base64"`
+}
+
+type EmailLogAttachmentResponse struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	Sha256      string `json:"sha256"`
+}
+
+type EmailLogTransitionResponse struct {
+	Id      uint      `json:"id"`
+	LogId   uint      `json:"log_id"`
+	From    string    `json:"from"`
+	To      string    `json:"to"`
+	Reason  string    `json:"reason"`
+	Created time.Time `json:"created"`
+}
+
+type DeliveryEventResponse struct {
+	Id        uint      `json:"id"`
+	LogId     uint      `json:"log_id"`
+	Type      string    `json:"type"`
+	Recipient string    `json:"recipient"`
+	Reason    string    `json:"reason"`
+	Hard      bool      `json:"hard"`
+	Occurred  time.Time `json:"occurred"`
+	Created   time.Time `json:"created"`
+}
+
+type EmailOpenResponse struct {
+	Id        uint      `json:"id"`
+	LogId     uint      `json:"log_id"`
+	First     bool      `json:"first"`
+	Machine   bool      `json:"machine"`
+	UserAgent string    `json:"user_agent"`
+	IpHash    string    `json:"ip_hash"`
+	Created   time.Time `json:"created"`
+}
+
+type EmailClickResponse struct {
 	Id        uint      `json:"id"`
-	FromEmail string    `json:"from_email"`
-	FromName  string    `json:"from_name"`
-	Subject   string    `json:"subject"`
-	ToEmail   string    `json:"to_email"`
-	Html      string    `json:"html"`
-	Text      string    `json:"text"`
-	Status    string    `json:"status"`
-	MessageId *string   `json:"message_id"`
-	Errors    *string   `json:"errors"`
+	LogId     uint      `json:"log_id"`
+	Link      int       `json:"link"`
+	Url       string    `json:"url"`
+	UserAgent string    `json:"user_agent"`
+	IpHash    string    `json:"ip_hash"`
 	Created   time.Time `json:"created"`
 }
+
+type ClickReportResponse struct {
+	Url          string `json:"url"`
+	Clicks       uint   `json:"clicks"`
+	UniqueClicks uint   `json:"unique_clicks"`
+}
+
+type TrashResponse struct {
+	Folders []TrashFolderResponse `json:"folders"`
+	Emails  []TrashEmailResponse  `json:"emails"`
+}
+
+type TrashFolderResponse struct {
+	Id          uint      `json:"id"`
+	ParentId    *uint     `json:"parent_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	SystemFlag  bool      `json:"system_flag"`
+	Deleted     time.Time `json:"deleted"`
+	Expires     time.Time `json:"expires"`
+	Updated     time.Time `json:"updated"`
+	Created     time.Time `json:"created"`
+}
+
+type TrashEmailResponse struct {
+	Id          uint      `json:"id"`
+	FolderId    *uint     `json:"folder_id"`
+	Key         string    `json:"key"`
+	Subject     string    `json:"subject"`
+	Description string    `json:"description"`
+	SystemFlag  bool      `json:"system_flag"`
+	Deleted     time.Time `json:"deleted"`
+	Expires     time.Time `json:"expires"`
+	Updated     time.Time `json:"updated"`
+	Created     time.Time `json:"created"`
+}
@@ -0,0 +1,32 @@
+package port
+
+import "testing"
+
+func TestSendDataValidateAttachments(t *testing.T) {
+	fileId := uint(12)
+	inline := AttachmentData{Filename: "invoice.pdf", ContentType: "application/pdf", Content: []byte("%PDF-1.4")}
+	stored := AttachmentData{FileId: &fileId}
+
+	tests := []struct {
+		name        string
+		attachments []AttachmentData
+		send        error
+		sendCustom  error
+	}{
+		{"none", nil, nil, nil},
+		{"inline content", []AttachmentData{inline}, nil, nil},
+		{"stored file", []AttachmentData{stored}, ErrEmailFileAttachments, nil},
+		{"stored file after inline content", []AttachmentData{inline, stored}, ErrEmailFileAttachments, nil},
+		{"inline content without name", []AttachmentData{{ContentType: "text/plain", Content: []byte("x")}}, ErrEmailInvalidAttachments, ErrEmailInvalidAttachments},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := (&SendData{Attachments: tt.attachments}).ValidateAttachments(); err != tt.send {
+				t.Errorf("SendData.ValidateAttachments() = %v, want %v", err, tt.send)
+			}
+			if err := (&SendCustomData{Attachments: tt.attachments}).ValidateAttachments(); err != tt.sendCustom {
+				t.Errorf("SendCustomData.ValidateAttachments() = %v, want %v", err, tt.sendCustom)
+			}
+		})
+	}
+}
@@ -5,18 +5,72 @@ import (
 )
 
 var (
+	// Senders
+	ErrSenderInvalidEmail       = errors.New(errors.ErrBadRequest, "invalid_email")
+	ErrSenderInvalidName        = errors.New(errors.ErrBadRequest, "invalid_name")
+	ErrSenderInvalidReplyTo     = errors.New(errors.ErrBadRequest, "invalid_reply_to")
+	ErrSenderInvalidDescription = errors.New(errors.ErrBadRequest, "invalid_description")
+	// Domains
+	ErrDomainInvalidName          = errors.New(errors.ErrBadRequest, "invalid_name")
+	ErrDomainInvalidDkimSelector  = errors.New(errors.ErrBadRequest, "invalid_dkim_selector")
+	ErrDomainInvalidDkimAlgorithm = errors.New(errors.ErrBadRequest, "invalid_dkim_algorithm")
+	// Files
+	ErrFileInvalidName        = errors.New(errors.ErrBadRequest, "invalid_name")
+	ErrFileInvalidContentType = errors.New(errors.ErrBadRequest, "invalid_content_type")
+	ErrFileInvalidContent     = errors.New(errors.ErrBadRequest, "invalid_content")
+	// Assets
+	ErrAssetInvalidName        = errors.New(errors.ErrBadRequest, "invalid_name")
+	ErrAssetInvalidContentType = errors.New(errors.ErrBadRequest, "invalid_content_type")
+	ErrAssetInvalidContent     = errors.New(errors.ErrBadRequest, "invalid_content")
+	// Suppressions
+	ErrSuppressionInvalidAddress      = errors.New(errors.ErrBadRequest, "invalid_address")
+	ErrSuppressionInvalidReason       = errors.New(errors.ErrBadRequest, "invalid_reason")
+	ErrSuppressionInvalidSuppressions = errors.New(errors.ErrBadRequest, "invalid_suppressions")
 	// Folders
+	ErrFolderInvalidSenderId    = errors.New(errors.ErrBadRequest, "invalid_sender_id")
 	ErrFolderInvalidParent      = errors.New(errors.ErrBadRequest, "invalid_parent")
 	ErrFolderInvalidName        = errors.New(errors.ErrBadRequest, "invalid_name")
 	ErrFolderInvalidDescription = errors.New(errors.ErrBadRequest, "invalid_description")
+	ErrFolderInvalidRootId      = errors.New(errors.ErrBadRequest, "invalid_root_id")
+	ErrFolderInvalidKeySuffix   = errors.New(errors.ErrBadRequest, "invalid_key_suffix")
 	// Emails
 	ErrEmailInvalidId          = errors.New(errors.ErrBadRequest, "invalid_id")
 	ErrEmailInvalidFolderId    = errors.New(errors.ErrBadRequest, "invalid_folder_id")
+	ErrEmailInvalidKey         = errors.New(errors.ErrBadRequest, "invalid_key")
 	ErrEmailInvalidFromEmail   = errors.New(errors.ErrBadRequest, "invalid_from_email")
-	ErrEmailInvalidFromName    = errors.New(errors.ErrBadRequest, "invalid_from_name")
+	ErrEmailInvalidSenderId    = errors.New(errors.ErrBadRequest, "invalid_sender_id")
 	ErrEmailInvalidSubject     = errors.New(errors.ErrBadRequest, "invalid_subject")
-	ErrEmailInvalidToEmail     = errors.New(errors.ErrBadRequest, "invalid_to_email")
+	ErrEmailInvalidTo          = errors.New(errors.ErrBadRequest, "invalid_to")
+	ErrEmailInvalidCc          = errors.New(errors.ErrBadRequest, "invalid_cc")
+	ErrEmailInvalidBcc         = errors.New(errors.ErrBadRequest, "invalid_bcc")
+	ErrEmailInvalidAttachments = errors.New(errors.ErrBadRequest, "invalid_attachments")
+	ErrEmailFileAttachments    = errors.New(errors.ErrBadRequest, "file_attachments_not_allowed")
+	ErrEmailInvalidEvent       = errors.New(errors.ErrBadRequest, "invalid_event")
+	ErrEmailInvalidVariantId   = errors.New(errors.ErrBadRequest, "invalid_variant_id")
 	ErrEmailInvalidHtml        = errors.New(errors.ErrBadRequest, "invalid_html")
+	ErrEmailInvalidReplyTo     = errors.New(errors.ErrBadRequest, "invalid_reply_to")
+	ErrEmailInvalidCategory    = errors.New(errors.ErrBadRequest, "invalid_category")
+	ErrEmailInvalidTrackOpens  = errors.New(errors.ErrBadRequest, "invalid_track_opens")
+	ErrEmailInvalidTrackClicks = errors.New(errors.ErrBadRequest, "invalid_track_clicks")
 	ErrEmailInvalidText        = errors.New(errors.ErrBadRequest, "invalid_text")
 	ErrEmailInvalidDescription = errors.New(errors.ErrBadRequest, "invalid_description")
+	ErrEmailInvalidQuery       = errors.New(errors.ErrBadRequest, "invalid_query")
+	ErrEmailInvalidLimit       = errors.New(errors.ErrBadRequest, "invalid_limit")
+	// Email logs
+	ErrEmailLogInvalidStatus = errors.New(errors.ErrBadRequest, "invalid_status")
+	// Variants
+	ErrVariantInvalidEmailId  = errors.New(errors.ErrBadRequest, "invalid_email_id")
+	ErrVariantInvalidName     = errors.New(errors.ErrBadRequest, "invalid_name")
+	ErrVariantInvalidSubject  = errors.New(errors.ErrBadRequest, "invalid_subject")
+	ErrVariantInvalidHtml     = errors.New(errors.ErrBadRequest, "invalid_html")
+	ErrVariantInvalidWeight   = errors.New(errors.ErrBadRequest, "invalid_weight")
+	ErrVariantInvalidActive   = errors.New(errors.ErrBadRequest, "invalid_active")
+	ErrVariantInvalidWinnerId = errors.New(errors.ErrBadRequest, "invalid_winner_id")
+	// Bundles
+	ErrBundleInvalidFolderId = errors.New(errors.ErrBadRequest, "invalid_folder_id")
+	ErrBundleInvalidParentId = errors.New(errors.ErrBadRequest, "invalid_parent_id")
+	ErrBundleInvalidFormat   = errors.New(errors.ErrBadRequest, "invalid_format")
+	ErrBundleInvalidStrategy = errors.New(errors.ErrBadRequest, "invalid_strategy")
+	ErrBundleInvalidPrune    = errors.New(errors.ErrBadRequest, "invalid_prune")
+	ErrBundleInvalidBundle   = errors.New(errors.ErrBadRequest, "invalid_bundle")
 )
@@ -5,17 +5,75 @@ import (
 )
 
 type Interface interface {
+	// Senders
+	AdminCreateSender(ctx server.ReqCtx)
+	AdminFilterSenders(ctx server.ReqCtx)
+	AdminDeleteSender(ctx server.ReqCtx)
+	AdminUpdateSender(ctx server.ReqCtx)
+	// Domains
+	AdminCreateDomain(ctx server.ReqCtx)
+	AdminFilterDomains(ctx server.ReqCtx)
+	AdminDeleteDomain(ctx server.ReqCtx)
+	AdminVerifyDomain(ctx server.ReqCtx)
+	AdminCreateDomainKey(ctx server.ReqCtx)
+	AdminActivateDomainKey(ctx server.ReqCtx)
+	AdminDeleteDomainKey(ctx server.ReqCtx)
+	// Files
+	AdminCreateFile(ctx server.ReqCtx)
+	AdminFilterFiles(ctx server.ReqCtx)
+	AdminDeleteFile(ctx server.ReqCtx)
+	// Assets
+	AdminCreateAsset(ctx server.ReqCtx)
+	AdminFilterAssets(ctx server.ReqCtx)
+	AdminDeleteAsset(ctx server.ReqCtx)
+	GetAsset(ctx server.ReqCtx)
+	// Unsubscribes
+	GetUnsubscribe(ctx server.ReqCtx)
+	Unsubscribe(ctx server.ReqCtx)
+	// Suppressions
+	AdminCreateSuppression(ctx server.ReqCtx)
+	AdminFilterSuppressions(ctx server.ReqCtx)
+	AdminDeleteSuppression(ctx server.ReqCtx)
+	AdminUpdateSuppression(ctx server.ReqCtx)
+	AdminImportSuppressions(ctx server.ReqCtx)
 	// Folders
 	AdminCreateFolder(ctx server.ReqCtx)
 	AdminFilterFolders(ctx server.ReqCtx)
 	AdminDeleteFolder(ctx server.ReqCtx)
 	AdminUpdateFolder(ctx server.ReqCtx)
+	AdminGetFolderTree(ctx server.ReqCtx)
+	AdminMoveFolder(ctx server.ReqCtx)
+	AdminCloneFolder(ctx server.ReqCtx)
 	// Emails
 	AdminCreateEmail(ctx server.ReqCtx)
 	AdminFilterEmails(ctx server.ReqCtx)
+	AdminSearchEmails(ctx server.ReqCtx)
 	AdminDeleteEmail(ctx server.ReqCtx)
 	AdminUpdateEmail(ctx server.ReqCtx)
+	AdminMoveEmails(ctx server.ReqCtx)
+	AdminCloneEmail(ctx server.ReqCtx)
 	SendCustom(ctx server.ReqCtx)
 	Send(ctx server.ReqCtx)
+	AdminPreviewEmail(ctx server.ReqCtx)
 	AdminFilterEmailLogs(ctx server.ReqCtx)
+	// Delivery events
+	SmtpBzWebhook(ctx server.ReqCtx)
+	// Tracking
+	TrackOpen(ctx server.ReqCtx)
+	TrackClick(ctx server.ReqCtx)
+	AdminGetClickReport(ctx server.ReqCtx)
+	// Variants
+	AdminCreateVariant(ctx server.ReqCtx)
+	AdminFilterVariants(ctx server.ReqCtx)
+	AdminDeleteVariant(ctx server.ReqCtx)
+	AdminUpdateVariant(ctx server.ReqCtx)
+	AdminGetVariantReport(ctx server.ReqCtx)
+	AdminEndExperiment(ctx server.ReqCtx)
+	// Bundles
+	AdminExportBundle(ctx server.ReqCtx)
+	AdminImportBundle(ctx server.ReqCtx)
+	// Trash
+	AdminFilterTrash(ctx server.ReqCtx)
+	AdminRestoreFolder(ctx server.ReqCtx)
+	AdminRestoreEmail(ctx server.ReqCtx)
 }
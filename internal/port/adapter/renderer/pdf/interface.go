@@ -0,0 +1,6 @@
+package port
+
+type Interface interface {
+	// Render plain text to a PDF document, lines starting with "# " are headings
+	Render(content string) ([]byte, error)
+}
@@ -4,10 +4,127 @@ import (
 	"time"
 )
 
+// DKIM signing algorithms
+const (
+	DkimRsaSha256     = "rsa-sha256"
+	DkimEd25519Sha256 = "ed25519-sha256"
+)
+
+// Email log statuses
+const (
+	EmailStatusAccepted   = "accepted"
+	EmailStatusQueued     = "queued"
+	EmailStatusSending    = "sending"
+	EmailStatusSent       = "sent"
+	EmailStatusDelivered  = "delivered"
+	EmailStatusBounced    = "bounced"
+	EmailStatusFailed     = "failed"
+	EmailStatusSuppressed = "suppressed"
+	EmailStatusCancelled  = "cancelled"
+)
+
+// Delivery event types
+const (
+	DeliveryEventDelivered  = "delivered"
+	DeliveryEventDeferred   = "deferred"
+	DeliveryEventBounced    = "bounced"
+	DeliveryEventOpened     = "opened"
+	DeliveryEventComplained = "complained"
+)
+
 // Data
 
+type CreateSenderData struct {
+	Email       string
+	Name        string
+	ReplyTo     string
+	Description string
+}
+
+type FilterSendersData struct {
+	Id    *[]uint
+	Email *[]string
+	Name  *[]string
+}
+
+type CreateDomainData struct {
+	Name           string
+	DkimSelector   string
+	DkimAlgorithm  string
+	DkimPrivateKey string
+	DkimPublicKey  string
+}
+
+type FilterDomainsData struct {
+	Id       *[]uint
+	Name     *[]string
+	Verified *bool
+}
+
+type CreateDomainKeyData struct {
+	DomainId   uint
+	Selector   string
+	Algorithm  string
+	PrivateKey string
+	PublicKey  string
+}
+
+type FilterDomainKeysData struct {
+	Id       *[]uint
+	DomainId *[]uint
+	Active   *bool
+}
+
+type CreateFileData struct {
+	Name        string
+	ContentType string
+	Size        int64
+	Sha256      string
+	StorageKey  string
+}
+type FilterFilesData struct {
+	Id   *[]uint
+	Name *[]string
+}
+
+type CreateAssetData struct {
+	Name        string
+	ContentType string
+	Size        int64
+	Sha256      string
+	StorageKey  string
+}
+type FilterAssetsData struct {
+	Id   *[]uint
+	Name *[]string
+}
+
+type CreateUnsubscribeData struct {
+	Email    string
+	Category string
+}
+type FilterUnsubscribesData struct {
+	Email    *[]string
+	Category *[]string
+}
+
+type CreateSuppressionData struct {
+	Address string
+	Reason  string
+	Source  string
+	Expires *time.Time
+}
+type FilterSuppressionsData struct {
+	Id      *[]uint
+	Address *[]string
+	Reason  *[]string
+	Source  *[]string
+	Active  *bool
+}
+
 type CreateFolderData struct {
 	ParentId    *uint
+	SenderId    *uint
 	Name        string
 	Description string
 	SystemFlag  bool
@@ -21,75 +138,473 @@ type FilterFoldersData struct {
 }
 
 type CreateEmailData struct {
-	FolderId    *uint
-	FromEmail   string
-	FromName    string
-	Subject     string
-	Html        string
-	Text        string
-	Description string
-	SystemFlag  bool
+	FolderId       *uint
+	Key            string
+	SenderId       *uint
+	Subject        string
+	Html           string
+	Text           string
+	ReplyTo        *string
+	Headers        map[string]string
+	PdfAttachments map[string]string
+	Category       *string
+	TrackOpens     bool
+	TrackClicks    bool
+	Description    string
+	SystemFlag     bool
 }
 type FilterEmailsData struct {
 	Id         *[]uint
 	FolderId   *[]*uint
+	Key        *[]string
+	SystemFlag *bool
+}
+type SearchEmailsData struct {
+	Query      string
+	Id         *[]uint
+	FolderId   *[]*uint
+	Key        *[]string
 	SystemFlag *bool
+	Limit      uint
+}
+
+type MoveEmailsData struct {
+	Ids      []uint
+	FolderId *uint
+}
+
+type CreateVariantData struct {
+	EmailId uint
+	Name    string
+	Subject *string
+	Html    *string
+	Text    *string
+	Weight  uint
+}
+
+type FilterVariantsData struct {
+	Id      *[]uint
+	EmailId *[]uint
+	Active  *bool
+}
+
+type ImportBundleData struct {
+	ParentId       *uint
+	Folders        []ImportFolderData
+	Emails         []ImportEmailData
+	DeleteFolders  []uint
+	DeleteEmails   []uint
+	DeleteVariants []uint
+}
+
+type ImportFolderData struct {
+	Id          *uint
+	Path        []string
+	Name        string
+	SenderId    *uint
+	Description string
+	SystemFlag  bool
+	Update      bool
+}
+
+type ImportEmailData struct {
+	Id             *uint
+	FolderPath     []string
+	Key            string
+	SenderId       *uint
+	Subject        string
+	Html           string
+	Text           string
+	ReplyTo        *string
+	Headers        map[string]string
+	PdfAttachments map[string]string
+	Category       *string
+	TrackOpens     bool
+	TrackClicks    bool
+	Description    string
+	SystemFlag     bool
+	Update         bool
+	Variants       []ImportVariantData
+}
+
+type ImportVariantData struct {
+	Id      *uint
+	Name    string
+	Subject *string
+	Html    *string
+	Text    *string
+	Weight  uint
+	Active  bool
+	Update  bool
 }
 
 type SendData struct {
-	FromEmail string
-	FromName  string
-	Subject   string
-	ToEmail   string
-	Html      string
-	Text      string
+	EmailId     *uint
+	VariantId   *uint
+	FromEmail   string
+	FromName    string
+	ReplyTo     string
+	Headers     map[string]string
+	Subject     string
+	To          []string
+	Cc          []string
+	Bcc         []string
+	Html        string
+	Text        string
+	Attachments []AttachmentData
+	Inline      []InlineData
+	Calendar    *CalendarData
+	Links       []string
+}
+
+type AttachmentData struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
+// Inline part referenced from html as cid:ContentId
+type InlineData struct {
+	ContentId   string
+	ContentType string
+	Content     []byte
+}
+
+// iCalendar object sent as text/calendar alternative
+type CalendarData struct {
+	Uid     string
+	Method  string
+	Content []byte
+}
+
+type EventSequenceData struct {
+	Uid    string
+	Method string
 }
 
 type FilterEmailLogsData struct {
 	Id        *[]uint
+	EmailId   *[]uint
+	VariantId *[]uint
 	FromEmail *[]string
 	FromName  *[]string
-	ToEmail   *[]string
+	Recipient *[]string
 	Status    *[]string
 	MessageId *[]string
+	EventUid  *[]string
+}
+
+// Delivery event reported by the provider for the message
+type DeliveryEventData struct {
+	MessageId string
+	Type      string
+	Recipient string
+	Reason    string
+	Hard      bool
+	Occurred  time.Time
+}
+
+// Status change of an email log, the message id and errors are set if not nil
+type TransitionData struct {
+	From      string
+	To        string
+	Reason    string
+	MessageId *string
+	Errors    *string
+	Created   time.Time
+}
+
+// Sent messages awaiting a final delivery status
+type FilterReconcileLogsData struct {
+	Since time.Time
+	Limit int
+}
+
+type CreateDeliveryEventData struct {
+	LogId     uint
+	Type      string
+	Recipient string
+	Reason    string
+	Hard      bool
+	Occurred  time.Time
+}
+
+// Click of the tracked link of the message by its index in the log links
+type CreateEmailClickData struct {
+	LogId     uint
+	Link      int
+	UserAgent string
+	IpHash    string
+	Created   time.Time
+}
+
+// Open of the message, the first open is the first one not made by a machine
+type CreateEmailOpenData struct {
+	LogId     uint
+	Machine   bool
+	UserAgent string
+	IpHash    string
+	Created   time.Time
 }
 
 // Results
 
+type SenderResult struct {
+	Id          uint
+	Email       string
+	Name        string
+	ReplyTo     string
+	Description string
+	Updated     time.Time
+	Created     time.Time
+}
+
+type DomainResult struct {
+	Id            uint
+	Name          string
+	SpfVerified   bool
+	DkimVerified  bool
+	DmarcVerified bool
+	Verified      bool
+	Checked       *time.Time
+	Updated       time.Time
+	Created       time.Time
+}
+
+type DomainKeyResult struct {
+	Id         uint
+	DomainId   uint
+	Selector   string
+	Algorithm  string
+	PrivateKey string
+	PublicKey  string
+	Active     bool
+	Verified   bool
+	Created    time.Time
+}
+
+type FileResult struct {
+	Id          uint
+	Name        string
+	ContentType string
+	Size        int64
+	Sha256      string
+	StorageKey  string
+	Created     time.Time
+}
+
+type AssetResult struct {
+	Id          uint
+	Name        string
+	ContentType string
+	Size        int64
+	Sha256      string
+	StorageKey  string
+	Created     time.Time
+}
+
+type UnsubscribeResult struct {
+	Email    string
+	Category string
+	Created  time.Time
+}
+
+type SuppressionResult struct {
+	Id      uint
+	Address string
+	Reason  string
+	Source  string
+	Expires *time.Time
+	Updated time.Time
+	Created time.Time
+}
+
 type FolderResult struct {
+	Id          uint
+	ParentId    *uint
+	SenderId    *uint
+	Name        string
+	Description string
+	SystemFlag  bool
+	Updated     time.Time
+	Created     time.Time
+}
+
+type FolderEmailsCountResult struct {
+	FolderId *uint
+	Count    uint
+}
+
+type TrashResult struct {
+	Folders []TrashFolderResult
+	Emails  []TrashEmailResult
+}
+
+type TrashFolderResult struct {
 	Id          uint
 	ParentId    *uint
 	Name        string
 	Description string
 	SystemFlag  bool
+	Deleted     time.Time
+	Updated     time.Time
+	Created     time.Time
+}
+
+type TrashEmailResult struct {
+	Id          uint
+	FolderId    *uint
+	Key         string
+	Subject     string
+	Description string
+	SystemFlag  bool
+	Deleted     time.Time
 	Updated     time.Time
 	Created     time.Time
 }
 
 type EmailResult struct {
+	Id             uint
+	FolderId       *uint
+	Key            string
+	SenderId       *uint
+	Subject        string
+	Html           string
+	Text           string
+	ReplyTo        *string
+	Headers        map[string]string
+	PdfAttachments map[string]string
+	Category       *string
+	TrackOpens     bool
+	TrackClicks    bool
+	Description    string
+	SystemFlag     bool
+	Updated        time.Time
+	Created        time.Time
+}
+
+type SearchEmailResult struct {
 	Id          uint
 	FolderId    *uint
-	FromEmail   string
-	FromName    string
+	FolderName  *string
+	Key         string
 	Subject     string
-	Html        string
-	Text        string
 	Description string
 	SystemFlag  bool
+	Rank        float64
+	Highlight   string
+	Snippet     string
 	Updated     time.Time
 	Created     time.Time
 }
 
+type VariantResult struct {
+	Id      uint
+	EmailId uint
+	Name    string
+	Subject *string
+	Html    *string
+	Text    *string
+	Weight  uint
+	Active  bool
+	Updated time.Time
+	Created time.Time
+}
+
+type VariantStatsResult struct {
+	VariantId    uint
+	Sent         uint
+	Delivered    uint
+	Failed       uint
+	UniqueOpens  uint
+	UniqueClicks uint
+}
+
 type EmailLogResult struct {
-	Id        uint
-	FromEmail string
-	FromName  string
-	Subject   string
-	ToEmail   string
-	Html      string
-	Text      string
-	Status    string
+	Id          uint
+	EmailId     *uint
+	VariantId   *uint
+	FromEmail   string
+	FromName    string
+	Subject     string
+	To          []string
+	Cc          []string
+	Bcc         []string
+	Html        string
+	Text        string
+	Status      string
+	MessageId   *string
+	Errors      *string
+	Attachments []EmailLogAttachmentResult
+	EventUid    *string
+	Links       []string
+	Events      []DeliveryEventResult
+	Transitions []EmailLogTransitionResult
+	Opens       []EmailOpenResult
+	Clicks      []EmailClickResult
+	Created     time.Time
+}
+
+type EmailLogAttachmentResult struct {
+	Filename    string
+	ContentType string
+	Size        int64
+	Sha256      string
+}
+
+// Result of a provider submission, the message is rejected if errors are set
+type SendResult struct {
 	MessageId *string
 	Errors    *string
+}
+
+type ReconcileLogResult struct {
+	Id        uint
+	MessageId string
+}
+
+type DeliveryEventResult struct {
+	Id        uint
+	LogId     uint
+	Type      string
+	Recipient string
+	Reason    string
+	Hard      bool
+	Occurred  time.Time
+	Created   time.Time
+}
+
+type EmailLogTransitionResult struct {
+	Id      uint
+	LogId   uint
+	From    string
+	To      string
+	Reason  string
+	Created time.Time
+}
+
+type EmailOpenResult struct {
+	Id        uint
+	LogId     uint
+	First     bool
+	Machine   bool
+	UserAgent string
+	IpHash    string
 	Created   time.Time
 }
+
+type EmailClickResult struct {
+	Id        uint
+	LogId     uint
+	Link      int
+	Url       string
+	UserAgent string
+	IpHash    string
+	Created   time.Time
+}
+
+type ClickStatsResult struct {
+	Url          string
+	Clicks       uint
+	UniqueClicks uint
+}
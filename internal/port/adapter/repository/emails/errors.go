@@ -5,8 +5,31 @@ import (
 )
 
 var (
+	// Senders
+	ErrSenderNotFound = errors.New(errors.ErrBadRequest, "sender_not_found")
+	// Domains
+	ErrDomainNotFound    = errors.New(errors.ErrBadRequest, "domain_not_found")
+	ErrDomainKeyNotFound = errors.New(errors.ErrBadRequest, "dkim_key_not_found")
+	// Files
+	ErrFileNotFound = errors.New(errors.ErrBadRequest, "file_not_found")
+	// Assets
+	ErrAssetNotFound = errors.New(errors.ErrBadRequest, "asset_not_found")
+	// Suppressions
+	ErrSuppressionNotFound = errors.New(errors.ErrBadRequest, "suppression_not_found")
 	// Folders
 	ErrFolderNotFound = errors.New(errors.ErrBadRequest, "folder_not_found")
 	// Emails
-	ErrEmailNotFound = errors.New(errors.ErrBadRequest, "email_not_found")
+	ErrEmailNotFound           = errors.New(errors.ErrBadRequest, "email_not_found")
+	ErrAttachmentsNotSupported = errors.New(errors.ErrBadRequest, "attachments_not_supported")
+	ErrCalendarNotSupported    = errors.New(errors.ErrBadRequest, "calendar_not_supported")
+	ErrHeadersNotSupported     = errors.New(errors.ErrBadRequest, "headers_not_supported")
+	ErrEmailLogStatusChanged   = errors.New(errors.ErrBadRequest, "email_log_status_changed")
+	ErrEmailLogNotFound        = errors.New(errors.ErrBadRequest, "email_log_not_found")
+	ErrEmailLinkNotFound       = errors.New(errors.ErrBadRequest, "email_link_not_found")
+	// Delivery events
+	ErrWebhookInvalid             = errors.New(errors.ErrBadRequest, "invalid_webhook")
+	ErrDeliveryStatusNotSupported = errors.New(errors.ErrBadRequest, "delivery_status_not_supported")
+	ErrProviderRateLimited        = errors.New(errors.ErrBadRequest, "provider_rate_limited")
+	// Variants
+	ErrVariantNotFound = errors.New(errors.ErrBadRequest, "variant_not_found")
 )
@@ -2,19 +2,86 @@ package port
 
 import (
 	"context"
+	"time"
 )
 
 type Interface interface {
+	// Senders
+	CreateSender(ctx context.Context, data CreateSenderData) (*SenderResult, error)
+	FilterSenders(ctx context.Context, data FilterSendersData) (*[]SenderResult, error)
+	DeleteSender(ctx context.Context, id uint) error
+	UpdateSender(ctx context.Context, id uint, data map[string]any) error
+	CountSenderUsage(ctx context.Context, id uint) (uint, error)
+	// Domains
+	CreateDomain(ctx context.Context, data CreateDomainData) (*DomainResult, error)
+	FilterDomains(ctx context.Context, data FilterDomainsData) (*[]DomainResult, error)
+	DeleteDomain(ctx context.Context, id uint) error
+	UpdateDomain(ctx context.Context, id uint, data map[string]any) error
+	CreateDomainKey(ctx context.Context, data CreateDomainKeyData) (*DomainKeyResult, error)
+	FilterDomainKeys(ctx context.Context, data FilterDomainKeysData) (*[]DomainKeyResult, error)
+	DeleteDomainKey(ctx context.Context, id uint) error
+	UpdateDomainKey(ctx context.Context, id uint, data map[string]any) error
+	ActivateDomainKey(ctx context.Context, domainId uint, id uint) error
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+	// Files
+	CreateFile(ctx context.Context, data CreateFileData) (*FileResult, error)
+	FilterFiles(ctx context.Context, data FilterFilesData) (*[]FileResult, error)
+	DeleteFile(ctx context.Context, id uint) error
+	// Assets
+	CreateAsset(ctx context.Context, data CreateAssetData) (*AssetResult, error)
+	FilterAssets(ctx context.Context, data FilterAssetsData) (*[]AssetResult, error)
+	DeleteAsset(ctx context.Context, id uint) error
+	// Unsubscribes
+	CreateUnsubscribe(ctx context.Context, data CreateUnsubscribeData) error
+	FilterUnsubscribes(ctx context.Context, data FilterUnsubscribesData) (*[]UnsubscribeResult, error)
+	// Suppressions
+	CreateSuppression(ctx context.Context, data CreateSuppressionData) (*SuppressionResult, error)
+	FilterSuppressions(ctx context.Context, data FilterSuppressionsData) (*[]SuppressionResult, error)
+	DeleteSuppression(ctx context.Context, id uint) error
+	UpdateSuppression(ctx context.Context, id uint, data map[string]any) error
+	ImportSuppressions(ctx context.Context, data []CreateSuppressionData) (uint, error)
+	AddSuppressions(ctx context.Context, data []CreateSuppressionData) error
 	// Folders
 	CreateFolder(ctx context.Context, data CreateFolderData) (*FolderResult, error)
 	FilterFolders(ctx context.Context, data FilterFoldersData) (*[]FolderResult, error)
 	DeleteFolder(ctx context.Context, id uint) error
 	UpdateFolder(ctx context.Context, id uint, data map[string]any) error
+	CountFolderEmails(ctx context.Context) (*[]FolderEmailsCountResult, error)
 	// Emails
 	CreateEmail(ctx context.Context, data CreateEmailData) (*EmailResult, error)
 	FilterEmails(ctx context.Context, data FilterEmailsData) (*[]EmailResult, error)
+	SearchEmails(ctx context.Context, data SearchEmailsData) (*[]SearchEmailResult, error)
 	DeleteEmail(ctx context.Context, id uint) error
 	UpdateEmail(ctx context.Context, id uint, data map[string]any) error
-	Send(ctx context.Context, data SendData) (*EmailLogResult, error)
+	MoveEmails(ctx context.Context, data MoveEmailsData) error
+	Send(ctx context.Context, data SendData) (*SendResult, error)
+	CreateEmailLog(ctx context.Context, data SendData, transition TransitionData) (*EmailLogResult, error)
+	UpdateEmailLogStatus(ctx context.Context, id uint, data TransitionData) (*EmailLogTransitionResult, error)
 	FilterEmailLogs(ctx context.Context, data FilterEmailLogsData) (*[]EmailLogResult, error)
+	NextEventSequence(ctx context.Context, data EventSequenceData) (uint, error)
+	// Delivery events
+	ParseSmtpBzWebhook(body []byte) ([]DeliveryEventData, error)
+	CreateDeliveryEvents(ctx context.Context, data []CreateDeliveryEventData) (uint, error)
+	GetDeliveryEvents(ctx context.Context, messageId string) ([]DeliveryEventData, error)
+	FilterReconcileLogs(ctx context.Context, data FilterReconcileLogsData) (*[]ReconcileLogResult, error)
+	SetLogsReconciled(ctx context.Context, ids []uint, reconciled time.Time) error
+	// Opens
+	CreateEmailOpen(ctx context.Context, data CreateEmailOpenData) (*EmailOpenResult, error)
+	// Clicks
+	CreateEmailClick(ctx context.Context, data CreateEmailClickData) (*EmailClickResult, error)
+	GetClickStats(ctx context.Context, emailId uint) (*[]ClickStatsResult, error)
+	// Variants
+	CreateVariant(ctx context.Context, data CreateVariantData) (*VariantResult, error)
+	FilterVariants(ctx context.Context, data FilterVariantsData) (*[]VariantResult, error)
+	DeleteVariant(ctx context.Context, id uint) error
+	UpdateVariant(ctx context.Context, id uint, data map[string]any) error
+	GetVariantStats(ctx context.Context, emailId uint) (*[]VariantStatsResult, error)
+	EndExperiment(ctx context.Context, emailId uint, data map[string]any) error
+	// Bundles
+	ImportBundle(ctx context.Context, data ImportBundleData) error
+	// Trash
+	FilterTrash(ctx context.Context) (*TrashResult, error)
+	RestoreFolder(ctx context.Context, id uint, deleted time.Time) error
+	RestoreEmail(ctx context.Context, id uint) error
+	PurgeTrash(ctx context.Context, before time.Time) error
 }
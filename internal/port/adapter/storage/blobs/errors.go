@@ -0,0 +1,10 @@
+package port
+
+import (
+	"github.com/flash-go/sdk/errors"
+)
+
+var (
+	ErrBlobNotFound   = errors.New(errors.ErrNotFound, "blob_not_found")
+	ErrBlobInvalidKey = errors.New(errors.ErrBadRequest, "invalid_blob_key")
+)
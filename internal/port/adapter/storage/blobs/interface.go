@@ -0,0 +1,9 @@
+package port
+
+import "context"
+
+type Interface interface {
+	Put(ctx context.Context, key string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+}
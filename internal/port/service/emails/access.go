@@ -0,0 +1,18 @@
+package port
+
+import (
+	"context"
+)
+
+type systemAccessKey struct{}
+
+// Allow content edits of system folders and emails within the context
+func WithSystemAccess(ctx context.Context) context.Context {
+	return context.WithValue(ctx, systemAccessKey{}, true)
+}
+
+// Check whether content edits of system folders and emails are allowed
+func HasSystemAccess(ctx context.Context) bool {
+	access, _ := ctx.Value(systemAccessKey{}).(bool)
+	return access
+}
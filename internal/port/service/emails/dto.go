@@ -7,8 +7,72 @@ import (
 
 // Data
 
+type CreateSenderData struct {
+	Email       string
+	Name        string
+	ReplyTo     string
+	Description string
+}
+type FilterSendersData struct {
+	Id    *[]uint
+	Email *[]string
+	Name  *[]string
+}
+
+type CreateDomainData struct {
+	Name          string
+	DkimSelector  string
+	DkimAlgorithm string
+}
+type CreateDomainKeyData struct {
+	Selector  string
+	Algorithm string
+}
+type FilterDomainsData struct {
+	Id       *[]uint
+	Name     *[]string
+	Verified *bool
+}
+
+type CreateFileData struct {
+	Name        string
+	ContentType string
+	Content     []byte
+}
+type FilterFilesData struct {
+	Id   *[]uint
+	Name *[]string
+}
+
+type CreateAssetData struct {
+	Name        string
+	ContentType string
+	Content     []byte
+}
+type FilterAssetsData struct {
+	Id   *[]uint
+	Name *[]string
+}
+
+type CreateSuppressionData struct {
+	Address string
+	Reason  string
+	Expires *time.Time
+}
+type FilterSuppressionsData struct {
+	Id      *[]uint
+	Address *[]string
+	Reason  *[]string
+	Source  *[]string
+	Active  *bool
+}
+type ImportSuppressionsData struct {
+	Suppressions []CreateSuppressionData
+}
+
 type CreateFolderData struct {
 	ParentId    *uint
+	SenderId    *uint
 	Name        string
 	Description string
 	SystemFlag  bool
@@ -19,50 +83,244 @@ type FilterFoldersData struct {
 	Name       *[]string
 	SystemFlag *bool
 }
+type FolderTreeData struct {
+	RootId *uint
+}
+type CloneFolderData struct {
+	ParentId  *uint
+	Name      *string
+	KeySuffix *string
+}
+type MoveFolderData struct {
+	ParentId *uint
+}
 
 type CreateEmailData struct {
-	FolderId    *uint
-	FromEmail   string
-	FromName    string
-	Subject     string
-	Html        string
-	Text        string
-	Description string
-	SystemFlag  bool
+	FolderId       *uint
+	Key            string
+	SenderId       *uint
+	Subject        string
+	Html           string
+	Text           string
+	ReplyTo        *string
+	Headers        map[string]string
+	PdfAttachments map[string]string
+	Category       *string
+	TrackOpens     bool
+	TrackClicks    bool
+	Description    string
+	SystemFlag     bool
 }
 type FilterEmailsData struct {
 	Id         *[]uint
 	FolderId   *[]*uint
+	Key        *[]string
 	SystemFlag *bool
 }
+type CloneEmailData struct {
+	FolderId *uint
+	Key      string
+}
+type SearchEmailsData struct {
+	Query      string
+	Id         *[]uint
+	FolderId   *[]*uint
+	Key        *[]string
+	SystemFlag *bool
+	Limit      *uint
+}
+
+type MoveEmailsData struct {
+	Ids      []uint
+	FolderId *uint
+}
+
+type CreateVariantData struct {
+	EmailId uint
+	Name    string
+	Subject *string
+	Html    *string
+	Text    *string
+	Weight  uint
+}
+type FilterVariantsData struct {
+	Id      *[]uint
+	EmailId *[]uint
+	Active  *bool
+}
+type EndExperimentData struct {
+	WinnerId *uint
+}
+
+type ExportBundleData struct {
+	FolderId *uint
+	Format   string
+}
+type ImportBundleData struct {
+	ParentId *uint
+	Strategy string
+	Prune    bool
+	DryRun   bool
+	Format   string
+	Bundle   []byte
+}
 
 type SendCustomData struct {
-	FromEmail string
-	FromName  string
-	Subject   string
-	ToEmail   string
-	Html      string
-	Text      string
+	FromEmail   string
+	FromName    string
+	ReplyTo     string
+	Headers     map[string]string
+	Subject     string
+	To          []string
+	Cc          []string
+	Bcc         []string
+	Html        string
+	Text        string
+	Attachments []AttachmentData
 }
 type SendData struct {
-	EmailId uint
-	ToEmail string
-	Vars    *json.RawMessage
+	EmailId     uint
+	To          []string
+	Cc          []string
+	Bcc         []string
+	Vars        *json.RawMessage
+	Attachments []AttachmentData
+	Event       *EventData
+}
+type AttachmentData struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+	FileId      *uint
+}
+type EventData struct {
+	Uid         string
+	Method      string
+	Start       time.Time
+	End         time.Time
+	Location    string
+	Organizer   string
+	Summary     string
+	Description string
+}
+type PreviewEmailData struct {
+	EmailId   uint
+	VariantId *uint
+	Vars      *json.RawMessage
 }
 type FilterEmailLogsData struct {
 	Id        *[]uint
+	EmailId   *[]uint
+	VariantId *[]uint
 	FromEmail *[]string
 	FromName  *[]string
-	ToEmail   *[]string
+	Recipient *[]string
 	Status    *[]string
 	MessageId *[]string
+	EventUid  *[]string
+}
+type TrackOpenData struct {
+	Token        string
+	UserAgent    string
+	RemoteAddr   string
+	ForwardedFor string
+}
+type TrackClickData struct {
+	Token        string
+	UserAgent    string
+	RemoteAddr   string
+	ForwardedFor string
 }
 
 // Results
 
+type SenderResult struct {
+	Id          uint
+	Email       string
+	Name        string
+	ReplyTo     string
+	Description string
+	Updated     time.Time
+	Created     time.Time
+}
+
+type DomainResult struct {
+	Id            uint
+	Name          string
+	SpfVerified   bool
+	DkimVerified  bool
+	DmarcVerified bool
+	Verified      bool
+	Keys          []DomainKeyResult
+	Records       []DnsRecordResult
+	Checked       *time.Time
+	Updated       time.Time
+	Created       time.Time
+}
+
+type DomainKeyResult struct {
+	Id        uint
+	Selector  string
+	Algorithm string
+	Active    bool
+	Verified  bool
+	Created   time.Time
+}
+
+type DnsRecordResult struct {
+	Type     string
+	Name     string
+	Value    string
+	Verified bool
+}
+
+type FileResult struct {
+	Id          uint
+	Name        string
+	ContentType string
+	Size        int64
+	Sha256      string
+	Created     time.Time
+}
+
+type AssetResult struct {
+	Id          uint
+	Name        string
+	ContentType string
+	Size        int64
+	Sha256      string
+	Url         string
+	Created     time.Time
+}
+
+type AssetContentResult struct {
+	ContentType string
+	Content     []byte
+}
+
+type UnsubscribeResult struct {
+	Email    string
+	Category string
+}
+
+type SuppressionResult struct {
+	Id      uint
+	Address string
+	Reason  string
+	Source  string
+	Expires *time.Time
+	Updated time.Time
+	Created time.Time
+}
+
+type ImportSuppressionsResult struct {
+	Imported uint
+}
+
 type FolderResult struct {
 	Id          uint
 	ParentId    *uint
+	SenderId    *uint
 	Name        string
 	Description string
 	SystemFlag  bool
@@ -70,30 +328,282 @@ type FolderResult struct {
 	Created     time.Time
 }
 
+type FolderTreeResult struct {
+	Id          uint
+	ParentId    *uint
+	Name        string
+	Description string
+	SystemFlag  bool
+	Path        string
+	Breadcrumbs []FolderBreadcrumbResult
+	Emails      uint
+	TotalEmails uint
+	Children    []FolderTreeResult
+	Updated     time.Time
+	Created     time.Time
+}
+
+type FolderBreadcrumbResult struct {
+	Id   uint
+	Name string
+}
+
+type TrashResult struct {
+	Folders []TrashFolderResult
+	Emails  []TrashEmailResult
+}
+
+type TrashFolderResult struct {
+	Id          uint
+	ParentId    *uint
+	Name        string
+	Description string
+	SystemFlag  bool
+	Deleted     time.Time
+	Expires     time.Time
+	Updated     time.Time
+	Created     time.Time
+}
+
+type TrashEmailResult struct {
+	Id          uint
+	FolderId    *uint
+	Key         string
+	Subject     string
+	Description string
+	SystemFlag  bool
+	Deleted     time.Time
+	Expires     time.Time
+	Updated     time.Time
+	Created     time.Time
+}
+
 type EmailResult struct {
+	Id             uint
+	FolderId       *uint
+	Key            string
+	SenderId       *uint
+	Subject        string
+	Html           string
+	Text           string
+	ReplyTo        *string
+	Headers        map[string]string
+	PdfAttachments map[string]string
+	Category       *string
+	TrackOpens     bool
+	TrackClicks    bool
+	Description    string
+	SystemFlag     bool
+	Updated        time.Time
+	Created        time.Time
+}
+
+type SearchEmailResult struct {
 	Id          uint
 	FolderId    *uint
-	FromEmail   string
-	FromName    string
+	FolderName  *string
+	Key         string
 	Subject     string
-	Html        string
-	Text        string
 	Description string
 	SystemFlag  bool
+	Rank        float64
+	Highlight   string
+	Snippet     string
 	Updated     time.Time
 	Created     time.Time
 }
 
+type VariantResult struct {
+	Id      uint
+	EmailId uint
+	Name    string
+	Subject *string
+	Html    *string
+	Text    *string
+	Weight  uint
+	Active  bool
+	Updated time.Time
+	Created time.Time
+}
+
+type VariantReportResult struct {
+	VariantId    uint
+	Name         string
+	Weight       uint
+	Active       bool
+	Sent         uint
+	Delivered    uint
+	Failed       uint
+	UniqueOpens  uint
+	UniqueClicks uint
+	DeliveryRate float64
+	OpenRate     float64
+	ClickRate    float64
+}
+
+type BundleResult struct {
+	Format string
+	Data   []byte
+}
+
+type ImportActionResult struct {
+	Entity  string
+	Key     string
+	Action  string
+	Target  *string
+	Changes []string
+}
+
 type EmailLogResult struct {
+	Id          uint
+	EmailId     *uint
+	VariantId   *uint
+	FromEmail   string
+	FromName    string
+	Subject     string
+	To          []string
+	Cc          []string
+	Bcc         []string
+	Html        string
+	Text        string
+	Status      string
+	MessageId   *string
+	Errors      *string
+	Attachments []EmailLogAttachmentResult
+	EventUid    *string
+	Links       []string
+	Events      []DeliveryEventResult
+	Transitions []EmailLogTransitionResult
+	Opens       []EmailOpenResult
+	Clicks      []EmailClickResult
+	Created     time.Time
+}
+
+type PreviewResult struct {
+	FromEmail   string
+	FromName    string
+	ReplyTo     string
+	Headers     map[string]string
+	Subject     string
+	Html        string
+	Text        string
+	Attachments []PreviewAttachmentResult
+}
+
+type PreviewAttachmentResult struct {
+	Filename    string
+	ContentType string
+	Size        int64
+	Content     []byte
+}
+
+type EmailLogAttachmentResult struct {
+	Filename    string
+	ContentType string
+	Size        int64
+	Sha256      string
+}
+
+type EmailLogTransitionResult struct {
+	Id      uint
+	LogId   uint
+	From    string
+	To      string
+	Reason  string
+	Created time.Time
+}
+
+type DeliveryEventResult struct {
+	Id        uint
+	LogId     uint
+	Type      string
+	Recipient string
+	Reason    string
+	Hard      bool
+	Occurred  time.Time
+	Created   time.Time
+}
+
+type EmailOpenResult struct {
+	Id        uint
+	LogId     uint
+	First     bool
+	Machine   bool
+	UserAgent string
+	IpHash    string
+	Created   time.Time
+}
+
+type EmailClickResult struct {
 	Id        uint
-	FromEmail string
-	FromName  string
-	Subject   string
-	ToEmail   string
-	Html      string
-	Text      string
-	Status    string
-	MessageId *string
-	Errors    *string
+	LogId     uint
+	Link      int
+	Url       string
+	UserAgent string
+	IpHash    string
 	Created   time.Time
 }
+
+type TrackClickResult struct {
+	Url string
+}
+
+type ClickReportResult struct {
+	Url          string
+	Clicks       uint
+	UniqueClicks uint
+}
+
+// Bundles
+
+const (
+	BundleVersion = 1
+
+	BundleFormatJson = "json"
+	BundleFormatZip  = "zip"
+
+	ImportStrategySkip      = "skip"
+	ImportStrategyOverwrite = "overwrite"
+	ImportStrategyRename    = "rename"
+)
+
+type Bundle struct {
+	Version  int            `json:"version"`
+	Exported time.Time      `json:"exported"`
+	Folders  []BundleFolder `json:"folders"`
+	Emails   []BundleEmail  `json:"emails"`
+}
+
+type BundleFolder struct {
+	Path        []string `json:"path"`
+	Description string   `json:"description"`
+	SystemFlag  bool     `json:"system_flag"`
+}
+
+type BundleEmail struct {
+	Key            string            `json:"key"`
+	Folder         []string          `json:"folder"`
+	FromEmail      string            `json:"from_email"`
+	FromName       string            `json:"from_name"`
+	Subject        string            `json:"subject"`
+	Html           string            `json:"html"`
+	Text           string            `json:"text"`
+	ReplyTo        *string           `json:"reply_to"`
+	Headers        map[string]string `json:"headers"`
+	PdfAttachments map[string]string `json:"pdf_attachments"`
+	Category       *string           `json:"category"`
+	TrackOpens     bool              `json:"track_opens"`
+	TrackClicks    bool              `json:"track_clicks"`
+	Description    string            `json:"description"`
+	SystemFlag     bool              `json:"system_flag"`
+	Variants       []BundleVariant   `json:"variants"`
+}
+
+type BundleVariant struct {
+	Name    string  `json:"name"`
+	Subject *string `json:"subject"`
+	Html    *string `json:"html"`
+	Text    *string `json:"text"`
+	Weight  uint    `json:"weight"`
+	Active  bool    `json:"active"`
+}
@@ -5,8 +5,64 @@ import (
 )
 
 var (
+	// System entities
+	ErrSystemEntity = errors.New(errors.ErrBadRequest, "system_entity")
+	ErrSystemAccess = errors.New(errors.ErrBadRequest, "system_access")
+	// Senders
+	ErrSenderExist       = errors.New(errors.ErrBadRequest, "sender_exist")
+	ErrSenderNotFound    = errors.New(errors.ErrBadRequest, "sender_not_found")
+	ErrSenderInUse       = errors.New(errors.ErrBadRequest, "sender_in_use")
+	ErrSenderNotApproved = errors.New(errors.ErrBadRequest, "sender_not_approved")
+	ErrSenderNotSet      = errors.New(errors.ErrBadRequest, "sender_not_set")
+	// Domains
+	ErrDomainExist       = errors.New(errors.ErrBadRequest, "domain_exist")
+	ErrDomainNotFound    = errors.New(errors.ErrBadRequest, "domain_not_found")
+	ErrDomainNotVerified = errors.New(errors.ErrBadRequest, "domain_not_verified")
+	// DKIM keys
+	ErrDomainKeyExist        = errors.New(errors.ErrBadRequest, "dkim_key_exist")
+	ErrDomainKeyNotFound     = errors.New(errors.ErrBadRequest, "dkim_key_not_found")
+	ErrDomainKeyActive       = errors.New(errors.ErrBadRequest, "dkim_key_active")
+	ErrDomainKeyNotPublished = errors.New(errors.ErrBadRequest, "dkim_key_not_published")
+	// Files
+	ErrFileNotFound = errors.New(errors.ErrBadRequest, "file_not_found")
+	// Attachments
+	ErrAttachmentsTooLarge      = errors.New(errors.ErrBadRequest, "attachments_too_large")
+	ErrAttachmentTypeNotAllowed = errors.New(errors.ErrBadRequest, "attachment_type_not_allowed")
+	// Assets
+	ErrAssetExist          = errors.New(errors.ErrBadRequest, "asset_exist")
+	ErrAssetNotFound       = errors.New(errors.ErrBadRequest, "asset_not_found")
+	ErrAssetTooLarge       = errors.New(errors.ErrBadRequest, "asset_too_large")
+	ErrAssetTypeNotAllowed = errors.New(errors.ErrBadRequest, "asset_type_not_allowed")
+	// Unsubscribes
+	ErrUnsubscribeInvalid = errors.New(errors.ErrBadRequest, "invalid_unsubscribe_token")
+	// Suppressions
+	ErrSuppressionExist = errors.New(errors.ErrBadRequest, "suppression_exist")
+	// Delivery events
+	ErrWebhookUnauthorized = errors.New(errors.ErrUnauthorized, "invalid_webhook_token")
+	// Tracking
+	ErrTrackingInvalid = errors.New(errors.ErrBadRequest, "invalid_tracking_token")
+	// Pdf attachments
+	ErrPdfAttachmentInvalid = errors.New(errors.ErrBadRequest, "invalid_pdf_attachment")
 	// Folders
-	ErrFolderExist = errors.New(errors.ErrBadRequest, "folder_exist")
+	ErrFolderExist    = errors.New(errors.ErrBadRequest, "folder_exist")
+	ErrFolderNotFound = errors.New(errors.ErrBadRequest, "folder_not_found")
+	ErrFolderCycle    = errors.New(errors.ErrBadRequest, "folder_cycle")
 	// Emails
+	ErrEmailExist    = errors.New(errors.ErrBadRequest, "email_exist")
 	ErrEmailNotFound = errors.New(errors.ErrBadRequest, "email_not_found")
+	// Recipients
+	ErrTooManyRecipients = errors.New(errors.ErrBadRequest, "too_many_recipients")
+	// Email logs
+	ErrStatusTransitionInvalid = errors.New(errors.ErrBadRequest, "invalid_status_transition")
+	// Headers
+	ErrHeaderInvalid  = errors.New(errors.ErrBadRequest, "invalid_header")
+	ErrHeaderReserved = errors.New(errors.ErrBadRequest, "header_reserved")
+	ErrReplyToInvalid = errors.New(errors.ErrBadRequest, "invalid_reply_to")
+	// Variants
+	ErrVariantNotFound = errors.New(errors.ErrBadRequest, "variant_not_found")
+	// Bundles
+	ErrBundleInvalid = errors.New(errors.ErrBadRequest, "invalid_bundle")
+	// Trash
+	ErrTrashExpired       = errors.New(errors.ErrBadRequest, "trash_expired")
+	ErrTrashParentDeleted = errors.New(errors.ErrBadRequest, "parent_deleted")
 )
@@ -5,17 +5,78 @@ import (
 )
 
 type Interface interface {
+	// Senders
+	CreateSender(ctx context.Context, data CreateSenderData) (*SenderResult, error)
+	FilterSenders(ctx context.Context, data FilterSendersData) (*[]SenderResult, error)
+	DeleteSender(ctx context.Context, id uint) error
+	UpdateSender(ctx context.Context, id uint, data map[string]any) error
+	// Domains
+	CreateDomain(ctx context.Context, data CreateDomainData) (*DomainResult, error)
+	FilterDomains(ctx context.Context, data FilterDomainsData) (*[]DomainResult, error)
+	DeleteDomain(ctx context.Context, id uint) error
+	VerifyDomain(ctx context.Context, id uint) (*DomainResult, error)
+	VerifyDomains(ctx context.Context) error
+	CreateDomainKey(ctx context.Context, domainId uint, data CreateDomainKeyData) (*DomainResult, error)
+	ActivateDomainKey(ctx context.Context, domainId uint, id uint) (*DomainResult, error)
+	DeleteDomainKey(ctx context.Context, domainId uint, id uint) error
+	// Files
+	CreateFile(ctx context.Context, data CreateFileData) (*FileResult, error)
+	FilterFiles(ctx context.Context, data FilterFilesData) (*[]FileResult, error)
+	DeleteFile(ctx context.Context, id uint) error
+	// Assets
+	CreateAsset(ctx context.Context, data CreateAssetData) (*AssetResult, error)
+	FilterAssets(ctx context.Context, data FilterAssetsData) (*[]AssetResult, error)
+	DeleteAsset(ctx context.Context, id uint) error
+	GetAssetContent(ctx context.Context, name string) (*AssetContentResult, error)
+	// Unsubscribes
+	GetUnsubscribe(ctx context.Context, token string) (*UnsubscribeResult, error)
+	Unsubscribe(ctx context.Context, token string) (*UnsubscribeResult, error)
+	// Suppressions
+	CreateSuppression(ctx context.Context, data CreateSuppressionData) (*SuppressionResult, error)
+	FilterSuppressions(ctx context.Context, data FilterSuppressionsData) (*[]SuppressionResult, error)
+	DeleteSuppression(ctx context.Context, id uint) error
+	UpdateSuppression(ctx context.Context, id uint, data map[string]any) error
+	ImportSuppressions(ctx context.Context, data ImportSuppressionsData) (*ImportSuppressionsResult, error)
 	// Folders
 	CreateFolder(ctx context.Context, data CreateFolderData) (*FolderResult, error)
 	FilterFolders(ctx context.Context, data FilterFoldersData) (*[]FolderResult, error)
 	DeleteFolder(ctx context.Context, id uint) error
 	UpdateFolder(ctx context.Context, id uint, data map[string]any) error
+	GetFolderTree(ctx context.Context, data FolderTreeData) (*[]FolderTreeResult, error)
+	MoveFolder(ctx context.Context, id uint, data MoveFolderData) error
+	CloneFolder(ctx context.Context, id uint, data CloneFolderData) (*FolderResult, error)
 	// Emails
 	CreateEmail(ctx context.Context, data CreateEmailData) (*EmailResult, error)
 	FilterEmails(ctx context.Context, data FilterEmailsData) (*[]EmailResult, error)
+	SearchEmails(ctx context.Context, data SearchEmailsData) (*[]SearchEmailResult, error)
 	DeleteEmail(ctx context.Context, id uint) error
 	UpdateEmail(ctx context.Context, id uint, data map[string]any) error
-	SendCustom(ctx context.Context, data SendCustomData) (*EmailLogResult, error)
-	Send(ctx context.Context, data SendData) (*EmailLogResult, error)
+	MoveEmails(ctx context.Context, data MoveEmailsData) error
+	CloneEmail(ctx context.Context, id uint, data CloneEmailData) (*EmailResult, error)
+	SendCustom(ctx context.Context, data SendCustomData) (*[]EmailLogResult, error)
+	Send(ctx context.Context, data SendData) (*[]EmailLogResult, error)
+	PreviewEmail(ctx context.Context, data PreviewEmailData) (*PreviewResult, error)
 	FilterEmailLogs(ctx context.Context, data FilterEmailLogsData) (*[]EmailLogResult, error)
+	// Delivery events
+	SmtpBzWebhook(ctx context.Context, token string, body []byte) error
+	ReconcileDeliveries(ctx context.Context) error
+	// Tracking
+	TrackOpen(ctx context.Context, data TrackOpenData) error
+	TrackClick(ctx context.Context, data TrackClickData) (*TrackClickResult, error)
+	GetClickReport(ctx context.Context, emailId uint) (*[]ClickReportResult, error)
+	// Variants
+	CreateVariant(ctx context.Context, data CreateVariantData) (*VariantResult, error)
+	FilterVariants(ctx context.Context, data FilterVariantsData) (*[]VariantResult, error)
+	DeleteVariant(ctx context.Context, id uint) error
+	UpdateVariant(ctx context.Context, id uint, data map[string]any) error
+	GetVariantReport(ctx context.Context, emailId uint) (*[]VariantReportResult, error)
+	EndExperiment(ctx context.Context, emailId uint, data EndExperimentData) error
+	// Bundles
+	ExportBundle(ctx context.Context, data ExportBundleData) (*BundleResult, error)
+	ImportBundle(ctx context.Context, data ImportBundleData) (*[]ImportActionResult, error)
+	// Trash
+	FilterTrash(ctx context.Context) (*TrashResult, error)
+	RestoreFolder(ctx context.Context, id uint) error
+	RestoreEmail(ctx context.Context, id uint) error
+	PurgeTrash(ctx context.Context) error
 }
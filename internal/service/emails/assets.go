@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"mime"
+	"regexp"
+	"slices"
+
+	emailsRepositoryAdapterPort "github.com/flash-go/notifications-service/internal/port/adapter/repository/emails"
+	blobsStorageAdapterPort "github.com/flash-go/notifications-service/internal/port/adapter/storage/blobs"
+	emailsServicePort "github.com/flash-go/notifications-service/internal/port/service/emails"
+)
+
+// Prefix of blob keys of stored assets
+const assetKeyPrefix = "assets/"
+
+// Path of the public route serving assets by name
+const assetsPath = "/notifications/emails/assets/"
+
+// Image types rendered by mail clients, svg is excluded as it may carry scripts
+var assetTypes = []string{
+	"image/gif",
+	"image/jpeg",
+	"image/png",
+	"image/webp",
+}
+
+// Inline references to assets in html (RFC 2392)
+var cidPattern = regexp.MustCompile(`cid:([A-Za-z0-9._-]+)`)
+
+func (s *service) CreateAsset(ctx context.Context, data emailsServicePort.CreateAssetData) (*emailsServicePort.AssetResult, error) {
+	// Check size and type
+	if s.assetsMaxSize > 0 && len(data.Content) > s.assetsMaxSize {
+		return nil, emailsServicePort.ErrAssetTooLarge
+	}
+	mediaType, _, err := mime.ParseMediaType(data.ContentType)
+	if err != nil || !slices.Contains(assetTypes, mediaType) {
+		return nil, emailsServicePort.ErrAssetTypeNotAllowed
+	}
+
+	// Check asset name is free
+	assets, err := s.emailsRepository.FilterAssets(
+		ctx,
+		emailsRepositoryAdapterPort.FilterAssetsData{
+			Name: &[]string{data.Name},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(*assets) > 0 {
+		return nil, emailsServicePort.ErrAssetExist
+	}
+
+	// Store content
+	key, err := newBlobKey(assetKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.blobStorage.Put(ctx, key, data.Content); err != nil {
+		return nil, err
+	}
+
+	// Create asset
+	sum := sha256.Sum256(data.Content)
+	asset, err := s.emailsRepository.CreateAsset(
+		ctx,
+		emailsRepositoryAdapterPort.CreateAssetData{
+			Name:        data.Name,
+			ContentType: mediaType,
+			Size:        int64(len(data.Content)),
+			Sha256:      hex.EncodeToString(sum[:]),
+			StorageKey:  key,
+		},
+	)
+	if err != nil {
+		s.deleteBlob(ctx, key)
+		return nil, err
+	}
+
+	// Map repository to service results
+	results := s.assetResult(*asset)
+
+	return &results, nil
+}
+
+func (s *service) FilterAssets(ctx context.Context, data emailsServicePort.FilterAssetsData) (*[]emailsServicePort.AssetResult, error) {
+	// Filter assets
+	assets, err := s.emailsRepository.FilterAssets(
+		ctx,
+		emailsRepositoryAdapterPort.FilterAssetsData(data),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Map repository to service results
+	results := make([]emailsServicePort.AssetResult, 0, len(*assets))
+	for _, asset := range *assets {
+		results = append(
+			results,
+			s.assetResult(asset),
+		)
+	}
+
+	return &results, nil
+}
+
+func (s *service) DeleteAsset(ctx context.Context, id uint) error {
+	// Get asset
+	assets, err := s.emailsRepository.FilterAssets(
+		ctx,
+		emailsRepositoryAdapterPort.FilterAssetsData{
+			Id: &[]uint{id},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if len(*assets) == 0 {
+		return emailsServicePort.ErrAssetNotFound
+	}
+
+	// Delete asset
+	if err := s.emailsRepository.DeleteAsset(ctx, id); err != nil {
+		return err
+	}
+
+	// Delete content
+	s.deleteBlob(ctx, (*assets)[0].StorageKey)
+
+	return nil
+}
+
+func (s *service) GetAssetContent(ctx context.Context, name string) (*emailsServicePort.AssetContentResult, error) {
+	// Get asset
+	assets, err := s.emailsRepository.FilterAssets(
+		ctx,
+		emailsRepositoryAdapterPort.FilterAssetsData{
+			Name: &[]string{name},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(*assets) == 0 {
+		return nil, emailsServicePort.ErrAssetNotFound
+	}
+	asset := (*assets)[0]
+
+	// Load content
+	content, err := s.assetContent(ctx, asset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &emailsServicePort.AssetContentResult{
+		ContentType: asset.ContentType,
+		Content:     content,
+	}, nil
+}
+
+// Load assets referenced from html as cid:name to embed them as inline parts
+func (s *service) inlineAssets(ctx context.Context, html string) ([]emailsRepositoryAdapterPort.InlineData, error) {
+	// Collect referenced names
+	var names []string
+	for _, match := range cidPattern.FindAllStringSubmatch(html, -1) {
+		if !slices.Contains(names, match[1]) {
+			names = append(names, match[1])
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	// Get assets
+	assets, err := s.emailsRepository.FilterAssets(
+		ctx,
+		emailsRepositoryAdapterPort.FilterAssetsData{
+			Name: &names,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(*assets) != len(names) {
+		return nil, emailsServicePort.ErrAssetNotFound
+	}
+
+	// Load contents
+	results := make([]emailsRepositoryAdapterPort.InlineData, 0, len(*assets))
+	for _, asset := range *assets {
+		content, err := s.assetContent(ctx, asset)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, emailsRepositoryAdapterPort.InlineData{
+			ContentId:   asset.Name,
+			ContentType: asset.ContentType,
+			Content:     content,
+		})
+	}
+	return results, nil
+}
+
+func (s *service) assetContent(ctx context.Context, asset emailsRepositoryAdapterPort.AssetResult) ([]byte, error) {
+	content, err := s.blobStorage.Get(ctx, asset.StorageKey)
+	if errors.Is(err, blobsStorageAdapterPort.ErrBlobNotFound) {
+		return nil, emailsServicePort.ErrAssetNotFound
+	}
+	return content, err
+}
+
+// Public url of the asset, relative if the base url is not set
+func (s *service) assetResult(asset emailsRepositoryAdapterPort.AssetResult) emailsServicePort.AssetResult {
+	return emailsServicePort.AssetResult{
+		Id:          asset.Id,
+		Name:        asset.Name,
+		ContentType: asset.ContentType,
+		Size:        asset.Size,
+		Sha256:      asset.Sha256,
+		Url:         s.assetsBaseUrl + assetsPath + asset.Name,
+		Created:     asset.Created,
+	}
+}
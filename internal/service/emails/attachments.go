@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"mime"
+	"slices"
+	"strings"
+
+	emailsRepositoryAdapterPort "github.com/flash-go/notifications-service/internal/port/adapter/repository/emails"
+	blobsStorageAdapterPort "github.com/flash-go/notifications-service/internal/port/adapter/storage/blobs"
+	emailsServicePort "github.com/flash-go/notifications-service/internal/port/service/emails"
+)
+
+// Load referenced files and check total size and types of attachments
+func (s *service) resolveAttachments(ctx context.Context, items []emailsServicePort.AttachmentData) ([]emailsRepositoryAdapterPort.AttachmentData, error) {
+	results := make([]emailsRepositoryAdapterPort.AttachmentData, 0, len(items))
+	total := 0
+	for _, item := range items {
+		attachment := emailsRepositoryAdapterPort.AttachmentData{
+			Filename:    item.Filename,
+			ContentType: item.ContentType,
+			Content:     item.Content,
+		}
+
+		// Load stored file, the request may override its name and type
+		if item.FileId != nil {
+			file, err := s.getFile(ctx, *item.FileId)
+			if err != nil {
+				return nil, err
+			}
+			content, err := s.blobStorage.Get(ctx, file.StorageKey)
+			if errors.Is(err, blobsStorageAdapterPort.ErrBlobNotFound) {
+				return nil, emailsServicePort.ErrFileNotFound
+			}
+			if err != nil {
+				return nil, err
+			}
+			attachment.Content = content
+			if attachment.Filename == "" {
+				attachment.Filename = file.Name
+			}
+			if attachment.ContentType == "" {
+				attachment.ContentType = file.ContentType
+			}
+		}
+
+		// Check type
+		contentType, err := s.checkAttachmentType(attachment.ContentType)
+		if err != nil {
+			return nil, err
+		}
+		attachment.ContentType = contentType
+
+		// Check total size
+		total += len(attachment.Content)
+		if s.attachmentsMaxSize > 0 && total > s.attachmentsMaxSize {
+			return nil, emailsServicePort.ErrAttachmentsTooLarge
+		}
+
+		results = append(results, attachment)
+	}
+	return results, nil
+}
+
+// Check total size of attachments of a message
+func (s *service) checkAttachmentsSize(attachments []emailsRepositoryAdapterPort.AttachmentData) error {
+	total := 0
+	for _, attachment := range attachments {
+		total += len(attachment.Content)
+	}
+	if s.attachmentsMaxSize > 0 && total > s.attachmentsMaxSize {
+		return emailsServicePort.ErrAttachmentsTooLarge
+	}
+	return nil
+}
+
+// Normalize content type and check it against allowed types, "type/*" allows any subtype
+func (s *service) checkAttachmentType(contentType string) (string, error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", emailsServicePort.ErrAttachmentTypeNotAllowed
+	}
+	if len(s.attachmentTypes) > 0 && !slices.ContainsFunc(s.attachmentTypes, func(allowed string) bool {
+		return allowed == mediaType || strings.HasSuffix(allowed, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(allowed, "*"))
+	}) {
+		return "", emailsServicePort.ErrAttachmentTypeNotAllowed
+	}
+	return mime.FormatMediaType(mediaType, params), nil
+}
+
+// Parse comma separated list of allowed types
+func attachmentTypes(list string) []string {
+	var results []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.ToLower(strings.TrimSpace(item)); item != "" {
+			results = append(results, item)
+		}
+	}
+	return results
+}
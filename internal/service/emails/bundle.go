@@ -0,0 +1,880 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	emailsRepositoryAdapterPort "github.com/flash-go/notifications-service/internal/port/adapter/repository/emails"
+	emailsServicePort "github.com/flash-go/notifications-service/internal/port/service/emails"
+)
+
+const (
+	// Name of the bundle document inside zip archives
+	bundleZipEntry = "bundle.json"
+
+	// Import entities
+	importEntityFolder = "folder"
+	importEntityEmail  = "email"
+
+	// Import actions
+	importActionCreate    = "create"
+	importActionUpdate    = "update"
+	importActionUnchanged = "unchanged"
+	importActionSkip      = "skip"
+	importActionRename    = "rename"
+	importActionDelete    = "delete"
+)
+
+func (s *service) ExportBundle(ctx context.Context, data emailsServicePort.ExportBundleData) (*emailsServicePort.BundleResult, error) {
+	// Get folders
+	folders, err := s.emailsRepository.FilterFolders(
+		ctx,
+		emailsRepositoryAdapterPort.FilterFoldersData{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Index folders
+	byId := make(map[uint]emailsRepositoryAdapterPort.FolderResult, len(*folders))
+	children := make(map[uint][]emailsRepositoryAdapterPort.FolderResult)
+	for _, folder := range *folders {
+		byId[folder.Id] = folder
+		children[parentKey(folder.ParentId)] = append(children[parentKey(folder.ParentId)], folder)
+	}
+
+	// Set subtree roots
+	var roots []emailsRepositoryAdapterPort.FolderResult
+	if data.FolderId != nil {
+		folder, ok := byId[*data.FolderId]
+		if !ok {
+			return nil, emailsServicePort.ErrFolderNotFound
+		}
+		roots = []emailsRepositoryAdapterPort.FolderResult{folder}
+	} else {
+		roots = children[0]
+	}
+
+	// Create bundle
+	bundle := emailsServicePort.Bundle{
+		Version:  emailsServicePort.BundleVersion,
+		Exported: time.Now().UTC(),
+		Folders:  []emailsServicePort.BundleFolder{},
+		Emails:   []emailsServicePort.BundleEmail{},
+	}
+
+	// Walk folders tree
+	paths := make(map[uint][]string)
+	folderIds := []*uint{}
+	if data.FolderId == nil {
+		folderIds = append(folderIds, nil)
+	}
+	var walk func(items []emailsRepositoryAdapterPort.FolderResult, parent []string)
+	walk = func(items []emailsRepositoryAdapterPort.FolderResult, parent []string) {
+		sort.Slice(items, func(i, j int) bool { return items[i].Name < items[j].Name })
+		for _, folder := range items {
+			path := append(slices.Clone(parent), folder.Name)
+			paths[folder.Id] = path
+			folderIds = append(folderIds, &folder.Id)
+			bundle.Folders = append(
+				bundle.Folders,
+				emailsServicePort.BundleFolder{
+					Path:        path,
+					Description: folder.Description,
+					SystemFlag:  folder.SystemFlag,
+				},
+			)
+			walk(children[folder.Id], path)
+		}
+	}
+	walk(roots, nil)
+
+	// Get emails
+	emails, err := s.emailsRepository.FilterEmails(
+		ctx,
+		emailsRepositoryAdapterPort.FilterEmailsData{
+			FolderId: &folderIds,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(*emails, func(i, j int) bool { return (*emails)[i].Key < (*emails)[j].Key })
+
+	// Get variants
+	variants := make(map[uint][]emailsServicePort.BundleVariant)
+	if len(*emails) > 0 {
+		ids := make([]uint, len(*emails))
+		for i, email := range *emails {
+			ids[i] = email.Id
+		}
+		items, err := s.emailsRepository.FilterVariants(
+			ctx,
+			emailsRepositoryAdapterPort.FilterVariantsData{
+				EmailId: &ids,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range *items {
+			variants[item.EmailId] = append(
+				variants[item.EmailId],
+				emailsServicePort.BundleVariant{
+					Name:    item.Name,
+					Subject: item.Subject,
+					Html:    item.Html,
+					Text:    item.Text,
+					Weight:  item.Weight,
+					Active:  item.Active,
+				},
+			)
+		}
+	}
+
+	// Get senders
+	senders, err := s.emailsRepository.FilterSenders(
+		ctx,
+		emailsRepositoryAdapterPort.FilterSendersData{},
+	)
+	if err != nil {
+		return nil, err
+	}
+	sendersById := make(map[uint]emailsRepositoryAdapterPort.SenderResult, len(*senders))
+	for _, sender := range *senders {
+		sendersById[sender.Id] = sender
+	}
+
+	// Add emails to bundle
+	for _, email := range *emails {
+		var folder []string
+		if email.FolderId != nil {
+			folder = paths[*email.FolderId]
+		}
+		var sender emailsRepositoryAdapterPort.SenderResult
+		if email.SenderId != nil {
+			sender = sendersById[*email.SenderId]
+		}
+		bundle.Emails = append(
+			bundle.Emails,
+			emailsServicePort.BundleEmail{
+				Key:            email.Key,
+				Folder:         folder,
+				FromEmail:      sender.Email,
+				FromName:       sender.Name,
+				Subject:        email.Subject,
+				Html:           email.Html,
+				Text:           email.Text,
+				ReplyTo:        email.ReplyTo,
+				Headers:        email.Headers,
+				PdfAttachments: email.PdfAttachments,
+				Category:       email.Category,
+				TrackOpens:     email.TrackOpens,
+				TrackClicks:    email.TrackClicks,
+				Description:    email.Description,
+				SystemFlag:     email.SystemFlag,
+				Variants:       variants[email.Id],
+			},
+		)
+	}
+
+	// Encode bundle
+	result, err := encodeBundle(data.Format, &bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	return &emailsServicePort.BundleResult{
+		Format: data.Format,
+		Data:   result,
+	}, nil
+}
+
+func (s *service) ImportBundle(ctx context.Context, data emailsServicePort.ImportBundleData) (*[]emailsServicePort.ImportActionResult, error) {
+	// Decode bundle
+	bundle, err := decodeBundle(data.Format, data.Bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get folders
+	folders, err := s.emailsRepository.FilterFolders(
+		ctx,
+		emailsRepositoryAdapterPort.FilterFoldersData{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Index folders by id, parent and name
+	byId := make(map[uint]emailsRepositoryAdapterPort.FolderResult, len(*folders))
+	children := make(map[uint]map[string]emailsRepositoryAdapterPort.FolderResult)
+	parentExist := data.ParentId == nil
+	for _, folder := range *folders {
+		byId[folder.Id] = folder
+		key := parentKey(folder.ParentId)
+		if children[key] == nil {
+			children[key] = make(map[string]emailsRepositoryAdapterPort.FolderResult)
+		}
+		children[key][folder.Name] = folder
+		if data.ParentId != nil && folder.Id == *data.ParentId {
+			parentExist = true
+		}
+	}
+	if !parentExist {
+		return nil, emailsServicePort.ErrFolderNotFound
+	}
+
+	// Create plan
+	actions := []emailsServicePort.ImportActionResult{}
+	ops := emailsRepositoryAdapterPort.ImportBundleData{
+		ParentId: data.ParentId,
+	}
+
+	// Plan folders
+	type resolvedFolder struct {
+		id     *uint
+		path   []string
+		system bool
+	}
+	resolved := make(map[string]resolvedFolder)
+	taken := make(map[uint]map[string]bool)
+	for _, item := range bundle.Folders {
+		name := item.Path[len(item.Path)-1]
+
+		// Resolve parent folder
+		parentId := data.ParentId
+		parentNew := false
+		var parentPath []string
+		if len(item.Path) > 1 {
+			parent, ok := resolved[strings.Join(item.Path[:len(item.Path)-1], "\x00")]
+			if !ok {
+				return nil, emailsServicePort.ErrBundleInvalid
+			}
+			parentId = parent.id
+			parentNew = parent.id == nil
+			parentPath = parent.path
+		}
+
+		// Find existing folder
+		var existing *emailsRepositoryAdapterPort.FolderResult
+		if !parentNew {
+			if folder, ok := children[parentKey(parentId)][name]; ok {
+				existing = &folder
+			}
+		}
+
+		op := emailsRepositoryAdapterPort.ImportFolderData{
+			Path:        append(slices.Clone(parentPath), name),
+			Name:        name,
+			Description: item.Description,
+			SystemFlag:  item.SystemFlag,
+		}
+		action := emailsServicePort.ImportActionResult{
+			Entity: importEntityFolder,
+			Key:    strings.Join(item.Path, "/"),
+		}
+
+		system := item.SystemFlag
+		switch {
+		case existing == nil:
+			if err := checkSystemAccess(ctx, item.SystemFlag); err != nil {
+				return nil, err
+			}
+			action.Action = importActionCreate
+		case data.Strategy == emailsServicePort.ImportStrategySkip:
+			op.Id = &existing.Id
+			system = existing.SystemFlag
+			action.Action = importActionSkip
+		case data.Strategy == emailsServicePort.ImportStrategyOverwrite:
+			op.Id = &existing.Id
+			if existing.Description != item.Description {
+				action.Changes = append(action.Changes, "description")
+			}
+			if existing.SystemFlag != item.SystemFlag {
+				action.Changes = append(action.Changes, "system_flag")
+			}
+			if len(action.Changes) > 0 {
+				if err := checkSystemAccess(ctx, existing.SystemFlag || item.SystemFlag); err != nil {
+					return nil, err
+				}
+				op.Update = true
+				action.Action = importActionUpdate
+			} else {
+				action.Action = importActionUnchanged
+			}
+		case data.Strategy == emailsServicePort.ImportStrategyRename:
+			if err := checkSystemAccess(ctx, item.SystemFlag); err != nil {
+				return nil, err
+			}
+
+			// Find free folder name
+			key := parentKey(parentId)
+			if taken[key] == nil {
+				taken[key] = make(map[string]bool)
+			}
+			for i := 2; ; i++ {
+				candidate := fmt.Sprintf("%s (%d)", name, i)
+				if _, ok := children[key][candidate]; !ok && !taken[key][candidate] {
+					op.Name = candidate
+					break
+				}
+			}
+			taken[key][op.Name] = true
+			op.Path = append(slices.Clone(parentPath), op.Name)
+			target := strings.Join(op.Path, "/")
+			action.Action = importActionRename
+			action.Target = &target
+		}
+
+		resolved[strings.Join(item.Path, "\x00")] = resolvedFolder{op.Id, op.Path, system}
+		ops.Folders = append(ops.Folders, op)
+		actions = append(actions, action)
+	}
+
+	// Get existing emails
+	keys := make([]string, len(bundle.Emails))
+	for i, item := range bundle.Emails {
+		keys[i] = item.Key
+	}
+	existingEmails := make(map[string]emailsRepositoryAdapterPort.EmailResult)
+	existingVariants := make(map[uint]map[string]emailsRepositoryAdapterPort.VariantResult)
+	if len(keys) > 0 {
+		emails, err := s.emailsRepository.FilterEmails(
+			ctx,
+			emailsRepositoryAdapterPort.FilterEmailsData{
+				Key: &keys,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		ids := make([]uint, 0, len(*emails))
+		for _, email := range *emails {
+			existingEmails[email.Key] = email
+			ids = append(ids, email.Id)
+		}
+
+		// Get existing variants
+		if len(ids) > 0 {
+			variants, err := s.emailsRepository.FilterVariants(
+				ctx,
+				emailsRepositoryAdapterPort.FilterVariantsData{
+					EmailId: &ids,
+				},
+			)
+			if err != nil {
+				return nil, err
+			}
+			for _, variant := range *variants {
+				if existingVariants[variant.EmailId] == nil {
+					existingVariants[variant.EmailId] = make(map[string]emailsRepositoryAdapterPort.VariantResult)
+				}
+				existingVariants[variant.EmailId][variant.Name] = variant
+			}
+		}
+	}
+
+	// Get senders
+	senders, err := s.emailsRepository.FilterSenders(
+		ctx,
+		emailsRepositoryAdapterPort.FilterSendersData{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Plan emails
+	usedKeys := make(map[string]bool)
+	for _, item := range bundle.Emails {
+		// Resolve sender
+		senderId, err := bundleSender(*senders, item.FromEmail, item.FromName)
+		if err != nil {
+			return nil, err
+		}
+
+		// Resolve folder
+		folderId := data.ParentId
+		folderSystem := isSystemFolder(byId, data.ParentId)
+		var folderPath []string
+		if len(item.Folder) > 0 {
+			folder, ok := resolved[strings.Join(item.Folder, "\x00")]
+			if !ok {
+				return nil, emailsServicePort.ErrBundleInvalid
+			}
+			folderId = folder.id
+			folderPath = folder.path
+			folderSystem = folder.system
+		}
+
+		op := emailsRepositoryAdapterPort.ImportEmailData{
+			FolderPath:     folderPath,
+			Key:            item.Key,
+			SenderId:       senderId,
+			Subject:        item.Subject,
+			Html:           item.Html,
+			Text:           item.Text,
+			ReplyTo:        item.ReplyTo,
+			Headers:        item.Headers,
+			PdfAttachments: item.PdfAttachments,
+			Category:       item.Category,
+			TrackOpens:     item.TrackOpens,
+			TrackClicks:    item.TrackClicks,
+			Description:    item.Description,
+			SystemFlag:     item.SystemFlag,
+		}
+		action := emailsServicePort.ImportActionResult{
+			Entity: importEntityEmail,
+			Key:    item.Key,
+		}
+
+		existing, exist := existingEmails[item.Key]
+
+		switch {
+		case !exist:
+			if err := checkSystemAccess(ctx, item.SystemFlag); err != nil {
+				return nil, err
+			}
+			action.Action = importActionCreate
+			op.Variants = newImportVariants(item.Variants)
+		case data.Strategy == emailsServicePort.ImportStrategySkip:
+			op.Id = &existing.Id
+			action.Action = importActionSkip
+		case data.Strategy == emailsServicePort.ImportStrategyOverwrite:
+			op.Id = &existing.Id
+			if folderNew := len(folderPath) > 0 && folderId == nil; folderNew || !sameId(folderId, existing.FolderId) {
+				// Keep system emails in system folders
+				if existing.SystemFlag && isSystemFolder(byId, existing.FolderId) && !folderSystem {
+					return nil, emailsServicePort.ErrSystemEntity
+				}
+				action.Changes = append(action.Changes, "folder")
+			}
+			action.Changes = append(action.Changes, emailChanges(existing, item, senderId)...)
+
+			// Plan variants
+			for _, variant := range item.Variants {
+				current, ok := existingVariants[existing.Id][variant.Name]
+				if !ok {
+					op.Variants = append(op.Variants, newImportVariants([]emailsServicePort.BundleVariant{variant})...)
+					continue
+				}
+				if variantChanged(current, variant) {
+					op.Variants = append(
+						op.Variants,
+						emailsRepositoryAdapterPort.ImportVariantData{
+							Id:      &current.Id,
+							Name:    variant.Name,
+							Subject: variant.Subject,
+							Html:    variant.Html,
+							Text:    variant.Text,
+							Weight:  variant.Weight,
+							Active:  variant.Active,
+							Update:  true,
+						},
+					)
+				}
+			}
+
+			// Prune variants missing from bundle
+			pruned := false
+			if data.Prune {
+				for name, current := range existingVariants[existing.Id] {
+					if !slices.ContainsFunc(item.Variants, func(v emailsServicePort.BundleVariant) bool { return v.Name == name }) {
+						ops.DeleteVariants = append(ops.DeleteVariants, current.Id)
+						pruned = true
+					}
+				}
+			}
+			if len(op.Variants) > 0 || pruned {
+				action.Changes = append(action.Changes, "variants")
+			}
+
+			if len(action.Changes) > 0 {
+				// Moves between system folders do not edit content
+				if slices.ContainsFunc(action.Changes, func(change string) bool { return change != "folder" }) {
+					if err := checkSystemAccess(ctx, existing.SystemFlag || item.SystemFlag); err != nil {
+						return nil, err
+					}
+				}
+				op.Update = true
+				action.Action = importActionUpdate
+			} else {
+				action.Action = importActionUnchanged
+			}
+		case data.Strategy == emailsServicePort.ImportStrategyRename:
+			if err := checkSystemAccess(ctx, item.SystemFlag); err != nil {
+				return nil, err
+			}
+			key, err := s.freeEmailKey(ctx, item.Key, usedKeys)
+			if err != nil {
+				return nil, err
+			}
+			op.Key = key
+			op.Variants = newImportVariants(item.Variants)
+			action.Action = importActionRename
+			action.Target = &key
+		}
+
+		usedKeys[op.Key] = true
+		ops.Emails = append(ops.Emails, op)
+		actions = append(actions, action)
+	}
+
+	// Plan pruning
+	if data.Prune {
+		pruned, err := s.planPrune(ctx, data.ParentId, *folders, &ops)
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, pruned...)
+	}
+
+	// Apply plan
+	if !data.DryRun {
+		if err := s.emailsRepository.ImportBundle(ctx, ops); err != nil {
+			return nil, err
+		}
+	}
+
+	return &actions, nil
+}
+
+// Plan deletion of folders and emails under the parent that the plan does not keep
+func (s *service) planPrune(ctx context.Context, parentId *uint, folders []emailsRepositoryAdapterPort.FolderResult, ops *emailsRepositoryAdapterPort.ImportBundleData) ([]emailsServicePort.ImportActionResult, error) {
+	// Index folders by parent
+	children := make(map[uint][]emailsRepositoryAdapterPort.FolderResult)
+	for _, folder := range folders {
+		children[parentKey(folder.ParentId)] = append(children[parentKey(folder.ParentId)], folder)
+	}
+
+	// Collect kept entities
+	keptFolders := make(map[uint]bool)
+	for _, item := range ops.Folders {
+		if item.Id != nil {
+			keptFolders[*item.Id] = true
+		}
+	}
+	keptEmails := make(map[uint]bool)
+	for _, item := range ops.Emails {
+		if item.Id != nil {
+			keptEmails[*item.Id] = true
+		}
+	}
+
+	// Walk folders under parent
+	var actions []emailsServicePort.ImportActionResult
+	folderIds := []*uint{parentId}
+	system := false
+	var walk func(id *uint, parent []string)
+	walk = func(id *uint, parent []string) {
+		items := children[parentKey(id)]
+		sort.Slice(items, func(i, j int) bool { return items[i].Name < items[j].Name })
+		for _, folder := range items {
+			path := append(slices.Clone(parent), folder.Name)
+			folderIds = append(folderIds, &folder.Id)
+			if !keptFolders[folder.Id] {
+				system = system || folder.SystemFlag
+				ops.DeleteFolders = append(ops.DeleteFolders, folder.Id)
+				actions = append(
+					actions,
+					emailsServicePort.ImportActionResult{
+						Entity: importEntityFolder,
+						Key:    strings.Join(path, "/"),
+						Action: importActionDelete,
+					},
+				)
+			}
+			walk(&folder.Id, path)
+		}
+	}
+	walk(parentId, nil)
+	if system {
+		return nil, emailsServicePort.ErrSystemEntity
+	}
+
+	// Get emails under parent
+	emails, err := s.emailsRepository.FilterEmails(
+		ctx,
+		emailsRepositoryAdapterPort.FilterEmailsData{
+			FolderId: &folderIds,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(*emails, func(i, j int) bool { return (*emails)[i].Key < (*emails)[j].Key })
+
+	// Delete emails before their folders
+	var emailActions []emailsServicePort.ImportActionResult
+	for _, email := range *emails {
+		if keptEmails[email.Id] {
+			continue
+		}
+		if email.SystemFlag {
+			return nil, emailsServicePort.ErrSystemEntity
+		}
+		ops.DeleteEmails = append(ops.DeleteEmails, email.Id)
+		emailActions = append(
+			emailActions,
+			emailsServicePort.ImportActionResult{
+				Entity: importEntityEmail,
+				Key:    email.Key,
+				Action: importActionDelete,
+			},
+		)
+	}
+
+	return append(emailActions, actions...), nil
+}
+
+// Find a free email key by appending a numeric suffix
+func (s *service) freeEmailKey(ctx context.Context, key string, used map[string]bool) (string, error) {
+	const batch = 20
+	for start := 2; ; start += batch {
+		// Create candidates
+		candidates := make([]string, 0, batch)
+		for i := start; i < start+batch; i++ {
+			candidates = append(candidates, fmt.Sprintf("%s_%d", key, i))
+		}
+
+		// Get taken candidates
+		emails, err := s.emailsRepository.FilterEmails(
+			ctx,
+			emailsRepositoryAdapterPort.FilterEmailsData{
+				Key: &candidates,
+			},
+		)
+		if err != nil {
+			return "", err
+		}
+		taken := make(map[string]bool, len(*emails))
+		for _, email := range *emails {
+			taken[email.Key] = true
+		}
+
+		// Pick first free candidate
+		for _, candidate := range candidates {
+			if !taken[candidate] && !used[candidate] {
+				return candidate, nil
+			}
+		}
+	}
+}
+
+func newImportVariants(variants []emailsServicePort.BundleVariant) []emailsRepositoryAdapterPort.ImportVariantData {
+	results := make([]emailsRepositoryAdapterPort.ImportVariantData, 0, len(variants))
+	for _, variant := range variants {
+		results = append(
+			results,
+			emailsRepositoryAdapterPort.ImportVariantData{
+				Name:    variant.Name,
+				Subject: variant.Subject,
+				Html:    variant.Html,
+				Text:    variant.Text,
+				Weight:  variant.Weight,
+				Active:  variant.Active,
+			},
+		)
+	}
+	return results
+}
+
+// Find the approved sender of a bundle email, emails without sender use the folder default
+func bundleSender(senders []emailsRepositoryAdapterPort.SenderResult, email, name string) (*uint, error) {
+	if email == "" {
+		return nil, nil
+	}
+	for _, sender := range senders {
+		if sender.Email == email && (name == "" || sender.Name == name) {
+			return &sender.Id, nil
+		}
+	}
+	return nil, emailsServicePort.ErrSenderNotFound
+}
+
+func emailChanges(existing emailsRepositoryAdapterPort.EmailResult, item emailsServicePort.BundleEmail, senderId *uint) []string {
+	var changes []string
+	if !sameId(existing.SenderId, senderId) {
+		changes = append(changes, "sender")
+	}
+	if existing.Subject != item.Subject {
+		changes = append(changes, "subject")
+	}
+	if existing.Html != item.Html {
+		changes = append(changes, "html")
+	}
+	if existing.Text != item.Text {
+		changes = append(changes, "text")
+	}
+	if !sameString(existing.ReplyTo, item.ReplyTo) {
+		changes = append(changes, "reply_to")
+	}
+	if !maps.Equal(existing.Headers, item.Headers) {
+		changes = append(changes, "headers")
+	}
+	if !maps.Equal(existing.PdfAttachments, item.PdfAttachments) {
+		changes = append(changes, "pdf_attachments")
+	}
+	if !sameString(existing.Category, item.Category) {
+		changes = append(changes, "category")
+	}
+	if existing.TrackOpens != item.TrackOpens {
+		changes = append(changes, "track_opens")
+	}
+	if existing.TrackClicks != item.TrackClicks {
+		changes = append(changes, "track_clicks")
+	}
+	if existing.Description != item.Description {
+		changes = append(changes, "description")
+	}
+	if existing.SystemFlag != item.SystemFlag {
+		changes = append(changes, "system_flag")
+	}
+	return changes
+}
+
+func variantChanged(existing emailsRepositoryAdapterPort.VariantResult, item emailsServicePort.BundleVariant) bool {
+	equal := func(a, b *string) bool {
+		return a == nil && b == nil || a != nil && b != nil && *a == *b
+	}
+	return !equal(existing.Subject, item.Subject) ||
+		!equal(existing.Html, item.Html) ||
+		!equal(existing.Text, item.Text) ||
+		existing.Weight != item.Weight ||
+		existing.Active != item.Active
+}
+
+func encodeBundle(format string, bundle *emailsServicePort.Bundle) ([]byte, error) {
+	// Encode bundle to json
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if format != emailsServicePort.BundleFormatZip {
+		return data, nil
+	}
+
+	// Pack json to zip archive
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	file, err := archive.Create(bundleZipEntry)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := file.Write(data); err != nil {
+		return nil, err
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func decodeBundle(format string, data []byte) (*emailsServicePort.Bundle, error) {
+	// Unpack json from zip archive
+	if format == emailsServicePort.BundleFormatZip {
+		archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, emailsServicePort.ErrBundleInvalid
+		}
+		file, err := archive.Open(bundleZipEntry)
+		if err != nil {
+			return nil, emailsServicePort.ErrBundleInvalid
+		}
+		defer file.Close()
+		if data, err = io.ReadAll(file); err != nil {
+			return nil, emailsServicePort.ErrBundleInvalid
+		}
+	}
+
+	// Decode json
+	var bundle emailsServicePort.Bundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, emailsServicePort.ErrBundleInvalid
+	}
+
+	// Validate bundle
+	if err := validateBundle(&bundle); err != nil {
+		return nil, err
+	}
+
+	return &bundle, nil
+}
+
+func validateBundle(bundle *emailsServicePort.Bundle) error {
+	if bundle.Version != emailsServicePort.BundleVersion {
+		return emailsServicePort.ErrBundleInvalid
+	}
+
+	// Validate folders
+	paths := make(map[string]bool, len(bundle.Folders))
+	for _, folder := range bundle.Folders {
+		if len(folder.Path) == 0 || slices.Contains(folder.Path, "") {
+			return emailsServicePort.ErrBundleInvalid
+		}
+		key := strings.Join(folder.Path, "\x00")
+		if paths[key] {
+			return emailsServicePort.ErrBundleInvalid
+		}
+		paths[key] = true
+	}
+
+	// Order parents before children
+	sort.SliceStable(bundle.Folders, func(i, j int) bool {
+		return len(bundle.Folders[i].Path) < len(bundle.Folders[j].Path)
+	})
+
+	// Validate emails
+	keys := make(map[string]bool, len(bundle.Emails))
+	for i, email := range bundle.Emails {
+		if email.Key == "" || keys[email.Key] || email.Subject == "" || email.Html == "" {
+			return emailsServicePort.ErrBundleInvalid
+		}
+		keys[email.Key] = true
+
+		headers, err := normalizeHeaders(email.Headers)
+		if err != nil || email.ReplyTo != nil && *email.ReplyTo == "" || email.Category != nil && *email.Category == "" {
+			return emailsServicePort.ErrBundleInvalid
+		}
+		bundle.Emails[i].Headers = headers
+		if err := checkPdfAttachments(email.PdfAttachments); err != nil {
+			return emailsServicePort.ErrBundleInvalid
+		}
+
+		names := make(map[string]bool, len(email.Variants))
+		for _, variant := range email.Variants {
+			if variant.Name == "" || names[variant.Name] || variant.Weight < 1 {
+				return emailsServicePort.ErrBundleInvalid
+			}
+			names[variant.Name] = true
+		}
+	}
+
+	return nil
+}
+
+func sameId(a, b *uint) bool {
+	return a == nil && b == nil || a != nil && b != nil && *a == *b
+}
+
+func sameString(a, b *string) bool {
+	return a == nil && b == nil || a != nil && b != nil && *a == *b
+}
+
+// Map key for root level folders is zero
+func parentKey(id *uint) uint {
+	if id == nil {
+		return 0
+	}
+	return *id
+}
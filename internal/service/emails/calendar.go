@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	emailsRepositoryAdapterPort "github.com/flash-go/notifications-service/internal/port/adapter/repository/emails"
+	emailsServicePort "github.com/flash-go/notifications-service/internal/port/service/emails"
+)
+
+// Calendar methods (RFC 5546, 1.4)
+const (
+	calendarMethodRequest = "REQUEST"
+	calendarMethodCancel  = "CANCEL"
+)
+
+// Product identifier of generated calendar objects
+const calendarProdId = "-//flash-go//notifications-service//EN"
+
+// Calendar event of a send request with its sequence
+type calendarEvent struct {
+	emailsServicePort.EventData
+	Sequence uint
+}
+
+// Set the event UID and increase its sequence, a new UID is generated for new events
+func (s *service) prepareEvent(ctx context.Context, event emailsServicePort.EventData, senderEmail string) (*calendarEvent, error) {
+	if event.Uid == "" {
+		id := make([]byte, 16)
+		if _, err := rand.Read(id); err != nil {
+			return nil, err
+		}
+		event.Uid = hex.EncodeToString(id) + "@" + strings.ToLower(senderEmail[strings.LastIndex(senderEmail, "@")+1:])
+	}
+	if event.Method == "" {
+		event.Method = calendarMethodRequest
+	}
+
+	// Updates and cancellations of the UID must have a greater sequence
+	sequence, err := s.emailsRepository.NextEventSequence(
+		ctx,
+		emailsRepositoryAdapterPort.EventSequenceData{
+			Uid:    event.Uid,
+			Method: event.Method,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &calendarEvent{EventData: event, Sequence: sequence}, nil
+}
+
+// Build iCalendar object of the event for the recipients of the message (RFC 5545)
+func (e calendarEvent) build(subject string, sender emailsRepositoryAdapterPort.SenderResult, message recipientSet, now time.Time) *emailsRepositoryAdapterPort.CalendarData {
+	var body strings.Builder
+	line := func(content string) {
+		body.WriteString(foldCalendarLine(content))
+	}
+
+	// Event summary defaults to the rendered subject
+	summary := e.Summary
+	if summary == "" {
+		summary = subject
+	}
+
+	// Event organizer defaults to the sender
+	organizer := &mail.Address{Name: sender.Name, Address: sender.Email}
+	if e.Organizer != "" {
+		if address, err := mail.ParseAddress(e.Organizer); err == nil {
+			organizer = address
+		}
+	}
+
+	// Cancelled events keep the UID and are shown as cancelled
+	status := "CONFIRMED"
+	if e.Method == calendarMethodCancel {
+		status = "CANCELLED"
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("PRODID:" + calendarProdId)
+	line("VERSION:2.0")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:" + e.Method)
+	line("BEGIN:VEVENT")
+	line("UID:" + e.Uid)
+	line("DTSTAMP:" + calendarTime(now))
+	line("SEQUENCE:" + strconv.FormatUint(uint64(e.Sequence), 10))
+	line("DTSTART:" + calendarTime(e.Start))
+	line("DTEND:" + calendarTime(e.End))
+	line("SUMMARY:" + calendarText(summary))
+	if e.Description != "" {
+		line("DESCRIPTION:" + calendarText(e.Description))
+	}
+	if e.Location != "" {
+		line("LOCATION:" + calendarText(e.Location))
+	}
+	line("ORGANIZER" + calendarName(organizer.Name) + ":mailto:" + organizer.Address)
+	for _, list := range []struct {
+		Role       string
+		Recipients []string
+	}{
+		{"REQ-PARTICIPANT", message.To},
+		{"OPT-PARTICIPANT", message.Cc},
+	} {
+		for _, recipient := range list.Recipients {
+			address, err := mail.ParseAddress(recipient)
+			if err != nil {
+				continue
+			}
+			line("ATTENDEE" + calendarName(address.Name) + ";ROLE=" + list.Role + ";PARTSTAT=NEEDS-ACTION;RSVP=TRUE:mailto:" + address.Address)
+		}
+	}
+	line("STATUS:" + status)
+	line("END:VEVENT")
+	line("END:VCALENDAR")
+
+	return &emailsRepositoryAdapterPort.CalendarData{
+		Uid:     e.Uid,
+		Method:  e.Method,
+		Content: []byte(body.String()),
+	}
+}
+
+// Date-time in UTC form (RFC 5545, 3.3.5)
+func calendarTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// Escape TEXT value (RFC 5545, 3.3.11)
+func calendarText(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(value)
+}
+
+// Common name parameter, quotes and control characters are not allowed in quoted values (RFC 5545, 3.1)
+func calendarName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r == '"' || r < 32 || r == 127 {
+			return -1
+		}
+		return r
+	}, name)
+	if name == "" {
+		return ""
+	}
+	return `;CN="` + name + `"`
+}
+
+// Fold content line into lines of 75 octets without splitting characters (RFC 5545, 3.1)
+func foldCalendarLine(content string) string {
+	var result strings.Builder
+	limit := 75
+	for len(content) > limit {
+		n := limit
+		for n > 0 && !utf8.RuneStart(content[n]) {
+			n--
+		}
+		result.WriteString(content[:n] + "\r\n ")
+		content = content[n:]
+		// Continuation lines start with a space
+		limit = 74
+	}
+	result.WriteString(content + "\r\n")
+	return result.String()
+}
@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+
+	emailsRepositoryAdapterPort "github.com/flash-go/notifications-service/internal/port/adapter/repository/emails"
+	emailsServicePort "github.com/flash-go/notifications-service/internal/port/service/emails"
+)
+
+// Default suffix of email keys in cloned folders
+const cloneKeySuffix = "_copy"
+
+func (s *service) CloneEmail(ctx context.Context, id uint, data emailsServicePort.CloneEmailData) (*emailsServicePort.EmailResult, error) {
+	// Get email
+	email, err := s.getEmail(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check folder exist
+	if err := s.checkFolderExist(ctx, data.FolderId); err != nil {
+		return nil, err
+	}
+
+	// Check email key exist
+	if err := s.checkEmailKeyFree(ctx, data.Key, nil); err != nil {
+		return nil, err
+	}
+
+	// Get variants
+	variants, err := s.cloneVariants(ctx, []uint{email.Id})
+	if err != nil {
+		return nil, err
+	}
+
+	// Create copy
+	op := cloneEmail(*email, nil, data.Key, variants[email.Id])
+	if err := s.emailsRepository.ImportBundle(
+		ctx,
+		emailsRepositoryAdapterPort.ImportBundleData{
+			ParentId: data.FolderId,
+			Emails:   []emailsRepositoryAdapterPort.ImportEmailData{op},
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	// Get copy
+	emails, err := s.emailsRepository.FilterEmails(
+		ctx,
+		emailsRepositoryAdapterPort.FilterEmailsData{
+			Key: &[]string{data.Key},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(*emails) == 0 {
+		return nil, emailsServicePort.ErrEmailNotFound
+	}
+
+	// Map repository to service results
+	results := emailsServicePort.EmailResult((*emails)[0])
+
+	return &results, nil
+}
+
+func (s *service) CloneFolder(ctx context.Context, id uint, data emailsServicePort.CloneFolderData) (*emailsServicePort.FolderResult, error) {
+	// Get folders
+	byId, err := s.foldersById(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check folder exist
+	folder, ok := byId[id]
+	if !ok {
+		return nil, emailsServicePort.ErrFolderNotFound
+	}
+
+	// Check parent folder exist
+	if data.ParentId != nil {
+		if _, ok := byId[*data.ParentId]; !ok {
+			return nil, emailsServicePort.ErrFolderNotFound
+		}
+	}
+
+	// Check name is free in parent
+	name := folder.Name
+	if data.Name != nil {
+		name = *data.Name
+	}
+	for _, item := range byId {
+		if item.Name == name && sameId(item.ParentId, data.ParentId) {
+			return nil, emailsServicePort.ErrFolderExist
+		}
+	}
+
+	// Index folders by parent
+	children := make(map[uint][]emailsRepositoryAdapterPort.FolderResult)
+	for _, item := range byId {
+		if item.ParentId != nil {
+			children[*item.ParentId] = append(children[*item.ParentId], item)
+		}
+	}
+
+	// Plan folders parents first
+	ops := emailsRepositoryAdapterPort.ImportBundleData{
+		ParentId: data.ParentId,
+	}
+	paths := map[uint][]string{id: {name}}
+	ops.Folders = append(ops.Folders, cloneFolder(folder, paths[id]))
+	folderIds := []*uint{&folder.Id}
+	for i := 0; i < len(ops.Folders); i++ {
+		parentId := *folderIds[i]
+		for _, child := range children[parentId] {
+			if _, ok := paths[child.Id]; ok {
+				continue
+			}
+			paths[child.Id] = append(append([]string{}, paths[parentId]...), child.Name)
+			ops.Folders = append(ops.Folders, cloneFolder(child, paths[child.Id]))
+			folderIds = append(folderIds, &child.Id)
+		}
+	}
+
+	// Get emails
+	emails, err := s.emailsRepository.FilterEmails(
+		ctx,
+		emailsRepositoryAdapterPort.FilterEmailsData{
+			FolderId: &folderIds,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uint, 0, len(*emails))
+	for _, email := range *emails {
+		ids = append(ids, email.Id)
+	}
+
+	// Get variants
+	variants, err := s.cloneVariants(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	// Plan emails under free keys
+	suffix := cloneKeySuffix
+	if data.KeySuffix != nil {
+		suffix = *data.KeySuffix
+	}
+	keys := make([]string, 0, len(*emails))
+	for _, email := range *emails {
+		keys = append(keys, email.Key+suffix)
+	}
+	taken := make(map[string]bool)
+	if len(keys) > 0 {
+		existing, err := s.emailsRepository.FilterEmails(
+			ctx,
+			emailsRepositoryAdapterPort.FilterEmailsData{
+				Key: &keys,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		for _, email := range *existing {
+			taken[email.Key] = true
+		}
+	}
+	used := make(map[string]bool)
+	for i, email := range *emails {
+		key := keys[i]
+		if taken[key] || used[key] {
+			if key, err = s.freeEmailKey(ctx, key, used); err != nil {
+				return nil, err
+			}
+		}
+		used[key] = true
+		ops.Emails = append(ops.Emails, cloneEmail(email, paths[*email.FolderId], key, variants[email.Id]))
+	}
+
+	// Create copy
+	if err := s.emailsRepository.ImportBundle(ctx, ops); err != nil {
+		return nil, err
+	}
+
+	// Get copy
+	folders, err := s.emailsRepository.FilterFolders(
+		ctx,
+		emailsRepositoryAdapterPort.FilterFoldersData{
+			ParentId: &[]*uint{data.ParentId},
+			Name:     &[]string{name},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(*folders) == 0 {
+		return nil, emailsServicePort.ErrFolderNotFound
+	}
+
+	// Map repository to service results
+	results := emailsServicePort.FolderResult((*folders)[0])
+
+	return &results, nil
+}
+
+// Get variants of the emails indexed by email id
+func (s *service) cloneVariants(ctx context.Context, emailIds []uint) (map[uint][]emailsRepositoryAdapterPort.VariantResult, error) {
+	results := make(map[uint][]emailsRepositoryAdapterPort.VariantResult)
+	if len(emailIds) == 0 {
+		return results, nil
+	}
+	variants, err := s.emailsRepository.FilterVariants(
+		ctx,
+		emailsRepositoryAdapterPort.FilterVariantsData{
+			EmailId: &emailIds,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, variant := range *variants {
+		results[variant.EmailId] = append(results[variant.EmailId], variant)
+	}
+	return results, nil
+}
+
+// Copies are never system folders, so that they can be edited freely
+func cloneFolder(folder emailsRepositoryAdapterPort.FolderResult, path []string) emailsRepositoryAdapterPort.ImportFolderData {
+	return emailsRepositoryAdapterPort.ImportFolderData{
+		Path:        path,
+		Name:        path[len(path)-1],
+		SenderId:    folder.SenderId,
+		Description: folder.Description,
+	}
+}
+
+// Copy of the email content and its variants, emails have no locales, layout references or sample vars to
+// copy. Copies are never system emails, so that they can be edited freely
+func cloneEmail(email emailsRepositoryAdapterPort.EmailResult, folderPath []string, key string, variants []emailsRepositoryAdapterPort.VariantResult) emailsRepositoryAdapterPort.ImportEmailData {
+	op := emailsRepositoryAdapterPort.ImportEmailData{
+		FolderPath:     folderPath,
+		Key:            key,
+		SenderId:       email.SenderId,
+		Subject:        email.Subject,
+		Html:           email.Html,
+		Text:           email.Text,
+		ReplyTo:        email.ReplyTo,
+		Headers:        email.Headers,
+		PdfAttachments: email.PdfAttachments,
+		Category:       email.Category,
+		TrackOpens:     email.TrackOpens,
+		TrackClicks:    email.TrackClicks,
+		Description:    email.Description,
+	}
+	for _, variant := range variants {
+		op.Variants = append(
+			op.Variants,
+			emailsRepositoryAdapterPort.ImportVariantData{
+				Name:    variant.Name,
+				Subject: variant.Subject,
+				Html:    variant.Html,
+				Text:    variant.Text,
+				Weight:  variant.Weight,
+				Active:  variant.Active,
+			},
+		)
+	}
+	return op
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+
+	emailsRepositoryAdapterPort "github.com/flash-go/notifications-service/internal/port/adapter/repository/emails"
+	emailsServicePort "github.com/flash-go/notifications-service/internal/port/service/emails"
+)
+
+// Source of suppressions added from provider delivery events
+const suppressionSourceProvider = "provider"
+
+func (s *service) SmtpBzWebhook(ctx context.Context, token string, body []byte) error {
+	// Check webhook token
+	if len(s.webhookSecret) == 0 || subtle.ConstantTimeCompare([]byte(token), s.webhookSecret) != 1 {
+		return emailsServicePort.ErrWebhookUnauthorized
+	}
+
+	// Parse events
+	events, err := s.emailsRepository.ParseSmtpBzWebhook(body)
+	if err != nil {
+		return err
+	}
+
+	return s.recordDeliveryEvents(ctx, events)
+}
+
+// Map delivery events onto email logs by message id, events of unknown messages are skipped,
+// final events move the log to its final status, hard bounces and complaints suppress the recipient
+func (s *service) recordDeliveryEvents(ctx context.Context, events []emailsRepositoryAdapterPort.DeliveryEventData) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	// Get logs of the messages
+	messageIds := make([]string, 0, len(events))
+	for _, event := range events {
+		messageIds = append(messageIds, event.MessageId)
+	}
+	logs, err := s.emailsRepository.FilterEmailLogs(
+		ctx,
+		emailsRepositoryAdapterPort.FilterEmailLogsData{
+			MessageId: &messageIds,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	logsByMessageId := make(map[string]*emailsRepositoryAdapterPort.EmailLogResult, len(*logs))
+	for i, log := range *logs {
+		if log.MessageId != nil {
+			logsByMessageId[*log.MessageId] = &(*logs)[i]
+		}
+	}
+
+	// Map events to logs
+	items := make([]emailsRepositoryAdapterPort.CreateDeliveryEventData, 0, len(events))
+	var suppressions []emailsRepositoryAdapterPort.CreateSuppressionData
+	suppressed := make(map[string]bool)
+	for _, event := range events {
+		log, ok := logsByMessageId[event.MessageId]
+		if !ok {
+			if s.logger != nil {
+				s.logger.Log().Warn().Str("message_id", event.MessageId).Str("type", event.Type).Msg("delivery event of unknown message")
+			}
+			continue
+		}
+		items = append(
+			items,
+			emailsRepositoryAdapterPort.CreateDeliveryEventData{
+				LogId:     log.Id,
+				Type:      event.Type,
+				Recipient: event.Recipient,
+				Reason:    event.Reason,
+				Hard:      event.Hard,
+				Occurred:  event.Occurred,
+			},
+		)
+		address := recipientAddress(event.Recipient)
+		if reason := deliveryEventSuppressReason(event); reason != "" && address != "" && !suppressed[address] {
+			suppressed[address] = true
+			suppressions = append(
+				suppressions,
+				emailsRepositoryAdapterPort.CreateSuppressionData{
+					Address: address,
+					Reason:  reason,
+					Source:  suppressionSourceProvider,
+				},
+			)
+		}
+	}
+
+	// Save events
+	if _, err := s.emailsRepository.CreateDeliveryEvents(ctx, items); err != nil {
+		return err
+	}
+
+	// Update statuses, events arriving after the final status are only recorded
+	for _, event := range events {
+		log, ok := logsByMessageId[event.MessageId]
+		status := deliveryEventStatus(event)
+		if !ok || status == "" || log.Status == status {
+			continue
+		}
+		if err := s.setEmailStatus(
+			ctx,
+			log,
+			emailsRepositoryAdapterPort.TransitionData{
+				To:     status,
+				Reason: event.Reason,
+			},
+		); err != nil && !errors.Is(err, emailsServicePort.ErrStatusTransitionInvalid) && !errors.Is(err, emailsRepositoryAdapterPort.ErrEmailLogStatusChanged) {
+			return err
+		}
+	}
+
+	// Suppress recipients
+	return s.emailsRepository.AddSuppressions(ctx, suppressions)
+}
+
+// Final status of the message reported by the event, empty if the event does not end the delivery
+func deliveryEventStatus(event emailsRepositoryAdapterPort.DeliveryEventData) string {
+	switch {
+	case event.Type == emailsRepositoryAdapterPort.DeliveryEventDelivered:
+		return emailsRepositoryAdapterPort.EmailStatusDelivered
+	case event.Type == emailsRepositoryAdapterPort.DeliveryEventBounced && event.Hard:
+		return emailsRepositoryAdapterPort.EmailStatusBounced
+	}
+	return ""
+}
+
+// Suppression reason of the event, empty if the event does not suppress the recipient
+func deliveryEventSuppressReason(event emailsRepositoryAdapterPort.DeliveryEventData) string {
+	switch {
+	case event.Type == emailsRepositoryAdapterPort.DeliveryEventBounced && event.Hard:
+		return "hard_bounce"
+	case event.Type == emailsRepositoryAdapterPort.DeliveryEventComplained:
+		return "complaint"
+	}
+	return ""
+}
@@ -0,0 +1,578 @@
+package service
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	emailsRepositoryAdapterPort "github.com/flash-go/notifications-service/internal/port/adapter/repository/emails"
+	emailsServicePort "github.com/flash-go/notifications-service/internal/port/service/emails"
+)
+
+const (
+	// Default DKIM selector of new domains
+	defaultDkimSelector = "notifications"
+	// Size of generated DKIM keys
+	dkimKeyBits = 2048
+	// Policy allowing to send from unverified domains with a warning
+	unverifiedDomainWarn = "warn"
+)
+
+func (s *service) CreateDomain(ctx context.Context, data emailsServicePort.CreateDomainData) (*emailsServicePort.DomainResult, error) {
+	// Normalize domain name
+	name := strings.ToLower(strings.TrimSuffix(data.Name, "."))
+
+	// Check domain is free
+	domains, err := s.emailsRepository.FilterDomains(
+		ctx,
+		emailsRepositoryAdapterPort.FilterDomainsData{
+			Name: &[]string{name},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(*domains) > 0 {
+		return nil, emailsServicePort.ErrDomainExist
+	}
+
+	// Set DKIM selector
+	selector := data.DkimSelector
+	if selector == "" {
+		selector = s.dkimSelector
+	}
+	if selector == "" {
+		selector = defaultDkimSelector
+	}
+
+	// Set DKIM algorithm
+	algorithm := data.DkimAlgorithm
+	if algorithm == "" {
+		algorithm = emailsRepositoryAdapterPort.DkimRsaSha256
+	}
+
+	// Generate DKIM keys
+	privateKey, publicKey, err := generateDkimKeys(algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create domain with active key
+	domain, err := s.emailsRepository.CreateDomain(
+		ctx,
+		emailsRepositoryAdapterPort.CreateDomainData{
+			Name:           name,
+			DkimSelector:   selector,
+			DkimAlgorithm:  algorithm,
+			DkimPrivateKey: privateKey,
+			DkimPublicKey:  publicKey,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Map repository to service results
+	return s.domainResult(ctx, *domain)
+}
+
+func (s *service) FilterDomains(ctx context.Context, data emailsServicePort.FilterDomainsData) (*[]emailsServicePort.DomainResult, error) {
+	// Filter domains
+	domains, err := s.emailsRepository.FilterDomains(
+		ctx,
+		emailsRepositoryAdapterPort.FilterDomainsData(data),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get keys
+	keys, err := s.domainKeys(ctx, *domains)
+	if err != nil {
+		return nil, err
+	}
+
+	// Map repository to service results
+	results := make([]emailsServicePort.DomainResult, 0, len(*domains))
+	for _, domain := range *domains {
+		results = append(
+			results,
+			s.mapDomain(domain, keys[domain.Id]),
+		)
+	}
+
+	return &results, nil
+}
+
+func (s *service) DeleteDomain(ctx context.Context, id uint) error {
+	return s.emailsRepository.DeleteDomain(ctx, id)
+}
+
+func (s *service) VerifyDomain(ctx context.Context, id uint) (*emailsServicePort.DomainResult, error) {
+	// Get domain
+	domain, err := s.getDomain(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify domain
+	if domain, err = s.verifyDomain(ctx, *domain); err != nil {
+		return nil, err
+	}
+
+	// Map repository to service results
+	return s.domainResult(ctx, *domain)
+}
+
+func (s *service) VerifyDomains(ctx context.Context) error {
+	// Get domains
+	domains, err := s.emailsRepository.FilterDomains(
+		ctx,
+		emailsRepositoryAdapterPort.FilterDomainsData{},
+	)
+	if err != nil {
+		return err
+	}
+
+	// Verify every domain, a failed lookup does not stop the others
+	var errs []error
+	for _, domain := range *domains {
+		if _, err := s.verifyDomain(ctx, domain); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *service) CreateDomainKey(ctx context.Context, domainId uint, data emailsServicePort.CreateDomainKeyData) (*emailsServicePort.DomainResult, error) {
+	// Get domain
+	domain, err := s.getDomain(ctx, domainId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check selector is free
+	keys, err := s.emailsRepository.FilterDomainKeys(
+		ctx,
+		emailsRepositoryAdapterPort.FilterDomainKeysData{
+			DomainId: &[]uint{domainId},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range *keys {
+		if key.Selector == data.Selector {
+			return nil, emailsServicePort.ErrDomainKeyExist
+		}
+	}
+
+	// Set algorithm
+	algorithm := data.Algorithm
+	if algorithm == "" {
+		algorithm = emailsRepositoryAdapterPort.DkimRsaSha256
+	}
+
+	// Generate keys
+	privateKey, publicKey, err := generateDkimKeys(algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create inactive key, it is activated once published
+	if _, err := s.emailsRepository.CreateDomainKey(
+		ctx,
+		emailsRepositoryAdapterPort.CreateDomainKeyData{
+			DomainId:   domainId,
+			Selector:   data.Selector,
+			Algorithm:  algorithm,
+			PrivateKey: privateKey,
+			PublicKey:  publicKey,
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	// Map repository to service results
+	return s.domainResult(ctx, *domain)
+}
+
+func (s *service) ActivateDomainKey(ctx context.Context, domainId uint, id uint) (*emailsServicePort.DomainResult, error) {
+	// Get domain
+	domain, err := s.getDomain(ctx, domainId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get key
+	key, err := s.getDomainKey(ctx, domainId, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check key is published
+	published, err := s.dkimPublished(ctx, *domain, *key)
+	if err != nil {
+		return nil, err
+	}
+	if !published {
+		return nil, emailsServicePort.ErrDomainKeyNotPublished
+	}
+
+	// Activate key
+	if err := s.emailsRepository.ActivateDomainKey(ctx, domainId, id); err != nil {
+		return nil, err
+	}
+
+	// Verify domain with the new key
+	if domain, err = s.verifyDomain(ctx, *domain); err != nil {
+		return nil, err
+	}
+
+	// Map repository to service results
+	return s.domainResult(ctx, *domain)
+}
+
+func (s *service) DeleteDomainKey(ctx context.Context, domainId uint, id uint) error {
+	// Get key
+	key, err := s.getDomainKey(ctx, domainId, id)
+	if err != nil {
+		return err
+	}
+
+	// Check key is not used for signing
+	if key.Active {
+		return emailsServicePort.ErrDomainKeyActive
+	}
+
+	// Delete key
+	return s.emailsRepository.DeleteDomainKey(ctx, id)
+}
+
+func (s *service) getDomain(ctx context.Context, id uint) (*emailsRepositoryAdapterPort.DomainResult, error) {
+	domains, err := s.emailsRepository.FilterDomains(
+		ctx,
+		emailsRepositoryAdapterPort.FilterDomainsData{
+			Id: &[]uint{id},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(*domains) == 0 {
+		return nil, emailsServicePort.ErrDomainNotFound
+	}
+	return &(*domains)[0], nil
+}
+
+func (s *service) getDomainKey(ctx context.Context, domainId uint, id uint) (*emailsRepositoryAdapterPort.DomainKeyResult, error) {
+	keys, err := s.emailsRepository.FilterDomainKeys(
+		ctx,
+		emailsRepositoryAdapterPort.FilterDomainKeysData{
+			Id:       &[]uint{id},
+			DomainId: &[]uint{domainId},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(*keys) == 0 {
+		return nil, emailsServicePort.ErrDomainKeyNotFound
+	}
+	return &(*keys)[0], nil
+}
+
+// Get keys of the domains indexed by domain id
+func (s *service) domainKeys(ctx context.Context, domains []emailsRepositoryAdapterPort.DomainResult) (map[uint][]emailsRepositoryAdapterPort.DomainKeyResult, error) {
+	results := make(map[uint][]emailsRepositoryAdapterPort.DomainKeyResult)
+	if len(domains) == 0 {
+		return results, nil
+	}
+	ids := make([]uint, 0, len(domains))
+	for _, domain := range domains {
+		ids = append(ids, domain.Id)
+	}
+	keys, err := s.emailsRepository.FilterDomainKeys(
+		ctx,
+		emailsRepositoryAdapterPort.FilterDomainKeysData{
+			DomainId: &ids,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range *keys {
+		results[key.DomainId] = append(results[key.DomainId], key)
+	}
+	return results, nil
+}
+
+// Check that the DKIM record of the key publishes its public key
+func (s *service) dkimPublished(ctx context.Context, domain emailsRepositoryAdapterPort.DomainResult, key emailsRepositoryAdapterPort.DomainKeyResult) (bool, error) {
+	records, err := s.emailsRepository.LookupTXT(ctx, dkimRecordName(domain, key))
+	if err != nil {
+		return false, err
+	}
+	return slices.ContainsFunc(records, func(record string) bool {
+		return validDkim(record, key)
+	}), nil
+}
+
+// Check DNS records of the domain and save the results
+func (s *service) verifyDomain(ctx context.Context, domain emailsRepositoryAdapterPort.DomainResult) (*emailsRepositoryAdapterPort.DomainResult, error) {
+	// Check SPF
+	spf, err := s.emailsRepository.LookupTXT(ctx, domain.Name)
+	if err != nil {
+		return nil, err
+	}
+	domain.SpfVerified = slices.ContainsFunc(spf, s.validSpf)
+
+	// Check DKIM of every key, the domain is verified by the active one
+	keys, err := s.emailsRepository.FilterDomainKeys(
+		ctx,
+		emailsRepositoryAdapterPort.FilterDomainKeysData{
+			DomainId: &[]uint{domain.Id},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	domain.DkimVerified = false
+	for _, key := range *keys {
+		published, err := s.dkimPublished(ctx, domain, key)
+		if err != nil {
+			return nil, err
+		}
+		if published != key.Verified {
+			if err := s.emailsRepository.UpdateDomainKey(
+				ctx,
+				key.Id,
+				map[string]any{
+					"verified": published,
+				},
+			); err != nil {
+				return nil, err
+			}
+		}
+		if key.Active {
+			domain.DkimVerified = published
+		}
+	}
+
+	// Check DMARC
+	dmarc, err := s.emailsRepository.LookupTXT(ctx, dmarcRecordName(domain))
+	if err != nil {
+		return nil, err
+	}
+	domain.DmarcVerified = slices.ContainsFunc(dmarc, validDmarc)
+
+	// Save results
+	now := time.Unix(0, time.Now().UnixNano())
+	domain.Verified = domain.SpfVerified && domain.DkimVerified && domain.DmarcVerified
+	domain.Checked = &now
+	if err := s.emailsRepository.UpdateDomain(
+		ctx,
+		domain.Id,
+		map[string]any{
+			"spf_verified":   domain.SpfVerified,
+			"dkim_verified":  domain.DkimVerified,
+			"dmarc_verified": domain.DmarcVerified,
+			"verified":       domain.Verified,
+			"checked":        domain.Checked,
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	return &domain, nil
+}
+
+// Check that the sender address belongs to a verified domain
+func (s *service) checkSenderDomain(ctx context.Context, email string) error {
+	name := strings.ToLower(email[strings.LastIndex(email, "@")+1:])
+	verified := true
+	domains, err := s.emailsRepository.FilterDomains(
+		ctx,
+		emailsRepositoryAdapterPort.FilterDomainsData{
+			Name:     &[]string{name},
+			Verified: &verified,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if len(*domains) > 0 {
+		return nil
+	}
+	if s.unverifiedDomainPolicy == unverifiedDomainWarn {
+		if s.logger != nil {
+			s.logger.Log().Warn().Str("domain", name).Msg("sending from unverified domain")
+		}
+		return nil
+	}
+	return emailsServicePort.ErrDomainNotVerified
+}
+
+func (s *service) domainResult(ctx context.Context, domain emailsRepositoryAdapterPort.DomainResult) (*emailsServicePort.DomainResult, error) {
+	keys, err := s.domainKeys(ctx, []emailsRepositoryAdapterPort.DomainResult{domain})
+	if err != nil {
+		return nil, err
+	}
+	results := s.mapDomain(domain, keys[domain.Id])
+	return &results, nil
+}
+
+// Map the domain with its keys and the DNS records to publish
+func (s *service) mapDomain(domain emailsRepositoryAdapterPort.DomainResult, keys []emailsRepositoryAdapterPort.DomainKeyResult) emailsServicePort.DomainResult {
+	results := emailsServicePort.DomainResult{
+		Id:            domain.Id,
+		Name:          domain.Name,
+		SpfVerified:   domain.SpfVerified,
+		DkimVerified:  domain.DkimVerified,
+		DmarcVerified: domain.DmarcVerified,
+		Verified:      domain.Verified,
+		Keys:          make([]emailsServicePort.DomainKeyResult, 0, len(keys)),
+		Records: []emailsServicePort.DnsRecordResult{
+			{
+				Type:     "TXT",
+				Name:     domain.Name,
+				Value:    s.spfRecord(),
+				Verified: domain.SpfVerified,
+			},
+		},
+		Checked: domain.Checked,
+		Updated: domain.Updated,
+		Created: domain.Created,
+	}
+	for _, key := range keys {
+		results.Keys = append(
+			results.Keys,
+			emailsServicePort.DomainKeyResult{
+				Id:        key.Id,
+				Selector:  key.Selector,
+				Algorithm: key.Algorithm,
+				Active:    key.Active,
+				Verified:  key.Verified,
+				Created:   key.Created,
+			},
+		)
+		results.Records = append(
+			results.Records,
+			emailsServicePort.DnsRecordResult{
+				Type:     "TXT",
+				Name:     dkimRecordName(domain, key),
+				Value:    "v=DKIM1; k=" + dkimKeyType(key.Algorithm) + "; p=" + key.PublicKey,
+				Verified: key.Verified,
+			},
+		)
+	}
+	results.Records = append(
+		results.Records,
+		emailsServicePort.DnsRecordResult{
+			Type:     "TXT",
+			Name:     dmarcRecordName(domain),
+			Value:    "v=DMARC1; p=none",
+			Verified: domain.DmarcVerified,
+		},
+	)
+	return results
+}
+
+func (s *service) spfRecord() string {
+	if s.spfInclude == "" {
+		return "v=spf1 mx ~all"
+	}
+	return "v=spf1 include:" + s.spfInclude + " ~all"
+}
+
+// Any SPF policy is valid, it must include the provider when one is configured
+func (s *service) validSpf(record string) bool {
+	fields := strings.Fields(strings.ToLower(record))
+	if len(fields) == 0 || fields[0] != "v=spf1" {
+		return false
+	}
+	return s.spfInclude == "" || slices.Contains(fields, "include:"+strings.ToLower(s.spfInclude))
+}
+
+// The DKIM record must publish the public key
+func validDkim(record string, key emailsRepositoryAdapterPort.DomainKeyResult) bool {
+	tags := dnsTags(record)
+	if version, ok := tags["v"]; ok && version != "DKIM1" {
+		return false
+	}
+	if keyType, ok := tags["k"]; ok && keyType != dkimKeyType(key.Algorithm) {
+		return false
+	}
+	return strings.Join(strings.Fields(tags["p"]), "") == key.PublicKey
+}
+
+// Any DMARC policy is valid
+func validDmarc(record string) bool {
+	return dnsTags(record)["v"] == "DMARC1"
+}
+
+// Parse "tag=value; tag=value" record
+func dnsTags(record string) map[string]string {
+	tags := make(map[string]string)
+	for _, item := range strings.Split(record, ";") {
+		if key, value, ok := strings.Cut(item, "="); ok {
+			tags[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	return tags
+}
+
+func dkimRecordName(domain emailsRepositoryAdapterPort.DomainResult, key emailsRepositoryAdapterPort.DomainKeyResult) string {
+	return key.Selector + "._domainkey." + domain.Name
+}
+
+// Key type of the DKIM record
+func dkimKeyType(algorithm string) string {
+	if algorithm == emailsRepositoryAdapterPort.DkimEd25519Sha256 {
+		return "ed25519"
+	}
+	return "rsa"
+}
+
+func dmarcRecordName(domain emailsRepositoryAdapterPort.DomainResult) string {
+	return "_dmarc." + domain.Name
+}
+
+// Generate PEM encoded private key and base64 encoded public key for the DKIM record
+func generateDkimKeys(algorithm string) (string, string, error) {
+	var key any
+	var public []byte
+	switch algorithm {
+	case emailsRepositoryAdapterPort.DkimEd25519Sha256:
+		// Ed25519 records publish the raw public key
+		publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return "", "", err
+		}
+		key, public = privateKey, publicKey
+	default:
+		privateKey, err := rsa.GenerateKey(rand.Reader, dkimKeyBits)
+		if err != nil {
+			return "", "", err
+		}
+		if public, err = x509.MarshalPKIXPublicKey(&privateKey.PublicKey); err != nil {
+			return "", "", err
+		}
+		key = privateKey
+	}
+	private, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", "", err
+	}
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: private})
+	return string(privateKey), base64.StdEncoding.EncodeToString(public), nil
+}
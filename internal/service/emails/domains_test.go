@@ -0,0 +1,276 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	emailsRepositoryAdapterPort "github.com/flash-go/notifications-service/internal/port/adapter/repository/emails"
+)
+
+const (
+	testDkimPublicKey      = "MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEAtestkey"
+	testOtherDkimPublicKey = "MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEAotherkey"
+)
+
+// Repository resolving TXT records from a map and recording domain updates
+type stubDomainRepository struct {
+	emailsRepositoryAdapterPort.Interface
+	txt           map[string][]string
+	keys          []emailsRepositoryAdapterPort.DomainKeyResult
+	domainUpdates map[string]any
+	keyUpdates    map[uint]map[string]any
+}
+
+func (r *stubDomainRepository) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	if name == "fail.example.com" {
+		return nil, errors.New("lookup timeout")
+	}
+	return r.txt[name], nil
+}
+
+func (r *stubDomainRepository) FilterDomainKeys(ctx context.Context, data emailsRepositoryAdapterPort.FilterDomainKeysData) (*[]emailsRepositoryAdapterPort.DomainKeyResult, error) {
+	keys := append([]emailsRepositoryAdapterPort.DomainKeyResult{}, r.keys...)
+	return &keys, nil
+}
+
+func (r *stubDomainRepository) UpdateDomainKey(ctx context.Context, id uint, data map[string]any) error {
+	r.keyUpdates[id] = data
+	return nil
+}
+
+func (r *stubDomainRepository) UpdateDomain(ctx context.Context, id uint, data map[string]any) error {
+	r.domainUpdates = data
+	return nil
+}
+
+func TestVerifyDomain(t *testing.T) {
+	activeKey := emailsRepositoryAdapterPort.DomainKeyResult{
+		Id:        1,
+		Selector:  "s1",
+		Algorithm: emailsRepositoryAdapterPort.DkimRsaSha256,
+		PublicKey: testDkimPublicKey,
+		Active:    true,
+	}
+	validSpf := []string{"v=spf1 include:_spf.smtp.bz ~all"}
+	validDkim := []string{"v=DKIM1; k=rsa; p=" + testDkimPublicKey}
+	validDmarc := []string{"v=DMARC1; p=none"}
+
+	tests := []struct {
+		name      string
+		spf       []string
+		dkim      []string
+		dmarc     []string
+		wantSpf   bool
+		wantDkim  bool
+		wantDmarc bool
+	}{
+		{
+			name:      "all records valid",
+			spf:       validSpf,
+			dkim:      validDkim,
+			dmarc:     validDmarc,
+			wantSpf:   true,
+			wantDkim:  true,
+			wantDmarc: true,
+		},
+		{
+			name:      "spf include in other case among other records",
+			spf:       []string{"google-site-verification=abc", "v=spf1 mx INCLUDE:_SPF.smtp.bz -all"},
+			dkim:      validDkim,
+			dmarc:     validDmarc,
+			wantSpf:   true,
+			wantDkim:  true,
+			wantDmarc: true,
+		},
+		{
+			name:      "spf without provider include",
+			spf:       []string{"v=spf1 include:_spf.google.com ~all"},
+			dkim:      validDkim,
+			dmarc:     validDmarc,
+			wantDkim:  true,
+			wantDmarc: true,
+		},
+		{
+			name:      "spf include outside spf record",
+			spf:       []string{"include:_spf.smtp.bz"},
+			dkim:      validDkim,
+			dmarc:     validDmarc,
+			wantDkim:  true,
+			wantDmarc: true,
+		},
+		{
+			name:      "dkim record missing",
+			spf:       validSpf,
+			dmarc:     validDmarc,
+			wantSpf:   true,
+			wantDmarc: true,
+		},
+		{
+			name:      "dkim key mismatched",
+			spf:       validSpf,
+			dkim:      []string{"v=DKIM1; k=rsa; p=" + testOtherDkimPublicKey},
+			dmarc:     validDmarc,
+			wantSpf:   true,
+			wantDmarc: true,
+		},
+		{
+			name:      "dkim without p tag",
+			spf:       validSpf,
+			dkim:      []string{"v=DKIM1; k=rsa"},
+			dmarc:     validDmarc,
+			wantSpf:   true,
+			wantDmarc: true,
+		},
+		{
+			name:      "dkim key type mismatched",
+			spf:       validSpf,
+			dkim:      []string{"v=DKIM1; k=ed25519; p=" + testDkimPublicKey},
+			dmarc:     validDmarc,
+			wantSpf:   true,
+			wantDmarc: true,
+		},
+		{
+			name:      "dkim key split into strings",
+			spf:       validSpf,
+			dkim:      []string{"v=DKIM1; p=" + testDkimPublicKey[:20] + " " + testDkimPublicKey[20:]},
+			dmarc:     validDmarc,
+			wantSpf:   true,
+			wantDkim:  true,
+			wantDmarc: true,
+		},
+		{
+			name:      "dmarc reject policy with reports",
+			spf:       validSpf,
+			dkim:      validDkim,
+			dmarc:     []string{"v=DMARC1;p=reject;rua=mailto:dmarc@example.com"},
+			wantSpf:   true,
+			wantDkim:  true,
+			wantDmarc: true,
+		},
+		{
+			name:     "dmarc without version",
+			spf:      validSpf,
+			dkim:     validDkim,
+			dmarc:    []string{"p=reject"},
+			wantSpf:  true,
+			wantDkim: true,
+		},
+		{
+			name:     "dmarc with other version",
+			spf:      validSpf,
+			dkim:     validDkim,
+			dmarc:    []string{"v=DMARC2; p=none"},
+			wantSpf:  true,
+			wantDkim: true,
+		},
+		{
+			name:     "dmarc record missing",
+			spf:      validSpf,
+			dkim:     validDkim,
+			wantSpf:  true,
+			wantDkim: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository := &stubDomainRepository{
+				txt: map[string][]string{
+					"example.com":               tt.spf,
+					"s1._domainkey.example.com": tt.dkim,
+					"_dmarc.example.com":        tt.dmarc,
+				},
+				keys:       []emailsRepositoryAdapterPort.DomainKeyResult{activeKey},
+				keyUpdates: make(map[uint]map[string]any),
+			}
+			s := &service{
+				emailsRepository: repository,
+				spfInclude:       "_spf.smtp.bz",
+			}
+
+			domain, err := s.verifyDomain(context.Background(), emailsRepositoryAdapterPort.DomainResult{Id: 1, Name: "example.com"})
+			if err != nil {
+				t.Fatalf("verifyDomain() error = %v", err)
+			}
+			if domain.SpfVerified != tt.wantSpf || domain.DkimVerified != tt.wantDkim || domain.DmarcVerified != tt.wantDmarc {
+				t.Errorf("verifyDomain() spf, dkim, dmarc = %v, %v, %v, want %v, %v, %v", domain.SpfVerified, domain.DkimVerified, domain.DmarcVerified, tt.wantSpf, tt.wantDkim, tt.wantDmarc)
+			}
+			wantVerified := tt.wantSpf && tt.wantDkim && tt.wantDmarc
+			if domain.Verified != wantVerified || domain.Checked == nil {
+				t.Errorf("verifyDomain() verified = %v, checked = %v, want %v and set", domain.Verified, domain.Checked, wantVerified)
+			}
+
+			// Check saved results
+			if repository.domainUpdates["verified"] != wantVerified || repository.domainUpdates["dkim_verified"] != tt.wantDkim {
+				t.Errorf("saved domain = %v", repository.domainUpdates)
+			}
+			if update, ok := repository.keyUpdates[activeKey.Id]; ok != tt.wantDkim || ok && update["verified"] != true {
+				t.Errorf("saved key updates = %v", repository.keyUpdates)
+			}
+		})
+	}
+}
+
+func TestVerifyDomainKeys(t *testing.T) {
+	// Only the active key verifies the domain, every key gets its own state
+	repository := &stubDomainRepository{
+		txt: map[string][]string{
+			"example.com":               {"v=spf1 include:_spf.smtp.bz ~all"},
+			"s1._domainkey.example.com": {"v=DKIM1; k=rsa; p=" + testOtherDkimPublicKey},
+			"s2._domainkey.example.com": {"v=DKIM1; k=ed25519; p=" + testDkimPublicKey},
+			"_dmarc.example.com":        {"v=DMARC1; p=none"},
+		},
+		keys: []emailsRepositoryAdapterPort.DomainKeyResult{
+			{Id: 1, Selector: "s1", Algorithm: emailsRepositoryAdapterPort.DkimRsaSha256, PublicKey: testDkimPublicKey, Active: true, Verified: true},
+			{Id: 2, Selector: "s2", Algorithm: emailsRepositoryAdapterPort.DkimEd25519Sha256, PublicKey: testDkimPublicKey},
+		},
+		keyUpdates: make(map[uint]map[string]any),
+	}
+	s := &service{
+		emailsRepository: repository,
+		spfInclude:       "_spf.smtp.bz",
+	}
+
+	domain, err := s.verifyDomain(context.Background(), emailsRepositoryAdapterPort.DomainResult{Id: 1, Name: "example.com"})
+	if err != nil {
+		t.Fatalf("verifyDomain() error = %v", err)
+	}
+	if domain.DkimVerified || domain.Verified {
+		t.Errorf("verifyDomain() dkim verified by an inactive key")
+	}
+	if repository.keyUpdates[1]["verified"] != false || repository.keyUpdates[2]["verified"] != true {
+		t.Errorf("saved key updates = %v", repository.keyUpdates)
+	}
+}
+
+func TestVerifyDomainLookupError(t *testing.T) {
+	repository := &stubDomainRepository{
+		keyUpdates: make(map[uint]map[string]any),
+	}
+	s := &service{
+		emailsRepository: repository,
+	}
+
+	if _, err := s.verifyDomain(context.Background(), emailsRepositoryAdapterPort.DomainResult{Id: 1, Name: "fail.example.com"}); err == nil {
+		t.Errorf("verifyDomain() error = nil, want lookup error")
+	}
+	if repository.domainUpdates != nil {
+		t.Errorf("verifyDomain() saved results after lookup error")
+	}
+}
+
+func TestValidSpfWithoutInclude(t *testing.T) {
+	s := &service{}
+	for record, want := range map[string]bool{
+		"v=spf1 mx ~all":   true,
+		"V=SPF1 -all":      true,
+		"v=spf10 mx ~all":  false,
+		"spf1 mx ~all":     false,
+		"":                 false,
+		"v=DMARC1; p=none": false,
+	} {
+		if got := s.validSpf(record); got != want {
+			t.Errorf("validSpf(%q) = %v, want %v", record, got, want)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+
+	emailsRepositoryAdapterPort "github.com/flash-go/notifications-service/internal/port/adapter/repository/emails"
+	emailsServicePort "github.com/flash-go/notifications-service/internal/port/service/emails"
+)
+
+// Prefix of blob keys of stored files
+const fileKeyPrefix = "files/"
+
+func (s *service) CreateFile(ctx context.Context, data emailsServicePort.CreateFileData) (*emailsServicePort.FileResult, error) {
+	// Check size and type
+	if s.attachmentsMaxSize > 0 && len(data.Content) > s.attachmentsMaxSize {
+		return nil, emailsServicePort.ErrAttachmentsTooLarge
+	}
+	contentType, err := s.checkAttachmentType(data.ContentType)
+	if err != nil {
+		return nil, err
+	}
+
+	// Store content
+	key, err := newBlobKey(fileKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.blobStorage.Put(ctx, key, data.Content); err != nil {
+		return nil, err
+	}
+
+	// Create file
+	sum := sha256.Sum256(data.Content)
+	file, err := s.emailsRepository.CreateFile(
+		ctx,
+		emailsRepositoryAdapterPort.CreateFileData{
+			Name:        data.Name,
+			ContentType: contentType,
+			Size:        int64(len(data.Content)),
+			Sha256:      hex.EncodeToString(sum[:]),
+			StorageKey:  key,
+		},
+	)
+	if err != nil {
+		s.deleteBlob(ctx, key)
+		return nil, err
+	}
+
+	// Map repository to service results
+	results := fileResult(*file)
+
+	return &results, nil
+}
+
+func (s *service) FilterFiles(ctx context.Context, data emailsServicePort.FilterFilesData) (*[]emailsServicePort.FileResult, error) {
+	// Filter files
+	files, err := s.emailsRepository.FilterFiles(
+		ctx,
+		emailsRepositoryAdapterPort.FilterFilesData(data),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Map repository to service results
+	results := make([]emailsServicePort.FileResult, 0, len(*files))
+	for _, file := range *files {
+		results = append(
+			results,
+			fileResult(file),
+		)
+	}
+
+	return &results, nil
+}
+
+func (s *service) DeleteFile(ctx context.Context, id uint) error {
+	// Get file
+	file, err := s.getFile(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Delete file
+	if err := s.emailsRepository.DeleteFile(ctx, id); err != nil {
+		return err
+	}
+
+	// Delete content
+	s.deleteBlob(ctx, file.StorageKey)
+
+	return nil
+}
+
+func (s *service) getFile(ctx context.Context, id uint) (*emailsRepositoryAdapterPort.FileResult, error) {
+	files, err := s.emailsRepository.FilterFiles(
+		ctx,
+		emailsRepositoryAdapterPort.FilterFilesData{
+			Id: &[]uint{id},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(*files) == 0 {
+		return nil, emailsServicePort.ErrFileNotFound
+	}
+	return &(*files)[0], nil
+}
+
+// Random blob key with the prefix
+func newBlobKey(prefix string) (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return prefix + hex.EncodeToString(id), nil
+}
+
+// Orphaned blobs are only logged, the database stays the source of truth
+func (s *service) deleteBlob(ctx context.Context, key string) {
+	if err := s.blobStorage.Delete(ctx, key); err != nil && s.logger != nil {
+		s.logger.Log().Warn().Str("key", key).Err(err).Msg("delete blob")
+	}
+}
+
+// Storage key is internal to the service
+func fileResult(file emailsRepositoryAdapterPort.FileResult) emailsServicePort.FileResult {
+	return emailsServicePort.FileResult{
+		Id:          file.Id,
+		Name:        file.Name,
+		ContentType: file.ContentType,
+		Size:        file.Size,
+		Sha256:      file.Sha256,
+		Created:     file.Created,
+	}
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"encoding/json"
+	"net/mail"
+	"net/textproto"
+	"slices"
+	"strings"
+
+	emailsServicePort "github.com/flash-go/notifications-service/internal/port/service/emails"
+)
+
+// Headers written by the service or the provider, in canonical form
+var reservedHeaders = []string{
+	"Bcc",
+	"Cc",
+	"Content-Disposition",
+	"Content-Transfer-Encoding",
+	"Content-Type",
+	"Date",
+	"Dkim-Signature",
+	"From",
+	"List-Unsubscribe",
+	"List-Unsubscribe-Post",
+	"Message-Id",
+	"Mime-Version",
+	"Received",
+	"Reply-To",
+	"Return-Path",
+	"Sender",
+	"Subject",
+	"To",
+}
+
+// Canonicalize header names, reject reserved names and values spanning lines
+func normalizeHeaders(headers map[string]string) (map[string]string, error) {
+	results := make(map[string]string, len(headers))
+	for name, value := range headers {
+		if !validHeaderName(name) || strings.ContainsAny(value, "\r\n") {
+			return nil, emailsServicePort.ErrHeaderInvalid
+		}
+		key := textproto.CanonicalMIMEHeaderKey(name)
+		if slices.Contains(reservedHeaders, key) {
+			return nil, emailsServicePort.ErrHeaderReserved
+		}
+		if _, ok := results[key]; ok {
+			return nil, emailsServicePort.ErrHeaderInvalid
+		}
+		results[key] = value
+	}
+	return results, nil
+}
+
+// Printable ASCII without colon (RFC 5322, 2.2)
+func validHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if r < 33 || r > 126 || r == ':' {
+			return false
+		}
+	}
+	return true
+}
+
+// Render header values, variables must not break values into new lines
+func (s *service) renderHeaders(headers map[string]string, vars *json.RawMessage) (map[string]string, error) {
+	results := make(map[string]string, len(headers))
+	for name, value := range headers {
+		rendered, err := s.renderTemplate(value, vars)
+		if err != nil {
+			return nil, err
+		}
+		if strings.ContainsAny(*rendered, "\r\n") {
+			return nil, emailsServicePort.ErrHeaderInvalid
+		}
+		results[name] = *rendered
+	}
+	return results, nil
+}
+
+// Render reply-to address of the email, the sender reply-to is used if it is not set
+func (s *service) renderReplyTo(replyTo *string, sender string, vars *json.RawMessage) (string, error) {
+	if replyTo == nil {
+		return sender, nil
+	}
+	rendered, err := s.renderTemplate(*replyTo, vars)
+	if err != nil {
+		return "", err
+	}
+	if _, err := mail.ParseAddress(*rendered); err != nil {
+		return "", emailsServicePort.ErrReplyToInvalid
+	}
+	return *rendered, nil
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	emailsRepositoryAdapterPort "github.com/flash-go/notifications-service/internal/port/adapter/repository/emails"
+	emailsServicePort "github.com/flash-go/notifications-service/internal/port/service/emails"
+)
+
+// Allowed transitions of email log statuses, statuses without transitions are final
+var statusTransitions = map[string][]string{
+	emailsRepositoryAdapterPort.EmailStatusAccepted: {
+		emailsRepositoryAdapterPort.EmailStatusQueued,
+		emailsRepositoryAdapterPort.EmailStatusSuppressed,
+		emailsRepositoryAdapterPort.EmailStatusCancelled,
+		emailsRepositoryAdapterPort.EmailStatusFailed,
+	},
+	emailsRepositoryAdapterPort.EmailStatusQueued: {
+		emailsRepositoryAdapterPort.EmailStatusSending,
+		emailsRepositoryAdapterPort.EmailStatusCancelled,
+		emailsRepositoryAdapterPort.EmailStatusFailed,
+	},
+	emailsRepositoryAdapterPort.EmailStatusSending: {
+		emailsRepositoryAdapterPort.EmailStatusSent,
+		emailsRepositoryAdapterPort.EmailStatusFailed,
+	},
+	emailsRepositoryAdapterPort.EmailStatusSent: {
+		emailsRepositoryAdapterPort.EmailStatusDelivered,
+		emailsRepositoryAdapterPort.EmailStatusBounced,
+	},
+}
+
+// Log the message as accepted, the first status of every message
+func (s *service) acceptEmail(ctx context.Context, data emailsRepositoryAdapterPort.SendData) (*emailsRepositoryAdapterPort.EmailLogResult, error) {
+	return s.emailsRepository.CreateEmailLog(
+		ctx,
+		data,
+		emailsRepositoryAdapterPort.TransitionData{
+			To:      emailsRepositoryAdapterPort.EmailStatusAccepted,
+			Created: time.Unix(0, time.Now().UnixNano()),
+		},
+	)
+}
+
+// Log the message and send it through the provider, the log follows the message through its statuses,
+// tracking of the log is added to the sent html only. Provider failures return the failed log with the error
+func (s *service) deliverEmail(ctx context.Context, data emailsRepositoryAdapterPort.SendData, tracking emailTracking) (*emailsRepositoryAdapterPort.EmailLogResult, error) {
+	// Collect tracked links, the log keeps them for redirects
+	if tracking.Clicks {
+		data.Links = s.trackedLinks(data.Html)
+	}
+
+	// Log message
+	log, err := s.acceptEmail(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add tracking
+	data.Html = s.trackHtml(data.Html, log.Id, tracking)
+
+	// Dispatch message
+	for _, status := range []string{emailsRepositoryAdapterPort.EmailStatusQueued, emailsRepositoryAdapterPort.EmailStatusSending} {
+		if err := s.setEmailStatus(ctx, log, emailsRepositoryAdapterPort.TransitionData{To: status}); err != nil {
+			return nil, err
+		}
+	}
+
+	// Send message, the log keeps the failure reason
+	result, sendErr := s.emailsRepository.Send(ctx, data)
+	if sendErr != nil {
+		reason := sendErr.Error()
+		if err := s.setEmailStatus(
+			ctx,
+			log,
+			emailsRepositoryAdapterPort.TransitionData{
+				To:     emailsRepositoryAdapterPort.EmailStatusFailed,
+				Reason: reason,
+				Errors: &reason,
+			},
+		); err != nil {
+			return nil, err
+		}
+		return log, sendErr
+	}
+	if result.Errors != nil {
+		return log, s.setEmailStatus(
+			ctx,
+			log,
+			emailsRepositoryAdapterPort.TransitionData{
+				To:     emailsRepositoryAdapterPort.EmailStatusFailed,
+				Reason: *result.Errors,
+				Errors: result.Errors,
+			},
+		)
+	}
+	return log, s.setEmailStatus(
+		ctx,
+		log,
+		emailsRepositoryAdapterPort.TransitionData{
+			To:        emailsRepositoryAdapterPort.EmailStatusSent,
+			MessageId: result.MessageId,
+		},
+	)
+}
+
+// Message of a send request ready to be logged, messages with a suppression reason are logged without sending
+type outgoingEmail struct {
+	data     emailsRepositoryAdapterPort.SendData
+	tracking emailTracking
+	reason   string
+}
+
+// Log and send the messages of a request. Once a message reached the provider, provider failures of later messages
+// are kept in their logs instead of failing the request, so that a retry does not send the same message twice
+func (s *service) sendEmails(ctx context.Context, messages []outgoingEmail) (*[]emailsServicePort.EmailLogResult, error) {
+	results := make([]emailsServicePort.EmailLogResult, 0, len(messages))
+	sent := false
+	for _, message := range messages {
+		// Log suppressed message without sending
+		if message.reason != "" {
+			log, err := s.suppressLog(ctx, message.data, message.reason)
+			if err != nil {
+				return nil, err
+			}
+			results = append(results, emailLogResult(*log))
+			continue
+		}
+
+		// Send message
+		log, err := s.deliverEmail(ctx, message.data, message.tracking)
+		if err != nil {
+			if !sent || log == nil {
+				return nil, err
+			}
+			if s.logger != nil {
+				s.logger.Log().Warn().Uint("log_id", log.Id).Err(err).Msg("send email")
+			}
+		}
+		if log.Status == emailsRepositoryAdapterPort.EmailStatusSent {
+			sent = true
+		}
+
+		// Map repository to service results
+		results = append(results, emailLogResult(*log))
+	}
+
+	return &results, nil
+}
+
+// Log the message as suppressed for the reason instead of sending it
+func (s *service) suppressLog(ctx context.Context, data emailsRepositoryAdapterPort.SendData, reason string) (*emailsRepositoryAdapterPort.EmailLogResult, error) {
+	// Log message
+	log, err := s.acceptEmail(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Suppress message
+	return log, s.setEmailStatus(
+		ctx,
+		log,
+		emailsRepositoryAdapterPort.TransitionData{
+			To:     emailsRepositoryAdapterPort.EmailStatusSuppressed,
+			Reason: reason,
+			Errors: &reason,
+		},
+	)
+}
+
+// Move the email log to the status of the transition, transitions not allowed from its status are rejected
+func (s *service) setEmailStatus(ctx context.Context, log *emailsRepositoryAdapterPort.EmailLogResult, data emailsRepositoryAdapterPort.TransitionData) error {
+	data.From = log.Status
+	data.Created = time.Unix(0, time.Now().UnixNano())
+
+	// Check transition
+	if !slices.Contains(statusTransitions[data.From], data.To) {
+		if s.logger != nil {
+			s.logger.Log().Warn().Uint("log_id", log.Id).Str("from", data.From).Str("to", data.To).Msg("invalid email status transition")
+		}
+		return emailsServicePort.ErrStatusTransitionInvalid
+	}
+
+	// Update email log
+	transition, err := s.emailsRepository.UpdateEmailLogStatus(ctx, log.Id, data)
+	if err != nil {
+		return err
+	}
+
+	// Keep the log in line with the database
+	log.Status = data.To
+	if data.MessageId != nil {
+		log.MessageId = data.MessageId
+	}
+	if data.Errors != nil {
+		log.Errors = data.Errors
+	}
+	log.Transitions = append(log.Transitions, *transition)
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	emailsRepositoryAdapterPort "github.com/flash-go/notifications-service/internal/port/adapter/repository/emails"
+)
+
+// Repository keeping email logs in memory, sending fails for the listed recipients
+type stubSendRepository struct {
+	emailsRepositoryAdapterPort.Interface
+	logs   []*emailsRepositoryAdapterPort.EmailLogResult
+	failed map[string]bool
+	sent   []string
+}
+
+func (r *stubSendRepository) CreateEmailLog(ctx context.Context, data emailsRepositoryAdapterPort.SendData, transition emailsRepositoryAdapterPort.TransitionData) (*emailsRepositoryAdapterPort.EmailLogResult, error) {
+	log := &emailsRepositoryAdapterPort.EmailLogResult{
+		Id:     uint(len(r.logs) + 1),
+		To:     data.To,
+		Status: transition.To,
+	}
+	r.logs = append(r.logs, log)
+	return log, nil
+}
+
+func (r *stubSendRepository) UpdateEmailLogStatus(ctx context.Context, id uint, data emailsRepositoryAdapterPort.TransitionData) (*emailsRepositoryAdapterPort.EmailLogTransitionResult, error) {
+	return &emailsRepositoryAdapterPort.EmailLogTransitionResult{LogId: id, From: data.From, To: data.To, Reason: data.Reason, Created: data.Created}, nil
+}
+
+func (r *stubSendRepository) Send(ctx context.Context, data emailsRepositoryAdapterPort.SendData) (*emailsRepositoryAdapterPort.SendResult, error) {
+	if r.failed[data.To[0]] {
+		return nil, errors.New("provider unavailable")
+	}
+	r.sent = append(r.sent, data.To[0])
+	messageId := data.To[0]
+	return &emailsRepositoryAdapterPort.SendResult{MessageId: &messageId}, nil
+}
+
+func TestSendEmails(t *testing.T) {
+	message := func(to string) outgoingEmail {
+		return outgoingEmail{data: emailsRepositoryAdapterPort.SendData{To: []string{to}}}
+	}
+
+	tests := []struct {
+		name     string
+		messages []outgoingEmail
+		failed   map[string]bool
+		statuses []string
+		sent     []string
+		err      bool
+	}{
+		{
+			name:     "all sent",
+			messages: []outgoingEmail{message("a@example.com"), message("b@example.com")},
+			statuses: []string{emailsRepositoryAdapterPort.EmailStatusSent, emailsRepositoryAdapterPort.EmailStatusSent},
+			sent:     []string{"a@example.com", "b@example.com"},
+		},
+		{
+			name:     "first failed",
+			messages: []outgoingEmail{message("a@example.com"), message("b@example.com")},
+			failed:   map[string]bool{"a@example.com": true},
+			err:      true,
+		},
+		{
+			name:     "later failed after a send",
+			messages: []outgoingEmail{message("a@example.com"), message("b@example.com"), message("c@example.com")},
+			failed:   map[string]bool{"b@example.com": true},
+			statuses: []string{emailsRepositoryAdapterPort.EmailStatusSent, emailsRepositoryAdapterPort.EmailStatusFailed, emailsRepositoryAdapterPort.EmailStatusSent},
+			sent:     []string{"a@example.com", "c@example.com"},
+		},
+		{
+			name: "suppressed logged without sending",
+			messages: []outgoingEmail{
+				{data: emailsRepositoryAdapterPort.SendData{To: []string{"a@example.com"}}, reason: suppressedUnsubscribed},
+				message("b@example.com"),
+			},
+			statuses: []string{emailsRepositoryAdapterPort.EmailStatusSuppressed, emailsRepositoryAdapterPort.EmailStatusSent},
+			sent:     []string{"b@example.com"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repository := &stubSendRepository{failed: test.failed}
+			s := &service{emailsRepository: repository}
+
+			results, err := s.sendEmails(context.Background(), test.messages)
+			if test.err {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(*results) != len(test.statuses) {
+				t.Fatalf("results = %d, want %d", len(*results), len(test.statuses))
+			}
+			for i, result := range *results {
+				if result.Status != test.statuses[i] {
+					t.Errorf("result %d status = %s, want %s", i, result.Status, test.statuses[i])
+				}
+			}
+			if len(repository.sent) != len(test.sent) {
+				t.Fatalf("sent = %v, want %v", repository.sent, test.sent)
+			}
+			for i, to := range test.sent {
+				if repository.sent[i] != to {
+					t.Errorf("sent %d = %s, want %s", i, repository.sent[i], to)
+				}
+			}
+		})
+	}
+}
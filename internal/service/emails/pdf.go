@@ -0,0 +1,63 @@
+package service
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"strings"
+	"text/template"
+
+	emailsRepositoryAdapterPort "github.com/flash-go/notifications-service/internal/port/adapter/repository/emails"
+	emailsServicePort "github.com/flash-go/notifications-service/internal/port/service/emails"
+)
+
+// Check file name and content templates of pdf attachments parse
+func checkPdfAttachments(templates map[string]string) error {
+	for filename, content := range templates {
+		if strings.TrimSpace(filename) == "" {
+			return emailsServicePort.ErrPdfAttachmentInvalid
+		}
+		if _, err := template.New("filename").Parse(filename); err != nil {
+			return emailsServicePort.ErrPdfAttachmentInvalid
+		}
+		if _, err := template.New("content").Parse(content); err != nil {
+			return emailsServicePort.ErrPdfAttachmentInvalid
+		}
+	}
+	return nil
+}
+
+// Render pdf attachments with vars in file name order
+func (s *service) renderPdfAttachments(templates map[string]string, vars *json.RawMessage) ([]emailsRepositoryAdapterPort.AttachmentData, error) {
+	results := make([]emailsRepositoryAdapterPort.AttachmentData, 0, len(templates))
+	for _, name := range slices.Sorted(maps.Keys(templates)) {
+		// Render file name, it must stay a plain file name
+		filename, err := s.renderTemplate(name, vars)
+		if err != nil {
+			return nil, err
+		}
+		if *filename == "" || strings.ContainsAny(*filename, "/\\\r\n") {
+			return nil, emailsServicePort.ErrPdfAttachmentInvalid
+		}
+		if !strings.HasSuffix(strings.ToLower(*filename), ".pdf") {
+			*filename += ".pdf"
+		}
+
+		// Render content
+		content, err := s.renderTemplate(templates[name], vars)
+		if err != nil {
+			return nil, err
+		}
+		document, err := s.pdfRenderer.Render(*content)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, emailsRepositoryAdapterPort.AttachmentData{
+			Filename:    *filename,
+			ContentType: "application/pdf",
+			Content:     document,
+		})
+	}
+	return results, nil
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"net/mail"
+	"strings"
+
+	emailsServicePort "github.com/flash-go/notifications-service/internal/port/service/emails"
+)
+
+// Recipient mode sending a separate message to every recipient
+const recipientModeIndividual = "individual"
+
+// Recipients of a single message
+type recipientSet struct {
+	To  []string
+	Cc  []string
+	Bcc []string
+}
+
+// Check the recipient cap and split recipients into messages by the recipient mode, individual messages may be forced
+func (s *service) splitRecipients(to, cc, bcc []string, individual bool) ([]recipientSet, error) {
+	// Check recipient cap
+	if s.maxRecipients > 0 && len(to)+len(cc)+len(bcc) > s.maxRecipients {
+		return nil, emailsServicePort.ErrTooManyRecipients
+	}
+
+	// One message shared by all recipients
+	if !individual && s.recipientMode != recipientModeIndividual {
+		return []recipientSet{{To: to, Cc: cc, Bcc: bcc}}, nil
+	}
+
+	// One message per unique address
+	seen := make(map[string]bool)
+	var messages []recipientSet
+	for _, list := range [][]string{to, cc, bcc} {
+		for _, item := range list {
+			address := recipientAddress(item)
+			if seen[address] {
+				continue
+			}
+			seen[address] = true
+			messages = append(messages, recipientSet{To: []string{item}})
+		}
+	}
+	return messages, nil
+}
+
+// Lowercased address of the recipient without display name
+func recipientAddress(recipient string) string {
+	if address, err := mail.ParseAddress(recipient); err == nil {
+		return strings.ToLower(address.Address)
+	}
+	return strings.ToLower(strings.TrimSpace(recipient))
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	emailsRepositoryAdapterPort "github.com/flash-go/notifications-service/internal/port/adapter/repository/emails"
+)
+
+// Poll delivery events of a batch of recent sent messages without a final delivery status,
+// for providers whose webhooks cannot reach the service
+func (s *service) ReconcileDeliveries(ctx context.Context) error {
+	if s.reconcileBatchSize <= 0 {
+		return nil
+	}
+	now := time.Now()
+
+	// Get logs awaiting final delivery status
+	logs, err := s.emailsRepository.FilterReconcileLogs(
+		ctx,
+		emailsRepositoryAdapterPort.FilterReconcileLogsData{
+			Since: now.Add(-s.reconcileWindow),
+			Limit: s.reconcileBatchSize,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	// Poll provider, requests are spaced out to stay within the rate limit
+	var events []emailsRepositoryAdapterPort.DeliveryEventData
+	polled := make([]uint, 0, len(*logs))
+	for i, log := range *logs {
+		if i > 0 && s.reconcileRate > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Second / time.Duration(s.reconcileRate)):
+			}
+		}
+		items, err := s.emailsRepository.GetDeliveryEvents(ctx, log.MessageId)
+		if errors.Is(err, emailsRepositoryAdapterPort.ErrDeliveryStatusNotSupported) {
+			return nil
+		}
+		// Keep the rest of the batch for the next run
+		if errors.Is(err, emailsRepositoryAdapterPort.ErrProviderRateLimited) {
+			if s.logger != nil {
+				s.logger.Log().Warn().Int("polled", len(polled)).Msg("delivery status polling rate limited")
+			}
+			break
+		}
+		if err != nil {
+			if s.logger != nil {
+				s.logger.Log().Warn().Str("message_id", log.MessageId).Err(err).Msg("poll delivery status")
+			}
+			continue
+		}
+		events = append(events, items...)
+		polled = append(polled, log.Id)
+	}
+
+	// Record events
+	if err := s.recordDeliveryEvents(ctx, events); err != nil {
+		return err
+	}
+
+	// Polled messages go to the end of the queue
+	return s.emailsRepository.SetLogsReconciled(ctx, polled, time.Unix(0, now.UnixNano()))
+}
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/flash-go/flash/http/client"
+	emailsRepositoryAdapterImpl "github.com/flash-go/notifications-service/internal/adapter/repository/emails"
+	emailsRepositoryAdapterPort "github.com/flash-go/notifications-service/internal/port/adapter/repository/emails"
+)
+
+// Repository keeping email logs in memory, delivery events are polled from the provider adapter
+type stubReconcileRepository struct {
+	emailsRepositoryAdapterPort.Interface
+	provider     emailsRepositoryAdapterPort.Interface
+	logs         []*emailsRepositoryAdapterPort.EmailLogResult
+	events       []emailsRepositoryAdapterPort.CreateDeliveryEventData
+	suppressions []emailsRepositoryAdapterPort.CreateSuppressionData
+	reconciled   []uint
+}
+
+func (r *stubReconcileRepository) FilterReconcileLogs(ctx context.Context, data emailsRepositoryAdapterPort.FilterReconcileLogsData) (*[]emailsRepositoryAdapterPort.ReconcileLogResult, error) {
+	results := []emailsRepositoryAdapterPort.ReconcileLogResult{}
+	for _, log := range r.logs {
+		if log.Status == emailsRepositoryAdapterPort.EmailStatusSent && len(results) < data.Limit {
+			results = append(results, emailsRepositoryAdapterPort.ReconcileLogResult{Id: log.Id, MessageId: *log.MessageId})
+		}
+	}
+	return &results, nil
+}
+
+func (r *stubReconcileRepository) GetDeliveryEvents(ctx context.Context, messageId string) ([]emailsRepositoryAdapterPort.DeliveryEventData, error) {
+	return r.provider.GetDeliveryEvents(ctx, messageId)
+}
+
+func (r *stubReconcileRepository) FilterEmailLogs(ctx context.Context, data emailsRepositoryAdapterPort.FilterEmailLogsData) (*[]emailsRepositoryAdapterPort.EmailLogResult, error) {
+	results := []emailsRepositoryAdapterPort.EmailLogResult{}
+	for _, log := range r.logs {
+		if slices.Contains(*data.MessageId, *log.MessageId) {
+			results = append(results, *log)
+		}
+	}
+	return &results, nil
+}
+
+func (r *stubReconcileRepository) CreateDeliveryEvents(ctx context.Context, data []emailsRepositoryAdapterPort.CreateDeliveryEventData) (uint, error) {
+	r.events = append(r.events, data...)
+	return uint(len(data)), nil
+}
+
+func (r *stubReconcileRepository) UpdateEmailLogStatus(ctx context.Context, id uint, data emailsRepositoryAdapterPort.TransitionData) (*emailsRepositoryAdapterPort.EmailLogTransitionResult, error) {
+	for _, log := range r.logs {
+		if log.Id == id && log.Status == data.From {
+			log.Status = data.To
+			return &emailsRepositoryAdapterPort.EmailLogTransitionResult{LogId: id, From: data.From, To: data.To, Reason: data.Reason, Created: data.Created}, nil
+		}
+	}
+	return nil, emailsRepositoryAdapterPort.ErrEmailLogStatusChanged
+}
+
+func (r *stubReconcileRepository) AddSuppressions(ctx context.Context, data []emailsRepositoryAdapterPort.CreateSuppressionData) error {
+	r.suppressions = append(r.suppressions, data...)
+	return nil
+}
+
+func (r *stubReconcileRepository) SetLogsReconciled(ctx context.Context, ids []uint, reconciled time.Time) error {
+	r.reconciled = append(r.reconciled, ids...)
+	return nil
+}
+
+// smtp.bz message status API stand-in answering with the response of the message id
+type statusApi struct {
+	mu        sync.Mutex
+	responses map[string]statusResponse
+	requests  []string
+	times     []time.Time
+}
+
+type statusResponse struct {
+	code int
+	body string
+}
+
+func (a *statusApi) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	messageId, ok := strings.CutPrefix(r.URL.Path, "/log/message/")
+	if !ok || r.Header.Get("Authorization") != "api-key" {
+		w.WriteHeader(400)
+		return
+	}
+	a.requests = append(a.requests, messageId)
+	a.times = append(a.times, time.Now())
+	response := a.responses[messageId]
+	w.WriteHeader(response.code)
+	w.Write([]byte(response.body))
+}
+
+func newReconcileTest(t *testing.T, provider string, responses map[string]statusResponse, messageIds ...string) (*service, *stubReconcileRepository, *statusApi) {
+	api := &statusApi{responses: responses}
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	repository := &stubReconcileRepository{
+		provider: emailsRepositoryAdapterImpl.New(
+			&emailsRepositoryAdapterImpl.Config{
+				HttpClient:   client.New(),
+				Provider:     provider,
+				SmtpBzApiKey: "api-key",
+				SmtpBzApiUrl: server.URL,
+			},
+		),
+	}
+	for i, messageId := range messageIds {
+		repository.logs = append(repository.logs, &emailsRepositoryAdapterPort.EmailLogResult{
+			Id:        uint(i + 1),
+			Status:    emailsRepositoryAdapterPort.EmailStatusSent,
+			MessageId: &messageId,
+		})
+	}
+	s := &service{
+		emailsRepository:   repository,
+		reconcileWindow:    time.Hour,
+		reconcileBatchSize: 10,
+		reconcileRate:      20,
+	}
+	return s, repository, api
+}
+
+func TestReconcileDeliveries(t *testing.T) {
+	s, repository, api := newReconcileTest(
+		t,
+		emailsRepositoryAdapterImpl.ProviderSmtpBz,
+		map[string]statusResponse{
+			"m1": {200, `{"result": true, "events": [{"event": "delivered", "email": "a@example.com", "time": 1760000000}]}`},
+			"m2": {200, `{"result": true, "events": [{"event": "deferred", "email": "b@example.com", "time": 1760000000}, {"event": "hard_bounce", "email": "B@Example.com", "time": 1760000060, "reason": "mailbox unavailable"}]}`},
+			"m3": {200, `{"result": true, "events": [{"event": "deferred", "email": "c@example.com", "time": 1760000000}]}`},
+			"m4": {404, `{"result": false}`},
+			"m5": {429, `{"result": false}`},
+			"m6": {200, `{"result": true, "events": [{"event": "delivered", "email": "f@example.com", "time": 1760000000}]}`},
+		},
+		"m1", "m2", "m3", "m4", "m5", "m6",
+	)
+
+	if err := s.ReconcileDeliveries(context.Background()); err != nil {
+		t.Fatalf("ReconcileDeliveries() error = %v", err)
+	}
+
+	// Run ends at the rate limited request
+	if want := []string{"m1", "m2", "m3", "m4", "m5"}; !slices.Equal(api.requests, want) {
+		t.Errorf("requested messages %v, want %v", api.requests, want)
+	}
+	if want := []uint{1, 2, 3, 4}; !slices.Equal(repository.reconciled, want) {
+		t.Errorf("reconciled logs %v, want %v", repository.reconciled, want)
+	}
+
+	// Requests are spaced out to the rate
+	for i := 1; i < len(api.times); i++ {
+		if gap := api.times[i].Sub(api.times[i-1]); gap < 45*time.Millisecond {
+			t.Errorf("request %d sent %v after the previous one, want at least 50ms", i, gap)
+		}
+	}
+
+	// Events without message id are mapped to the polled message
+	if len(repository.events) != 4 {
+		t.Fatalf("recorded events %+v, want 4", repository.events)
+	}
+	if event := repository.events[0]; event.LogId != 1 || event.Type != emailsRepositoryAdapterPort.DeliveryEventDelivered || event.Recipient != "a@example.com" || !event.Occurred.Equal(time.Unix(1760000000, 0)) {
+		t.Errorf("recorded event %+v", event)
+	}
+
+	// Final events move the logs from sent
+	wantStatus := []string{
+		emailsRepositoryAdapterPort.EmailStatusDelivered,
+		emailsRepositoryAdapterPort.EmailStatusBounced,
+		emailsRepositoryAdapterPort.EmailStatusSent,
+		emailsRepositoryAdapterPort.EmailStatusSent,
+		emailsRepositoryAdapterPort.EmailStatusSent,
+		emailsRepositoryAdapterPort.EmailStatusSent,
+	}
+	for i, log := range repository.logs {
+		if log.Status != wantStatus[i] {
+			t.Errorf("log %s status = %s, want %s", *log.MessageId, log.Status, wantStatus[i])
+		}
+	}
+
+	// Hard bounce suppresses the recipient
+	if len(repository.suppressions) != 1 || repository.suppressions[0].Address != "b@example.com" || repository.suppressions[0].Reason != "hard_bounce" {
+		t.Errorf("suppressions %+v, want b@example.com hard_bounce", repository.suppressions)
+	}
+
+	// Next run starts with the messages still in status sent
+	api.requests = nil
+	api.responses["m5"] = statusResponse{200, `{"result": true, "events": [{"event": "delivered", "email": "e@example.com"}]}`}
+	if err := s.ReconcileDeliveries(context.Background()); err != nil {
+		t.Fatalf("ReconcileDeliveries() error = %v", err)
+	}
+	if want := []string{"m3", "m4", "m5", "m6"}; !slices.Equal(api.requests, want) {
+		t.Errorf("requested messages %v, want %v", api.requests, want)
+	}
+	if repository.logs[4].Status != emailsRepositoryAdapterPort.EmailStatusDelivered || repository.logs[5].Status != emailsRepositoryAdapterPort.EmailStatusDelivered {
+		t.Errorf("logs m5, m6 status = %s, %s, want delivered", repository.logs[4].Status, repository.logs[5].Status)
+	}
+}
+
+func TestReconcileDeliveriesSmtpRelay(t *testing.T) {
+	s, repository, api := newReconcileTest(t, emailsRepositoryAdapterImpl.ProviderSmtp, nil, "m1")
+
+	if err := s.ReconcileDeliveries(context.Background()); err != nil {
+		t.Fatalf("ReconcileDeliveries() error = %v", err)
+	}
+	if len(api.requests) > 0 || len(repository.reconciled) > 0 {
+		t.Errorf("relay without status API polled %v, reconciled %v", api.requests, repository.reconciled)
+	}
+}
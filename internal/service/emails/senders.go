@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	emailsRepositoryAdapterPort "github.com/flash-go/notifications-service/internal/port/adapter/repository/emails"
+	emailsServicePort "github.com/flash-go/notifications-service/internal/port/service/emails"
+)
+
+func (s *service) CreateSender(ctx context.Context, data emailsServicePort.CreateSenderData) (*emailsServicePort.SenderResult, error) {
+	// Check sender identity is free
+	if err := s.checkSenderFree(ctx, data.Email, data.Name, nil); err != nil {
+		return nil, err
+	}
+
+	// Create sender
+	sender, err := s.emailsRepository.CreateSender(
+		ctx,
+		emailsRepositoryAdapterPort.CreateSenderData(data),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Map repository to service results
+	results := emailsServicePort.SenderResult(*sender)
+
+	return &results, nil
+}
+
+func (s *service) FilterSenders(ctx context.Context, data emailsServicePort.FilterSendersData) (*[]emailsServicePort.SenderResult, error) {
+	// Filter senders
+	senders, err := s.emailsRepository.FilterSenders(
+		ctx,
+		emailsRepositoryAdapterPort.FilterSendersData(data),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Map repository to service results
+	results := make([]emailsServicePort.SenderResult, 0, len(*senders))
+	for _, sender := range *senders {
+		results = append(
+			results,
+			emailsServicePort.SenderResult(sender),
+		)
+	}
+
+	return &results, nil
+}
+
+func (s *service) DeleteSender(ctx context.Context, id uint) error {
+	// Check sender is not used by folders and emails
+	count, err := s.emailsRepository.CountSenderUsage(ctx, id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return emailsServicePort.ErrSenderInUse
+	}
+
+	// Delete sender
+	if err := s.emailsRepository.DeleteSender(ctx, id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *service) UpdateSender(ctx context.Context, id uint, data map[string]any) error {
+	// Check sender identity is free
+	email, emailSet := data["email"].(*string)
+	name, nameSet := data["name"].(*string)
+	if emailSet || nameSet {
+		sender, err := s.getSender(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !emailSet {
+			email = &sender.Email
+		}
+		if !nameSet {
+			name = &sender.Name
+		}
+		if err := s.checkSenderFree(ctx, *email, *name, &id); err != nil {
+			return err
+		}
+	}
+
+	// Set sender data
+	data["updated"] = time.Unix(0, time.Now().UnixNano())
+
+	// Update sender
+	return s.emailsRepository.UpdateSender(ctx, id, data)
+}
+
+func (s *service) getSender(ctx context.Context, id uint) (*emailsRepositoryAdapterPort.SenderResult, error) {
+	senders, err := s.emailsRepository.FilterSenders(
+		ctx,
+		emailsRepositoryAdapterPort.FilterSendersData{
+			Id: &[]uint{id},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(*senders) == 0 {
+		return nil, emailsServicePort.ErrSenderNotFound
+	}
+	return &(*senders)[0], nil
+}
+
+// Check that the sender exists, no sender is always valid
+func (s *service) checkSenderExist(ctx context.Context, id *uint) error {
+	if id == nil {
+		return nil
+	}
+	_, err := s.getSender(ctx, *id)
+	return err
+}
+
+func (s *service) checkSenderFree(ctx context.Context, email, name string, exceptId *uint) error {
+	senders, err := s.emailsRepository.FilterSenders(
+		ctx,
+		emailsRepositoryAdapterPort.FilterSendersData{
+			Email: &[]string{email},
+			Name:  &[]string{name},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	for _, sender := range *senders {
+		if exceptId == nil || sender.Id != *exceptId {
+			return emailsServicePort.ErrSenderExist
+		}
+	}
+	return nil
+}
+
+// Find an approved sender by address and, if set, display name
+func (s *service) approvedSender(ctx context.Context, email, name string) (*emailsRepositoryAdapterPort.SenderResult, error) {
+	filter := emailsRepositoryAdapterPort.FilterSendersData{
+		Email: &[]string{email},
+	}
+	if name != "" {
+		filter.Name = &[]string{name}
+	}
+	senders, err := s.emailsRepository.FilterSenders(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(*senders) == 0 {
+		return nil, emailsServicePort.ErrSenderNotApproved
+	}
+	return &(*senders)[0], nil
+}
+
+// Get the sender of the email or the default sender of its nearest folder
+func (s *service) emailSender(ctx context.Context, email emailsRepositoryAdapterPort.EmailResult) (*emailsRepositoryAdapterPort.SenderResult, error) {
+	senderId := email.SenderId
+	if senderId == nil && email.FolderId != nil {
+		byId, err := s.foldersById(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for current, depth := email.FolderId, 0; current != nil && depth <= len(byId); current, depth = byId[*current].ParentId, depth+1 {
+			if folder := byId[*current]; folder.SenderId != nil {
+				senderId = folder.SenderId
+				break
+			}
+		}
+	}
+	if senderId == nil {
+		return nil, emailsServicePort.ErrSenderNotSet
+	}
+	return s.getSender(ctx, *senderId)
+}
@@ -3,31 +3,119 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"hash/fnv"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"text/template"
 	"time"
 
+	"github.com/flash-go/flash/logger"
+	pdfRendererAdapterPort "github.com/flash-go/notifications-service/internal/port/adapter/renderer/pdf"
 	emailsRepositoryAdapterPort "github.com/flash-go/notifications-service/internal/port/adapter/repository/emails"
+	blobsStorageAdapterPort "github.com/flash-go/notifications-service/internal/port/adapter/storage/blobs"
 	emailsServicePort "github.com/flash-go/notifications-service/internal/port/service/emails"
 )
 
+// Default number of email search results
+const searchEmailsLimit = 20
+
 type Config struct {
-	EmailsRepository emailsRepositoryAdapterPort.Interface
+	EmailsRepository       emailsRepositoryAdapterPort.Interface
+	BlobStorage            blobsStorageAdapterPort.Interface
+	PdfRenderer            pdfRendererAdapterPort.Interface
+	TrashRetention         time.Duration
+	DkimSelector           string
+	SpfInclude             string
+	UnverifiedDomainPolicy string
+	MaxRecipients          int
+	RecipientMode          string
+	AttachmentsMaxSize     int
+	AttachmentTypes        string
+	AssetsBaseUrl          string
+	AssetsMaxSize          int
+	UnsubscribeBaseUrl     string
+	UnsubscribeSecret      string
+	WebhookSecret          string
+	TrackingBaseUrl        string
+	TrackingSecret         string
+	ReconcileWindow        time.Duration
+	ReconcileBatchSize     int
+	ReconcileRate          int
+	Logger                 logger.Logger
 }
 
 func New(config *Config) emailsServicePort.Interface {
 	return &service{
 		config.EmailsRepository,
+		config.BlobStorage,
+		config.PdfRenderer,
+		config.TrashRetention,
+		config.DkimSelector,
+		config.SpfInclude,
+		config.UnverifiedDomainPolicy,
+		config.MaxRecipients,
+		config.RecipientMode,
+		config.AttachmentsMaxSize,
+		attachmentTypes(config.AttachmentTypes),
+		strings.TrimSuffix(config.AssetsBaseUrl, "/"),
+		config.AssetsMaxSize,
+		strings.TrimSuffix(config.UnsubscribeBaseUrl, "/"),
+		[]byte(config.UnsubscribeSecret),
+		[]byte(config.WebhookSecret),
+		strings.TrimSuffix(config.TrackingBaseUrl, "/"),
+		[]byte(config.TrackingSecret),
+		config.ReconcileWindow,
+		config.ReconcileBatchSize,
+		config.ReconcileRate,
+		config.Logger,
 	}
 }
 
 type service struct {
-	emailsRepository emailsRepositoryAdapterPort.Interface
+	emailsRepository       emailsRepositoryAdapterPort.Interface
+	blobStorage            blobsStorageAdapterPort.Interface
+	pdfRenderer            pdfRendererAdapterPort.Interface
+	trashRetention         time.Duration
+	dkimSelector           string
+	spfInclude             string
+	unverifiedDomainPolicy string
+	maxRecipients          int
+	recipientMode          string
+	attachmentsMaxSize     int
+	attachmentTypes        []string
+	assetsBaseUrl          string
+	assetsMaxSize          int
+	unsubscribeBaseUrl     string
+	unsubscribeSecret      []byte
+	webhookSecret          []byte
+	trackingBaseUrl        string
+	trackingSecret         []byte
+	reconcileWindow        time.Duration
+	reconcileBatchSize     int
+	reconcileRate          int
+	logger                 logger.Logger
 }
 
 // Folders
 
 func (s *service) CreateFolder(ctx context.Context, data emailsServicePort.CreateFolderData) (*emailsServicePort.FolderResult, error) {
+	// Check system access
+	if err := checkSystemAccess(ctx, data.SystemFlag); err != nil {
+		return nil, err
+	}
+
+	// Check parent folder exist
+	if err := s.checkFolderExist(ctx, data.ParentId); err != nil {
+		return nil, err
+	}
+
+	// Check sender exist
+	if err := s.checkSenderExist(ctx, data.SenderId); err != nil {
+		return nil, err
+	}
+
 	// Check folder exist
 	var parent *[]*uint
 	if data.ParentId != nil {
@@ -83,6 +171,11 @@ func (s *service) FilterFolders(ctx context.Context, data emailsServicePort.Filt
 }
 
 func (s *service) DeleteFolder(ctx context.Context, id uint) error {
+	// Check folder subtree has no system entities
+	if err := s.checkFolderDeletable(ctx, id); err != nil {
+		return err
+	}
+
 	// Delete folder
 	if err := s.emailsRepository.DeleteFolder(ctx, id); err != nil {
 		return err
@@ -92,6 +185,18 @@ func (s *service) DeleteFolder(ctx context.Context, id uint) error {
 }
 
 func (s *service) UpdateFolder(ctx context.Context, id uint, data map[string]any) error {
+	// Check sender exist
+	if senderId, ok := data["sender_id"].(*uint); ok {
+		if err := s.checkSenderExist(ctx, senderId); err != nil {
+			return err
+		}
+	}
+
+	// Check folder update
+	if err := s.checkFolderUpdate(ctx, id, data); err != nil {
+		return err
+	}
+
 	// Set folder data
 	data["updated"] = time.Unix(0, time.Now().UnixNano())
 
@@ -99,9 +204,230 @@ func (s *service) UpdateFolder(ctx context.Context, id uint, data map[string]any
 	return s.emailsRepository.UpdateFolder(ctx, id, data)
 }
 
+func (s *service) GetFolderTree(ctx context.Context, data emailsServicePort.FolderTreeData) (*[]emailsServicePort.FolderTreeResult, error) {
+	// Get folders
+	folders, err := s.emailsRepository.FilterFolders(
+		ctx,
+		emailsRepositoryAdapterPort.FilterFoldersData{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get emails count by folder
+	counts, err := s.emailsRepository.CountFolderEmails(ctx)
+	if err != nil {
+		return nil, err
+	}
+	emails := make(map[uint]uint, len(*counts))
+	for _, count := range *counts {
+		if count.FolderId != nil {
+			emails[*count.FolderId] = count.Count
+		}
+	}
+
+	// Index folders
+	byId := make(map[uint]emailsRepositoryAdapterPort.FolderResult, len(*folders))
+	children := make(map[uint][]emailsRepositoryAdapterPort.FolderResult)
+	for _, folder := range *folders {
+		byId[folder.Id] = folder
+		children[parentKey(folder.ParentId)] = append(children[parentKey(folder.ParentId)], folder)
+	}
+
+	// Build tree nodes with breadcrumbs from root
+	visited := make(map[uint]bool, len(*folders))
+	var build func(folder emailsRepositoryAdapterPort.FolderResult, breadcrumbs []emailsServicePort.FolderBreadcrumbResult) emailsServicePort.FolderTreeResult
+	build = func(folder emailsRepositoryAdapterPort.FolderResult, breadcrumbs []emailsServicePort.FolderBreadcrumbResult) emailsServicePort.FolderTreeResult {
+		visited[folder.Id] = true
+		breadcrumbs = append(
+			slices.Clone(breadcrumbs),
+			emailsServicePort.FolderBreadcrumbResult{
+				Id:   folder.Id,
+				Name: folder.Name,
+			},
+		)
+		names := make([]string, len(breadcrumbs))
+		for i, item := range breadcrumbs {
+			names[i] = item.Name
+		}
+		node := emailsServicePort.FolderTreeResult{
+			Id:          folder.Id,
+			ParentId:    folder.ParentId,
+			Name:        folder.Name,
+			Description: folder.Description,
+			SystemFlag:  folder.SystemFlag,
+			Path:        strings.Join(names, "/"),
+			Breadcrumbs: breadcrumbs,
+			Emails:      emails[folder.Id],
+			TotalEmails: emails[folder.Id],
+			Children:    []emailsServicePort.FolderTreeResult{},
+			Updated:     folder.Updated,
+			Created:     folder.Created,
+		}
+		items := children[folder.Id]
+		sort.Slice(items, func(i, j int) bool { return items[i].Name < items[j].Name })
+		for _, item := range items {
+			if visited[item.Id] {
+				continue
+			}
+			child := build(item, breadcrumbs)
+			node.TotalEmails += child.TotalEmails
+			node.Children = append(node.Children, child)
+		}
+		return node
+	}
+
+	// Set tree roots
+	var roots []emailsRepositoryAdapterPort.FolderResult
+	var breadcrumbs []emailsServicePort.FolderBreadcrumbResult
+	if data.RootId != nil {
+		folder, ok := byId[*data.RootId]
+		if !ok {
+			return nil, emailsServicePort.ErrFolderNotFound
+		}
+		roots = []emailsRepositoryAdapterPort.FolderResult{folder}
+
+		// Collect ancestors of subtree root
+		for parentId := folder.ParentId; parentId != nil && len(breadcrumbs) < len(byId); parentId = byId[*parentId].ParentId {
+			breadcrumbs = append(
+				[]emailsServicePort.FolderBreadcrumbResult{
+					{
+						Id:   *parentId,
+						Name: byId[*parentId].Name,
+					},
+				},
+				breadcrumbs...,
+			)
+		}
+	} else {
+		roots = children[0]
+		sort.Slice(roots, func(i, j int) bool { return roots[i].Name < roots[j].Name })
+	}
+
+	// Build tree
+	results := make([]emailsServicePort.FolderTreeResult, 0, len(roots))
+	for _, folder := range roots {
+		results = append(results, build(folder, breadcrumbs))
+	}
+
+	return &results, nil
+}
+
+func (s *service) MoveFolder(ctx context.Context, id uint, data emailsServicePort.MoveFolderData) error {
+	// Update folder parent
+	return s.UpdateFolder(
+		ctx,
+		id,
+		map[string]any{
+			"parent_id": data.ParentId,
+		},
+	)
+}
+
+// Check that the folder update keeps system folders protected and does not
+// create a cycle or a name conflict
+func (s *service) checkFolderUpdate(ctx context.Context, id uint, data map[string]any) error {
+	// Get folders
+	folders, err := s.emailsRepository.FilterFolders(
+		ctx,
+		emailsRepositoryAdapterPort.FilterFoldersData{},
+	)
+	if err != nil {
+		return err
+	}
+	byId := make(map[uint]emailsRepositoryAdapterPort.FolderResult, len(*folders))
+	for _, folder := range *folders {
+		byId[folder.Id] = folder
+	}
+
+	// Check folder exist
+	folder, ok := byId[id]
+	if !ok {
+		return emailsServicePort.ErrFolderNotFound
+	}
+
+	// Set new placement
+	parentId, parentSet := data["parent_id"].(*uint)
+	if !parentSet {
+		parentId = folder.ParentId
+	}
+	name, nameSet := data["name"].(*string)
+	if !nameSet || name == nil {
+		name = &folder.Name
+	}
+
+	// Check system folder protection
+	if folder.SystemFlag {
+		if *name != folder.Name || !systemMoveAllowed(byId, folder.ParentId, parentId) {
+			return emailsServicePort.ErrSystemEntity
+		}
+		for _, field := range []string{"sender_id", "description"} {
+			if _, ok := data[field]; ok {
+				if err := checkSystemAccess(ctx, true); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if !parentSet && !nameSet {
+		return nil
+	}
+
+	// Check parent exist and is not the folder or its descendant
+	for current, depth := parentId, 0; current != nil; current, depth = byId[*current].ParentId, depth+1 {
+		if _, ok := byId[*current]; !ok {
+			return emailsServicePort.ErrFolderNotFound
+		}
+		if *current == id || depth > len(byId) {
+			return emailsServicePort.ErrFolderCycle
+		}
+	}
+
+	// Check name is free in parent
+	for _, item := range *folders {
+		if item.Id != id && item.Name == *name && sameId(item.ParentId, parentId) {
+			return emailsServicePort.ErrFolderExist
+		}
+	}
+
+	return nil
+}
+
 // Emails
 
 func (s *service) CreateEmail(ctx context.Context, data emailsServicePort.CreateEmailData) (*emailsServicePort.EmailResult, error) {
+	// Check system access
+	if err := checkSystemAccess(ctx, data.SystemFlag); err != nil {
+		return nil, err
+	}
+
+	// Check folder exist
+	if err := s.checkFolderExist(ctx, data.FolderId); err != nil {
+		return nil, err
+	}
+
+	// Check sender exist
+	if err := s.checkSenderExist(ctx, data.SenderId); err != nil {
+		return nil, err
+	}
+
+	// Check email key exist
+	if err := s.checkEmailKeyFree(ctx, data.Key, nil); err != nil {
+		return nil, err
+	}
+
+	// Check headers
+	headers, err := normalizeHeaders(data.Headers)
+	if err != nil {
+		return nil, err
+	}
+	data.Headers = headers
+
+	// Check pdf attachments
+	if err := checkPdfAttachments(data.PdfAttachments); err != nil {
+		return nil, err
+	}
+
 	// Create email
 	email, err := s.emailsRepository.CreateEmail(
 		ctx,
@@ -139,7 +465,51 @@ func (s *service) FilterEmails(ctx context.Context, data emailsServicePort.Filte
 	return &results, nil
 }
 
+func (s *service) SearchEmails(ctx context.Context, data emailsServicePort.SearchEmailsData) (*[]emailsServicePort.SearchEmailResult, error) {
+	// Set limit
+	limit := uint(searchEmailsLimit)
+	if data.Limit != nil {
+		limit = *data.Limit
+	}
+
+	// Search emails
+	emails, err := s.emailsRepository.SearchEmails(
+		ctx,
+		emailsRepositoryAdapterPort.SearchEmailsData{
+			Query:      strings.TrimSpace(data.Query),
+			Id:         data.Id,
+			FolderId:   data.FolderId,
+			Key:        data.Key,
+			SystemFlag: data.SystemFlag,
+			Limit:      limit,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Map repository to service results
+	results := make([]emailsServicePort.SearchEmailResult, 0, len(*emails))
+	for _, email := range *emails {
+		results = append(
+			results,
+			emailsServicePort.SearchEmailResult(email),
+		)
+	}
+
+	return &results, nil
+}
+
 func (s *service) DeleteEmail(ctx context.Context, id uint) error {
+	// Check email is not a system email
+	email, err := s.getEmail(ctx, id)
+	if err != nil {
+		return err
+	}
+	if email.SystemFlag {
+		return emailsServicePort.ErrSystemEntity
+	}
+
 	// Delete email
 	if err := s.emailsRepository.DeleteEmail(ctx, id); err != nil {
 		return err
@@ -149,78 +519,511 @@ func (s *service) DeleteEmail(ctx context.Context, id uint) error {
 }
 
 func (s *service) UpdateEmail(ctx context.Context, id uint, data map[string]any) error {
-	// Set folder data
+	// Get email
+	email, err := s.getEmail(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Check folder exist
+	if folderId, ok := data["folder_id"].(*uint); ok {
+		if err := s.checkFolderExist(ctx, folderId); err != nil {
+			return err
+		}
+	}
+
+	// Check sender exist
+	if senderId, ok := data["sender_id"].(*uint); ok {
+		if err := s.checkSenderExist(ctx, senderId); err != nil {
+			return err
+		}
+	}
+
+	// Check system email protection
+	if email.SystemFlag {
+		if key, ok := data["key"].(*string); ok && key != nil && *key != email.Key {
+			return emailsServicePort.ErrSystemEntity
+		}
+		if folderId, ok := data["folder_id"].(*uint); ok {
+			byId, err := s.foldersById(ctx)
+			if err != nil {
+				return err
+			}
+			if !systemMoveAllowed(byId, email.FolderId, folderId) {
+				return emailsServicePort.ErrSystemEntity
+			}
+		}
+		for _, field := range systemEmailContent {
+			if _, ok := data[field]; ok {
+				if err := checkSystemAccess(ctx, true); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	// Check email key exist
+	if key, ok := data["key"].(*string); ok && key != nil {
+		if err := s.checkEmailKeyFree(ctx, *key, &id); err != nil {
+			return err
+		}
+	}
+
+	// Check headers
+	if headers, ok := data["headers"].(map[string]string); ok {
+		if data["headers"], err = normalizeHeaders(headers); err != nil {
+			return err
+		}
+	}
+
+	// Check pdf attachments
+	if templates, ok := data["pdf_attachments"].(map[string]string); ok {
+		if err := checkPdfAttachments(templates); err != nil {
+			return err
+		}
+	}
+
+	// Set email data
 	data["updated"] = time.Unix(0, time.Now().UnixNano())
 
 	// Update email
 	return s.emailsRepository.UpdateEmail(ctx, id, data)
 }
 
-func (s *service) SendCustom(ctx context.Context, data emailsServicePort.SendCustomData) (*emailsServicePort.EmailLogResult, error) {
-	// Send email
-	log, err := s.emailsRepository.Send(
+func (s *service) MoveEmails(ctx context.Context, data emailsServicePort.MoveEmailsData) error {
+	// Check folder exist
+	if err := s.checkFolderExist(ctx, data.FolderId); err != nil {
+		return err
+	}
+
+	// Check emails exist
+	ids := slices.Compact(slices.Sorted(slices.Values(data.Ids)))
+	emails, err := s.emailsRepository.FilterEmails(
+		ctx,
+		emailsRepositoryAdapterPort.FilterEmailsData{
+			Id: &ids,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if len(*emails) != len(ids) {
+		return emailsServicePort.ErrEmailNotFound
+	}
+
+	// Check system emails stay in system folders
+	var byId map[uint]emailsRepositoryAdapterPort.FolderResult
+	for _, email := range *emails {
+		if !email.SystemFlag {
+			continue
+		}
+		if byId == nil {
+			if byId, err = s.foldersById(ctx); err != nil {
+				return err
+			}
+		}
+		if !systemMoveAllowed(byId, email.FolderId, data.FolderId) {
+			return emailsServicePort.ErrSystemEntity
+		}
+	}
+
+	// Move emails
+	return s.emailsRepository.MoveEmails(
 		ctx,
-		emailsRepositoryAdapterPort.SendData(data),
+		emailsRepositoryAdapterPort.MoveEmailsData{
+			Ids:      ids,
+			FolderId: data.FolderId,
+		},
 	)
+}
+
+func (s *service) SendCustom(ctx context.Context, data emailsServicePort.SendCustomData) (*[]emailsServicePort.EmailLogResult, error) {
+	// Split recipients into messages
+	messages, err := s.splitRecipients(data.To, data.Cc, data.Bcc, false)
 	if err != nil {
 		return nil, err
 	}
 
-	// Map repository to service results
-	results := emailsServicePort.EmailLogResult(*log)
+	// Generate text from html
+	if data.Text == "" {
+		text, err := htmlToText(data.Html)
+		if err != nil {
+			return nil, err
+		}
+		data.Text = text
+	}
 
-	return &results, nil
+	// Get approved sender
+	sender, err := s.approvedSender(ctx, data.FromEmail, data.FromName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check sender domain
+	if err := s.checkSenderDomain(ctx, sender.Email); err != nil {
+		return nil, err
+	}
+
+	// Check headers
+	headers, err := normalizeHeaders(data.Headers)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reply-to of the request overrides the sender reply-to
+	replyTo := sender.ReplyTo
+	if data.ReplyTo != "" {
+		replyTo = data.ReplyTo
+	}
+
+	// Resolve attachments
+	attachments, err := s.resolveAttachments(ctx, data.Attachments)
+	if err != nil {
+		return nil, err
+	}
+
+	// Embed referenced assets
+	inline, err := s.inlineAssets(ctx, data.Html)
+	if err != nil {
+		return nil, err
+	}
+
+	// Prepare messages
+	outgoing := make([]outgoingEmail, 0, len(messages))
+	for _, message := range messages {
+		// Split suppressed recipients
+		sent, suppressed, reason, err := s.splitSuppressed(ctx, message)
+		if err != nil {
+			return nil, err
+		}
+		if suppressed != nil {
+			outgoing = append(
+				outgoing,
+				outgoingEmail{
+					data: emailsRepositoryAdapterPort.SendData{
+						FromEmail: sender.Email,
+						FromName:  sender.Name,
+						Subject:   data.Subject,
+						To:        suppressed.To,
+						Cc:        suppressed.Cc,
+						Bcc:       suppressed.Bcc,
+						Html:      data.Html,
+						Text:      data.Text,
+					},
+					reason: reason,
+				},
+			)
+		}
+		if sent == nil {
+			continue
+		}
+		outgoing = append(
+			outgoing,
+			outgoingEmail{
+				data: emailsRepositoryAdapterPort.SendData{
+					FromEmail:   sender.Email,
+					FromName:    sender.Name,
+					ReplyTo:     replyTo,
+					Headers:     headers,
+					Subject:     data.Subject,
+					To:          sent.To,
+					Cc:          sent.Cc,
+					Bcc:         sent.Bcc,
+					Html:        data.Html,
+					Text:        data.Text,
+					Attachments: attachments,
+					Inline:      inline,
+				},
+			},
+		)
+	}
+
+	// Send messages
+	return s.sendEmails(ctx, outgoing)
 }
 
-func (s *service) Send(ctx context.Context, data emailsServicePort.SendData) (*emailsServicePort.EmailLogResult, error) {
+func (s *service) Send(ctx context.Context, data emailsServicePort.SendData) (*[]emailsServicePort.EmailLogResult, error) {
 	// Get email
-	emails, err := s.emailsRepository.FilterEmails(
-		ctx,
-		emailsRepositoryAdapterPort.FilterEmailsData{
-			Id: &[]uint{data.EmailId},
-		},
-	)
+	email, err := s.getEmail(ctx, data.EmailId)
 	if err != nil {
 		return nil, err
 	}
-	if len(*emails) == 0 {
-		return nil, emailsServicePort.ErrEmailNotFound
+
+	// Split recipients into messages, every recipient of a category gets own unsubscribe link
+	messages, err := s.splitRecipients(data.To, data.Cc, data.Bcc, email.Category != nil)
+	if err != nil {
+		return nil, err
 	}
 
-	// Render template
-	subject, err := s.renderTemplate((*emails)[0].Subject, data.Vars)
+	// Get sender
+	sender, err := s.emailSender(ctx, *email)
 	if err != nil {
 		return nil, err
 	}
-	html, err := s.renderTemplate((*emails)[0].Html, data.Vars)
+
+	// Check sender domain
+	if err := s.checkSenderDomain(ctx, sender.Email); err != nil {
+		return nil, err
+	}
+
+	// Resolve attachments
+	attachments, err := s.resolveAttachments(ctx, data.Attachments)
 	if err != nil {
 		return nil, err
 	}
-	text, err := s.renderTemplate((*emails)[0].Text, data.Vars)
+
+	// Prepare calendar event, messages of the request share its sequence
+	var event *calendarEvent
+	if data.Event != nil {
+		if event, err = s.prepareEvent(ctx, *data.Event, sender.Email); err != nil {
+			return nil, err
+		}
+	}
+
+	// Prepare messages, all of them are rendered before the first one is sent
+	outgoing := make([]outgoingEmail, 0, len(messages))
+	for _, message := range messages {
+		// Split suppressed recipients
+		sent, suppressed, reason, err := s.splitSuppressed(ctx, message)
+		if err != nil {
+			return nil, err
+		}
+		if suppressed != nil {
+			item, err := s.suppressEmail(*email, *sender, *suppressed, data.Vars, reason)
+			if err != nil {
+				return nil, err
+			}
+			outgoing = append(outgoing, *item)
+		}
+		if sent == nil {
+			continue
+		}
+
+		// Render message
+		item, err := s.prepareEmail(ctx, *email, *sender, *sent, data.Vars, attachments, event)
+		if err != nil {
+			return nil, err
+		}
+		outgoing = append(outgoing, *item)
+	}
+
+	// Send messages
+	return s.sendEmails(ctx, outgoing)
+}
+
+// Render the email for the recipients of the message
+func (s *service) prepareEmail(ctx context.Context, email emailsRepositoryAdapterPort.EmailResult, sender emailsRepositoryAdapterPort.SenderResult, message recipientSet, vars *json.RawMessage, attachments []emailsRepositoryAdapterPort.AttachmentData, event *calendarEvent) (*outgoingEmail, error) {
+	// Suppress emails of the category to recipients who opted out
+	if email.Category != nil {
+		unsubscribed, err := s.isUnsubscribed(ctx, message.To[0], *email.Category)
+		if err != nil {
+			return nil, err
+		}
+		if unsubscribed {
+			return s.suppressEmail(email, sender, message, vars, suppressedUnsubscribed)
+		}
+	}
+
+	// Pick experiment variant for the first recipient
+	variant, err := s.pickVariant(ctx, email.Id, recipientAddress(message.To[0]))
 	if err != nil {
 		return nil, err
 	}
+	var variantId *uint
+	if variant != nil {
+		variantId = &variant.Id
+	}
 
-	// Send email
-	log, err := s.emailsRepository.Send(
-		ctx,
-		emailsRepositoryAdapterPort.SendData{
-			FromEmail: (*emails)[0].FromEmail,
-			FromName:  (*emails)[0].FromName,
-			Subject:   *subject,
-			ToEmail:   data.ToEmail,
-			Html:      *html,
-			Text:      *text,
+	// Render email
+	rendered, err := s.renderEmail(ctx, email, variant, sender.ReplyTo, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generated attachments follow the attachments of the request
+	attachments = append(slices.Clone(attachments), rendered.Attachments...)
+	if err := s.checkAttachmentsSize(attachments); err != nil {
+		return nil, err
+	}
+
+	// Add unsubscribe link of the recipient
+	if email.Category != nil {
+		rendered.Headers = s.unsubscribeHeaders(rendered.Headers, message.To[0], *email.Category)
+	}
+
+	// Build calendar event for the recipients
+	var calendar *emailsRepositoryAdapterPort.CalendarData
+	if event != nil {
+		calendar = event.build(rendered.Subject, sender, message, time.Now())
+	}
+
+	return &outgoingEmail{
+		data: emailsRepositoryAdapterPort.SendData{
+			EmailId:     &email.Id,
+			VariantId:   variantId,
+			FromEmail:   sender.Email,
+			FromName:    sender.Name,
+			ReplyTo:     rendered.ReplyTo,
+			Headers:     rendered.Headers,
+			Subject:     rendered.Subject,
+			To:          message.To,
+			Cc:          message.Cc,
+			Bcc:         message.Bcc,
+			Html:        rendered.Html,
+			Text:        rendered.Text,
+			Attachments: attachments,
+			Inline:      rendered.Inline,
+			Calendar:    calendar,
 		},
-	)
+		tracking: emailTracking{
+			Opens:  email.TrackOpens,
+			Clicks: email.TrackClicks,
+		},
+	}, nil
+}
+
+// Email rendered with vars
+type renderedEmail struct {
+	Subject     string
+	Html        string
+	Text        string
+	ReplyTo     string
+	Headers     map[string]string
+	Attachments []emailsRepositoryAdapterPort.AttachmentData
+	Inline      []emailsRepositoryAdapterPort.InlineData
+}
+
+// Render the email or its variant with vars
+func (s *service) renderEmail(ctx context.Context, email emailsRepositoryAdapterPort.EmailResult, variant *emailsRepositoryAdapterPort.VariantResult, senderReplyTo string, vars *json.RawMessage) (*renderedEmail, error) {
+	// Set templates
+	subjectTemplate := email.Subject
+	htmlTemplate := email.Html
+	textTemplate := email.Text
+	if variant != nil {
+		if variant.Subject != nil {
+			subjectTemplate = *variant.Subject
+		}
+		if variant.Html != nil {
+			htmlTemplate = *variant.Html
+		}
+		if variant.Text != nil {
+			textTemplate = *variant.Text
+		}
+	}
+
+	// Render template
+	subject, err := s.renderTemplate(subjectTemplate, vars)
+	if err != nil {
+		return nil, err
+	}
+	html, err := s.renderTemplate(htmlTemplate, vars)
+	if err != nil {
+		return nil, err
+	}
+	text, err := s.renderTemplate(textTemplate, vars)
+	if err != nil {
+		return nil, err
+	}
+	replyTo, err := s.renderReplyTo(email.ReplyTo, senderReplyTo, vars)
+	if err != nil {
+		return nil, err
+	}
+	headers, err := s.renderHeaders(email.Headers, vars)
 	if err != nil {
 		return nil, err
 	}
 
-	// Map repository to service results
-	results := emailsServicePort.EmailLogResult(*log)
+	// Generate text from rendered html
+	if *text == "" {
+		generated, err := htmlToText(*html)
+		if err != nil {
+			return nil, err
+		}
+		text = &generated
+	}
 
-	return &results, nil
+	// Embed referenced assets
+	inline, err := s.inlineAssets(ctx, *html)
+	if err != nil {
+		return nil, err
+	}
+
+	// Render pdf attachments
+	attachments, err := s.renderPdfAttachments(email.PdfAttachments, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	return &renderedEmail{
+		Subject:     *subject,
+		Html:        *html,
+		Text:        *text,
+		ReplyTo:     replyTo,
+		Headers:     headers,
+		Attachments: attachments,
+		Inline:      inline,
+	}, nil
+}
+
+func (s *service) PreviewEmail(ctx context.Context, data emailsServicePort.PreviewEmailData) (*emailsServicePort.PreviewResult, error) {
+	// Get email
+	email, err := s.getEmail(ctx, data.EmailId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get sender
+	sender, err := s.emailSender(ctx, *email)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get variant
+	var variant *emailsRepositoryAdapterPort.VariantResult
+	if data.VariantId != nil {
+		variants, err := s.emailsRepository.FilterVariants(
+			ctx,
+			emailsRepositoryAdapterPort.FilterVariantsData{
+				Id:      &[]uint{*data.VariantId},
+				EmailId: &[]uint{email.Id},
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		if len(*variants) == 0 {
+			return nil, emailsServicePort.ErrVariantNotFound
+		}
+		variant = &(*variants)[0]
+	}
+
+	// Render email
+	rendered, err := s.renderEmail(ctx, *email, variant, sender.ReplyTo, data.Vars)
+	if err != nil {
+		return nil, err
+	}
+
+	// Map rendered attachments to service results
+	attachments := make([]emailsServicePort.PreviewAttachmentResult, len(rendered.Attachments))
+	for i, item := range rendered.Attachments {
+		attachments[i] = emailsServicePort.PreviewAttachmentResult{
+			Filename:    item.Filename,
+			ContentType: item.ContentType,
+			Size:        int64(len(item.Content)),
+			Content:     item.Content,
+		}
+	}
+
+	return &emailsServicePort.PreviewResult{
+		FromEmail:   sender.Email,
+		FromName:    sender.Name,
+		ReplyTo:     rendered.ReplyTo,
+		Headers:     rendered.Headers,
+		Subject:     rendered.Subject,
+		Html:        rendered.Html,
+		Text:        rendered.Text,
+		Attachments: attachments,
+	}, nil
 }
 
 func (s *service) FilterEmailLogs(ctx context.Context, data emailsServicePort.FilterEmailLogsData) (*[]emailsServicePort.EmailLogResult, error) {
@@ -238,13 +1041,285 @@ func (s *service) FilterEmailLogs(ctx context.Context, data emailsServicePort.Fi
 	for _, log := range *logs {
 		results = append(
 			results,
-			emailsServicePort.EmailLogResult(log),
+			emailLogResult(log),
+		)
+	}
+
+	return &results, nil
+}
+
+// Variants
+
+func (s *service) CreateVariant(ctx context.Context, data emailsServicePort.CreateVariantData) (*emailsServicePort.VariantResult, error) {
+	// Check email exist
+	email, err := s.getEmail(ctx, data.EmailId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check system access
+	if err := checkSystemAccess(ctx, email.SystemFlag); err != nil {
+		return nil, err
+	}
+
+	// Create variant
+	variant, err := s.emailsRepository.CreateVariant(
+		ctx,
+		emailsRepositoryAdapterPort.CreateVariantData(data),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Map repository to service results
+	results := emailsServicePort.VariantResult(*variant)
+
+	return &results, nil
+}
+
+func (s *service) FilterVariants(ctx context.Context, data emailsServicePort.FilterVariantsData) (*[]emailsServicePort.VariantResult, error) {
+	// Filter variants
+	variants, err := s.emailsRepository.FilterVariants(
+		ctx,
+		emailsRepositoryAdapterPort.FilterVariantsData(data),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Map repository to service results
+	results := make([]emailsServicePort.VariantResult, 0, len(*variants))
+	for _, variant := range *variants {
+		results = append(
+			results,
+			emailsServicePort.VariantResult(variant),
 		)
 	}
 
 	return &results, nil
 }
 
+func (s *service) DeleteVariant(ctx context.Context, id uint) error {
+	// Check system access
+	if err := s.checkVariantAccess(ctx, id); err != nil {
+		return err
+	}
+
+	// Delete variant
+	if err := s.emailsRepository.DeleteVariant(ctx, id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *service) UpdateVariant(ctx context.Context, id uint, data map[string]any) error {
+	// Check system access
+	if err := s.checkVariantAccess(ctx, id); err != nil {
+		return err
+	}
+
+	// Set variant data
+	data["updated"] = time.Unix(0, time.Now().UnixNano())
+
+	// Update variant
+	return s.emailsRepository.UpdateVariant(ctx, id, data)
+}
+
+func (s *service) GetVariantReport(ctx context.Context, emailId uint) (*[]emailsServicePort.VariantReportResult, error) {
+	// Check email exist
+	if err := s.checkEmailExist(ctx, emailId); err != nil {
+		return nil, err
+	}
+
+	// Get variants
+	variants, err := s.emailsRepository.FilterVariants(
+		ctx,
+		emailsRepositoryAdapterPort.FilterVariantsData{
+			EmailId: &[]uint{emailId},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get variant stats
+	stats, err := s.emailsRepository.GetVariantStats(ctx, emailId)
+	if err != nil {
+		return nil, err
+	}
+	statsMap := make(map[uint]emailsRepositoryAdapterPort.VariantStatsResult, len(*stats))
+	for _, item := range *stats {
+		statsMap[item.VariantId] = item
+	}
+
+	// Build report
+	results := make([]emailsServicePort.VariantReportResult, 0, len(*variants))
+	for _, variant := range *variants {
+		item := statsMap[variant.Id]
+		report := emailsServicePort.VariantReportResult{
+			VariantId:    variant.Id,
+			Name:         variant.Name,
+			Weight:       variant.Weight,
+			Active:       variant.Active,
+			Sent:         item.Sent,
+			Delivered:    item.Delivered,
+			Failed:       item.Failed,
+			UniqueOpens:  item.UniqueOpens,
+			UniqueClicks: item.UniqueClicks,
+		}
+		if item.Sent > 0 {
+			report.DeliveryRate = float64(item.Delivered) / float64(item.Sent)
+			report.OpenRate = float64(item.UniqueOpens) / float64(item.Sent)
+			report.ClickRate = float64(item.UniqueClicks) / float64(item.Sent)
+		}
+		results = append(results, report)
+	}
+
+	return &results, nil
+}
+
+func (s *service) EndExperiment(ctx context.Context, emailId uint, data emailsServicePort.EndExperimentData) error {
+	// Check email exist
+	current, err := s.getEmail(ctx, emailId)
+	if err != nil {
+		return err
+	}
+
+	// Check system access
+	if err := checkSystemAccess(ctx, current.SystemFlag); err != nil {
+		return err
+	}
+
+	// Set winner content
+	email := make(map[string]any)
+	if data.WinnerId != nil {
+		variants, err := s.emailsRepository.FilterVariants(
+			ctx,
+			emailsRepositoryAdapterPort.FilterVariantsData{
+				Id:      &[]uint{*data.WinnerId},
+				EmailId: &[]uint{emailId},
+			},
+		)
+		if err != nil {
+			return err
+		}
+		if len(*variants) == 0 {
+			return emailsServicePort.ErrVariantNotFound
+		}
+		winner := (*variants)[0]
+		if winner.Subject != nil {
+			email["subject"] = *winner.Subject
+		}
+		if winner.Html != nil {
+			email["html"] = *winner.Html
+		}
+		if winner.Text != nil {
+			email["text"] = *winner.Text
+		}
+		if len(email) > 0 {
+			email["updated"] = time.Unix(0, time.Now().UnixNano())
+		}
+	}
+
+	// Deactivate variants and promote winner
+	return s.emailsRepository.EndExperiment(ctx, emailId, email)
+}
+
+// Check that the folder exists and is not in trash, top level is always valid
+func (s *service) checkFolderExist(ctx context.Context, id *uint) error {
+	if id == nil {
+		return nil
+	}
+	folders, err := s.emailsRepository.FilterFolders(
+		ctx,
+		emailsRepositoryAdapterPort.FilterFoldersData{
+			Id: &[]uint{*id},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if len(*folders) == 0 {
+		return emailsServicePort.ErrFolderNotFound
+	}
+	return nil
+}
+
+func (s *service) checkEmailExist(ctx context.Context, id uint) error {
+	emails, err := s.emailsRepository.FilterEmails(
+		ctx,
+		emailsRepositoryAdapterPort.FilterEmailsData{
+			Id: &[]uint{id},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if len(*emails) == 0 {
+		return emailsServicePort.ErrEmailNotFound
+	}
+	return nil
+}
+
+func (s *service) checkEmailKeyFree(ctx context.Context, key string, exceptId *uint) error {
+	emails, err := s.emailsRepository.FilterEmails(
+		ctx,
+		emailsRepositoryAdapterPort.FilterEmailsData{
+			Key: &[]string{key},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	for _, email := range *emails {
+		if exceptId == nil || email.Id != *exceptId {
+			return emailsServicePort.ErrEmailExist
+		}
+	}
+	return nil
+}
+
+// Pick active variant deterministically by recipient hash
+func (s *service) pickVariant(ctx context.Context, emailId uint, toEmail string) (*emailsRepositoryAdapterPort.VariantResult, error) {
+	// Get active variants
+	active := true
+	variants, err := s.emailsRepository.FilterVariants(
+		ctx,
+		emailsRepositoryAdapterPort.FilterVariantsData{
+			EmailId: &[]uint{emailId},
+			Active:  &active,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sum weights
+	var total uint64
+	for _, variant := range *variants {
+		total += uint64(variant.Weight)
+	}
+	if total == 0 {
+		return nil, nil
+	}
+
+	// Hash recipient
+	hash := fnv.New64a()
+	hash.Write([]byte(strconv.FormatUint(uint64(emailId), 10) + ":" + strings.ToLower(strings.TrimSpace(toEmail))))
+	point := hash.Sum64() % total
+
+	// Find variant bucket
+	for i, variant := range *variants {
+		if point < uint64(variant.Weight) {
+			return &(*variants)[i], nil
+		}
+		point -= uint64(variant.Weight)
+	}
+
+	return nil, nil
+}
+
 func (s *service) renderTemplate(templateContent string, vars *json.RawMessage) (*string, error) {
 	// No vars
 	if vars == nil {
@@ -272,3 +1347,50 @@ func (s *service) renderTemplate(templateContent string, vars *json.RawMessage)
 	result := sb.String()
 	return &result, nil
 }
+
+func emailLogResult(log emailsRepositoryAdapterPort.EmailLogResult) emailsServicePort.EmailLogResult {
+	attachments := make([]emailsServicePort.EmailLogAttachmentResult, len(log.Attachments))
+	for i, item := range log.Attachments {
+		attachments[i] = emailsServicePort.EmailLogAttachmentResult(item)
+	}
+	events := make([]emailsServicePort.DeliveryEventResult, len(log.Events))
+	for i, item := range log.Events {
+		events[i] = emailsServicePort.DeliveryEventResult(item)
+	}
+	transitions := make([]emailsServicePort.EmailLogTransitionResult, len(log.Transitions))
+	for i, item := range log.Transitions {
+		transitions[i] = emailsServicePort.EmailLogTransitionResult(item)
+	}
+	opens := make([]emailsServicePort.EmailOpenResult, len(log.Opens))
+	for i, item := range log.Opens {
+		opens[i] = emailsServicePort.EmailOpenResult(item)
+	}
+	clicks := make([]emailsServicePort.EmailClickResult, len(log.Clicks))
+	for i, item := range log.Clicks {
+		clicks[i] = emailsServicePort.EmailClickResult(item)
+	}
+	return emailsServicePort.EmailLogResult{
+		Id:          log.Id,
+		EmailId:     log.EmailId,
+		VariantId:   log.VariantId,
+		FromEmail:   log.FromEmail,
+		FromName:    log.FromName,
+		Subject:     log.Subject,
+		To:          log.To,
+		Cc:          log.Cc,
+		Bcc:         log.Bcc,
+		Html:        log.Html,
+		Text:        log.Text,
+		Status:      log.Status,
+		MessageId:   log.MessageId,
+		Errors:      log.Errors,
+		Attachments: attachments,
+		EventUid:    log.EventUid,
+		Links:       log.Links,
+		Events:      events,
+		Transitions: transitions,
+		Opens:       opens,
+		Clicks:      clicks,
+		Created:     log.Created,
+	}
+}
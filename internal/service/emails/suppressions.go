@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	emailsRepositoryAdapterPort "github.com/flash-go/notifications-service/internal/port/adapter/repository/emails"
+	emailsServicePort "github.com/flash-go/notifications-service/internal/port/service/emails"
+)
+
+// Sources of suppressions
+const (
+	suppressionSourceManual = "manual"
+	suppressionSourceImport = "import"
+)
+
+func (s *service) CreateSuppression(ctx context.Context, data emailsServicePort.CreateSuppressionData) (*emailsServicePort.SuppressionResult, error) {
+	address := recipientAddress(data.Address)
+
+	// Check suppression exist
+	suppressions, err := s.emailsRepository.FilterSuppressions(
+		ctx,
+		emailsRepositoryAdapterPort.FilterSuppressionsData{
+			Address: &[]string{address},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(*suppressions) > 0 {
+		return nil, emailsServicePort.ErrSuppressionExist
+	}
+
+	// Create suppression
+	suppression, err := s.emailsRepository.CreateSuppression(
+		ctx,
+		emailsRepositoryAdapterPort.CreateSuppressionData{
+			Address: address,
+			Reason:  data.Reason,
+			Source:  suppressionSourceManual,
+			Expires: data.Expires,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Map repository to service results
+	results := emailsServicePort.SuppressionResult(*suppression)
+
+	return &results, nil
+}
+
+func (s *service) FilterSuppressions(ctx context.Context, data emailsServicePort.FilterSuppressionsData) (*[]emailsServicePort.SuppressionResult, error) {
+	// Filter suppressions
+	suppressions, err := s.emailsRepository.FilterSuppressions(
+		ctx,
+		emailsRepositoryAdapterPort.FilterSuppressionsData(data),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Map repository to service results
+	results := make([]emailsServicePort.SuppressionResult, 0, len(*suppressions))
+	for _, suppression := range *suppressions {
+		results = append(
+			results,
+			emailsServicePort.SuppressionResult(suppression),
+		)
+	}
+
+	return &results, nil
+}
+
+func (s *service) DeleteSuppression(ctx context.Context, id uint) error {
+	// Delete suppression
+	return s.emailsRepository.DeleteSuppression(ctx, id)
+}
+
+func (s *service) UpdateSuppression(ctx context.Context, id uint, data map[string]any) error {
+	// Set suppression data
+	data["updated"] = time.Unix(0, time.Now().UnixNano())
+
+	// Update suppression
+	return s.emailsRepository.UpdateSuppression(ctx, id, data)
+}
+
+func (s *service) ImportSuppressions(ctx context.Context, data emailsServicePort.ImportSuppressionsData) (*emailsServicePort.ImportSuppressionsResult, error) {
+	// Normalize addresses, the last entry of a repeated address wins
+	index := make(map[string]int, len(data.Suppressions))
+	items := make([]emailsRepositoryAdapterPort.CreateSuppressionData, 0, len(data.Suppressions))
+	for _, suppression := range data.Suppressions {
+		item := emailsRepositoryAdapterPort.CreateSuppressionData{
+			Address: recipientAddress(suppression.Address),
+			Reason:  suppression.Reason,
+			Source:  suppressionSourceImport,
+			Expires: suppression.Expires,
+		}
+		if i, ok := index[item.Address]; ok {
+			items[i] = item
+			continue
+		}
+		index[item.Address] = len(items)
+		items = append(items, item)
+	}
+
+	// Import suppressions
+	imported, err := s.emailsRepository.ImportSuppressions(ctx, items)
+	if err != nil {
+		return nil, err
+	}
+
+	return &emailsServicePort.ImportSuppressionsResult{
+		Imported: imported,
+	}, nil
+}
+
+// Split recipients of the message into recipients to send to and suppressed recipients with their reasons,
+// the message is suppressed whole when all its to recipients are suppressed
+func (s *service) splitSuppressed(ctx context.Context, message recipientSet) (*recipientSet, *recipientSet, string, error) {
+	// Get active suppressions of the recipients
+	var addresses []string
+	for _, list := range [][]string{message.To, message.Cc, message.Bcc} {
+		for _, item := range list {
+			addresses = append(addresses, recipientAddress(item))
+		}
+	}
+	active := true
+	suppressions, err := s.emailsRepository.FilterSuppressions(
+		ctx,
+		emailsRepositoryAdapterPort.FilterSuppressionsData{
+			Address: &addresses,
+			Active:  &active,
+		},
+	)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if len(*suppressions) == 0 {
+		return &message, nil, "", nil
+	}
+	reasons := make(map[string]string, len(*suppressions))
+	for _, suppression := range *suppressions {
+		reasons[suppression.Address] = suppression.Reason
+	}
+
+	// Split recipient lists
+	var found []string
+	split := func(list []string) ([]string, []string) {
+		var sent, suppressed []string
+		for _, item := range list {
+			reason, ok := reasons[recipientAddress(item)]
+			if !ok {
+				sent = append(sent, item)
+				continue
+			}
+			suppressed = append(suppressed, item)
+			if !slices.Contains(found, reason) {
+				found = append(found, reason)
+			}
+		}
+		return sent, suppressed
+	}
+	var sent, suppressed recipientSet
+	sent.To, suppressed.To = split(message.To)
+	sent.Cc, suppressed.Cc = split(message.Cc)
+	sent.Bcc, suppressed.Bcc = split(message.Bcc)
+	slices.Sort(found)
+	reason := strings.Join(found, ",")
+
+	// Message without to recipients is not sent
+	if len(sent.To) == 0 {
+		return nil, &message, reason, nil
+	}
+
+	return &sent, &suppressed, reason, nil
+}
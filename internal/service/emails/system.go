@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+
+	emailsRepositoryAdapterPort "github.com/flash-go/notifications-service/internal/port/adapter/repository/emails"
+	emailsServicePort "github.com/flash-go/notifications-service/internal/port/service/emails"
+)
+
+// Fields of system emails that require system access to edit
+var systemEmailContent = []string{"sender_id", "subject", "html", "text", "reply_to", "headers", "pdf_attachments", "category", "track_opens", "track_clicks", "description"}
+
+// Check that content edits of system entities are allowed within the context
+func checkSystemAccess(ctx context.Context, system bool) error {
+	if system && !emailsServicePort.HasSystemAccess(ctx) {
+		return emailsServicePort.ErrSystemAccess
+	}
+	return nil
+}
+
+// Top level is not a system folder
+func isSystemFolder(byId map[uint]emailsRepositoryAdapterPort.FolderResult, id *uint) bool {
+	return id != nil && byId[*id].SystemFlag
+}
+
+// System entities may leave a system folder only for another system folder
+func systemMoveAllowed(byId map[uint]emailsRepositoryAdapterPort.FolderResult, from, to *uint) bool {
+	return sameId(from, to) || !isSystemFolder(byId, from) || isSystemFolder(byId, to)
+}
+
+func (s *service) foldersById(ctx context.Context) (map[uint]emailsRepositoryAdapterPort.FolderResult, error) {
+	folders, err := s.emailsRepository.FilterFolders(
+		ctx,
+		emailsRepositoryAdapterPort.FilterFoldersData{},
+	)
+	if err != nil {
+		return nil, err
+	}
+	byId := make(map[uint]emailsRepositoryAdapterPort.FolderResult, len(*folders))
+	for _, folder := range *folders {
+		byId[folder.Id] = folder
+	}
+	return byId, nil
+}
+
+func (s *service) getEmail(ctx context.Context, id uint) (*emailsRepositoryAdapterPort.EmailResult, error) {
+	emails, err := s.emailsRepository.FilterEmails(
+		ctx,
+		emailsRepositoryAdapterPort.FilterEmailsData{
+			Id: &[]uint{id},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(*emails) == 0 {
+		return nil, emailsServicePort.ErrEmailNotFound
+	}
+	return &(*emails)[0], nil
+}
+
+// Check system access to the email of the variant
+func (s *service) checkVariantAccess(ctx context.Context, id uint) error {
+	variants, err := s.emailsRepository.FilterVariants(
+		ctx,
+		emailsRepositoryAdapterPort.FilterVariantsData{
+			Id: &[]uint{id},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if len(*variants) == 0 {
+		return emailsServicePort.ErrVariantNotFound
+	}
+	email, err := s.getEmail(ctx, (*variants)[0].EmailId)
+	if err != nil {
+		return err
+	}
+	return checkSystemAccess(ctx, email.SystemFlag)
+}
+
+// Check that the folder subtree contains no system folders or emails
+func (s *service) checkFolderDeletable(ctx context.Context, id uint) error {
+	byId, err := s.foldersById(ctx)
+	if err != nil {
+		return err
+	}
+	if _, ok := byId[id]; !ok {
+		return emailsServicePort.ErrFolderNotFound
+	}
+
+	// Index folders by parent
+	children := make(map[uint][]uint)
+	for _, folder := range byId {
+		if folder.ParentId != nil {
+			children[*folder.ParentId] = append(children[*folder.ParentId], folder.Id)
+		}
+	}
+
+	// Collect subtree
+	visited := map[uint]bool{id: true}
+	subtree := []*uint{&id}
+	for i := 0; i < len(subtree); i++ {
+		current := *subtree[i]
+		if byId[current].SystemFlag {
+			return emailsServicePort.ErrSystemEntity
+		}
+		for _, child := range children[current] {
+			if !visited[child] {
+				visited[child] = true
+				subtree = append(subtree, &child)
+			}
+		}
+	}
+
+	// Check system emails in subtree
+	system := true
+	emails, err := s.emailsRepository.FilterEmails(
+		ctx,
+		emailsRepositoryAdapterPort.FilterEmailsData{
+			FolderId:   &subtree,
+			SystemFlag: &system,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if len(*emails) > 0 {
+		return emailsServicePort.ErrSystemEntity
+	}
+
+	return nil
+}
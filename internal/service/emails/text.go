@@ -0,0 +1,408 @@
+package service
+
+import (
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Convert rendered HTML into a readable plain text alternative
+func htmlToText(content string) (string, error) {
+	// Parse html document
+	doc, err := html.Parse(strings.NewReader(content))
+	if err != nil {
+		return "", err
+	}
+
+	// Render document
+	w := &textWriter{}
+	w.walk(doc)
+
+	return w.String(), nil
+}
+
+type textWriter struct {
+	lines        []string
+	line         strings.Builder
+	linePrefixes []string
+	started      bool
+	space        bool
+	breaks       int
+	prefixes     []string
+	marker       string
+	pre          int
+}
+
+// Request line breaks before the next content
+func (w *textWriter) lineBreak(n int) {
+	if n > w.breaks {
+		w.breaks = n
+	}
+}
+
+// Start a new line if content was requested to break
+func (w *textWriter) startLine() {
+	if w.started && w.breaks == 0 {
+		return
+	}
+
+	// Flush current line
+	if w.started {
+		w.lines = append(w.lines, strings.TrimRight(w.line.String(), " "))
+		w.line.Reset()
+	}
+	if w.started || len(w.lines) > 0 {
+		blank := w.blankLine()
+		for i := 1; i < w.breaks; i++ {
+			w.lines = append(w.lines, blank)
+		}
+	}
+
+	// Write line prefix
+	if w.marker != "" {
+		w.line.WriteString(strings.Join(w.prefixes[:len(w.prefixes)-1], ""))
+		w.line.WriteString(w.marker)
+		w.marker = ""
+	} else {
+		w.line.WriteString(strings.Join(w.prefixes, ""))
+	}
+	w.linePrefixes = append(w.linePrefixes[:0], w.prefixes...)
+
+	w.started = true
+	w.space = false
+	w.breaks = 0
+}
+
+// Blank line between the current line and the next one, quoted if both lines are in the same quote
+func (w *textWriter) blankLine() string {
+	n := 0
+	for n < len(w.prefixes) && n < len(w.linePrefixes) && w.prefixes[n] == w.linePrefixes[n] {
+		n++
+	}
+	return strings.TrimRight(strings.Join(w.prefixes[:n], ""), " ")
+}
+
+// Write inline text with collapsed whitespace
+func (w *textWriter) text(s string) {
+	if s == "" {
+		return
+	}
+
+	leading := strings.TrimLeft(s, " \t\r\n\f") != s
+	trailing := strings.TrimRight(s, " \t\r\n\f") != s
+	words := strings.Fields(s)
+
+	if len(words) == 0 {
+		w.space = w.started
+		return
+	}
+
+	if w.started && w.breaks == 0 && (leading || w.space) {
+		w.line.WriteByte(' ')
+	}
+	w.startLine()
+
+	w.line.WriteString(strings.Join(words, " "))
+	w.space = trailing
+}
+
+// Write text verbatim line by line
+func (w *textWriter) raw(s string) {
+	for i, part := range strings.Split(s, "\n") {
+		if i > 0 {
+			w.lineBreak(1)
+		}
+		w.startLine()
+		w.line.WriteString(part)
+	}
+}
+
+func (w *textWriter) String() string {
+	if w.started {
+		w.lines = append(w.lines, strings.TrimRight(w.line.String(), " "))
+	}
+	return strings.Trim(strings.Join(w.lines, "\n"), "\n")
+}
+
+func (w *textWriter) walk(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		if w.pre > 0 {
+			w.raw(n.Data)
+		} else {
+			w.text(n.Data)
+		}
+		return
+	case html.ElementNode:
+		w.element(n)
+		return
+	}
+	w.children(n)
+}
+
+func (w *textWriter) children(n *html.Node) {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		w.walk(c)
+	}
+}
+
+func (w *textWriter) element(n *html.Node) {
+	switch n.DataAtom {
+	case atom.Head, atom.Script, atom.Style, atom.Template, atom.Noscript:
+		return
+	case atom.Br:
+		w.startLine()
+		w.lineBreak(1)
+	case atom.Hr:
+		w.lineBreak(2)
+		w.raw(strings.Repeat("-", 40))
+		w.lineBreak(2)
+	case atom.P, atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		w.lineBreak(2)
+		w.children(n)
+		w.lineBreak(2)
+	case atom.Pre:
+		w.lineBreak(2)
+		w.pre++
+		w.children(n)
+		w.pre--
+		w.lineBreak(2)
+	case atom.Blockquote:
+		w.lineBreak(2)
+		w.prefixes = append(w.prefixes, "> ")
+		w.children(n)
+		w.prefixes = w.prefixes[:len(w.prefixes)-1]
+		w.lineBreak(2)
+	case atom.Ul, atom.Ol:
+		w.list(n)
+	case atom.A:
+		w.link(n)
+	case atom.Img:
+		if alt := attr(n, "alt"); alt != "" {
+			w.text(alt)
+		}
+	case atom.Table:
+		w.table(n)
+	case atom.Div, atom.Section, atom.Article, atom.Header, atom.Footer, atom.Nav, atom.Aside, atom.Main,
+		atom.Address, atom.Figure, atom.Figcaption, atom.Dl, atom.Dt, atom.Dd, atom.Center, atom.Form, atom.Fieldset:
+		w.lineBreak(1)
+		w.children(n)
+		w.lineBreak(1)
+	default:
+		w.children(n)
+	}
+}
+
+func (w *textWriter) list(n *html.Node) {
+	ordered := n.DataAtom == atom.Ol
+	index := 1
+	if start, err := strconv.Atoi(attr(n, "start")); ordered && err == nil {
+		index = start
+	}
+
+	w.lineBreak(1)
+	if len(w.prefixes) == 0 {
+		w.lineBreak(2)
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode || c.DataAtom != atom.Li {
+			w.walk(c)
+			continue
+		}
+
+		// Set item marker
+		marker := "- "
+		if ordered {
+			marker = strconv.Itoa(index) + ". "
+			index++
+		}
+
+		// Render item
+		w.lineBreak(1)
+		w.prefixes = append(w.prefixes, strings.Repeat(" ", len(marker)))
+		w.marker = marker
+		w.children(c)
+		w.marker = ""
+		w.prefixes = w.prefixes[:len(w.prefixes)-1]
+	}
+
+	w.lineBreak(1)
+	if len(w.prefixes) == 0 {
+		w.lineBreak(2)
+	}
+}
+
+func (w *textWriter) link(n *html.Node) {
+	label := inlineText(n)
+	href := strings.TrimSpace(attr(n, "href"))
+
+	// Skip anchors and scripts
+	lower := strings.ToLower(href)
+	if href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(lower, "javascript:") {
+		w.text(label)
+		return
+	}
+
+	// Show address only once
+	target := href
+	if strings.HasPrefix(lower, "mailto:") || strings.HasPrefix(lower, "tel:") {
+		target = href[strings.Index(href, ":")+1:]
+	}
+
+	switch {
+	case label == "":
+		w.text(target)
+	case label == target || label == href:
+		w.text(label)
+	default:
+		w.text(label + " (" + target + ")")
+	}
+}
+
+func (w *textWriter) table(n *html.Node) {
+	rows := tableRows(n)
+
+	// Render layout tables as a sequence of blocks
+	if !isDataTable(rows) {
+		w.lineBreak(1)
+		for _, row := range rows {
+			for _, cell := range row {
+				w.lineBreak(1)
+				w.children(cell)
+				w.lineBreak(1)
+			}
+		}
+		w.lineBreak(1)
+		return
+	}
+
+	// Render cells
+	cells := make([][]string, len(rows))
+	widths := []int{}
+	header := false
+	for i, row := range rows {
+		cells[i] = make([]string, len(row))
+		for j, cell := range row {
+			cells[i][j] = inlineText(cell)
+			if j >= len(widths) {
+				widths = append(widths, 0)
+			}
+			if l := len([]rune(cells[i][j])); l > widths[j] {
+				widths[j] = l
+			}
+		}
+		if i == 0 && len(row) > 0 && row[0].DataAtom == atom.Th {
+			header = true
+		}
+	}
+
+	// Write aligned columns
+	w.lineBreak(2)
+	for i, row := range cells {
+		var sb strings.Builder
+		for j, cell := range row {
+			if j > 0 {
+				sb.WriteString("  ")
+			}
+			sb.WriteString(cell)
+			if j < len(row)-1 {
+				sb.WriteString(strings.Repeat(" ", widths[j]-len([]rune(cell))))
+			}
+		}
+		w.lineBreak(1)
+		w.raw(strings.TrimRight(sb.String(), " "))
+
+		// Underline header row
+		if i == 0 && header {
+			total := 0
+			for j, width := range widths {
+				if j > 0 {
+					total += 2
+				}
+				total += width
+			}
+			w.lineBreak(1)
+			w.raw(strings.Repeat("-", total))
+		}
+	}
+	w.lineBreak(2)
+}
+
+// Collect rows of a table without descending into nested tables
+func tableRows(table *html.Node) [][]*html.Node {
+	var rows [][]*html.Node
+	var visit func(n *html.Node)
+	visit = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type != html.ElementNode {
+				continue
+			}
+			switch c.DataAtom {
+			case atom.Thead, atom.Tbody, atom.Tfoot:
+				visit(c)
+			case atom.Tr:
+				var row []*html.Node
+				for cell := c.FirstChild; cell != nil; cell = cell.NextSibling {
+					if cell.Type == html.ElementNode && (cell.DataAtom == atom.Td || cell.DataAtom == atom.Th) {
+						row = append(row, cell)
+					}
+				}
+				rows = append(rows, row)
+			}
+		}
+	}
+	visit(table)
+	return rows
+}
+
+// A data table has several columns and only inline content in its cells
+func isDataTable(rows [][]*html.Node) bool {
+	columns := 0
+	for _, row := range rows {
+		if len(row) > columns {
+			columns = len(row)
+		}
+		for _, cell := range row {
+			if hasBlockContent(cell) {
+				return false
+			}
+		}
+	}
+	return columns > 1
+}
+
+func hasBlockContent(n *html.Node) bool {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode {
+			continue
+		}
+		switch c.DataAtom {
+		case atom.Table, atom.Div, atom.P, atom.Ul, atom.Ol, atom.Blockquote, atom.Pre,
+			atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6, atom.Section, atom.Article:
+			return true
+		}
+		if hasBlockContent(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// Render node content into a single line
+func inlineText(n *html.Node) string {
+	w := &textWriter{}
+	w.children(n)
+	return strings.Join(strings.Fields(w.String()), " ")
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
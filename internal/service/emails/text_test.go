@@ -0,0 +1,88 @@
+package service
+
+import "testing"
+
+func TestHtmlToText(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "link with label",
+			html: `<p>Read the <a href="https://example.com/docs">docs</a>.</p>`,
+			want: "Read the docs (https://example.com/docs).",
+		},
+		{
+			name: "link labeled with its url",
+			html: `<a href="https://example.com">https://example.com</a>`,
+			want: "https://example.com",
+		},
+		{
+			name: "link without label",
+			html: `<a href="https://example.com/x"></a>`,
+			want: "https://example.com/x",
+		},
+		{
+			name: "mailto and tel links",
+			html: `<a href="mailto:help@example.com">help@example.com</a> or <a href="tel:+123">call us</a>`,
+			want: "help@example.com or call us (+123)",
+		},
+		{
+			name: "anchor and script links",
+			html: `<a href="#top">Top</a> <a href="javascript:void(0)">Run</a>`,
+			want: "Top Run",
+		},
+		{
+			name: "unordered list",
+			html: `<ul><li>One</li><li>Two<ul><li>Nested</li></ul></li></ul>`,
+			want: "- One\n- Two\n  - Nested",
+		},
+		{
+			name: "ordered list with start",
+			html: `<p>Steps:</p><ol start="3"><li>Open</li><li>Close</li></ol><p>Done</p>`,
+			want: "Steps:\n\n3. Open\n4. Close\n\nDone",
+		},
+		{
+			name: "data table",
+			html: `<table><tr><th>Item</th><th>Qty</th></tr><tr><td>Apple</td><td>10</td></tr><tr><td>Kiwi fruit</td><td>2</td></tr></table>`,
+			want: "Item        Qty\n---------------\nApple       10\nKiwi fruit  2",
+		},
+		{
+			name: "layout table",
+			html: `<table><tr><td><p>Header</p></td></tr><tr><td><div>Body</div></td></tr></table>`,
+			want: "Header\n\nBody",
+		},
+		{
+			name: "line breaks",
+			html: `Line one<br>Line two<br><br>Line four`,
+			want: "Line one\nLine two\n\nLine four",
+		},
+		{
+			name: "blockquote paragraphs",
+			html: `<blockquote><p>First</p><p>Second</p></blockquote><p>After</p>`,
+			want: "> First\n>\n> Second\n\nAfter",
+		},
+		{
+			name: "nested blockquotes",
+			html: `<p>Before</p><blockquote><p>Outer</p><blockquote><p>Inner one</p><p>Inner two</p></blockquote></blockquote>`,
+			want: "Before\n\n> Outer\n>\n> > Inner one\n> >\n> > Inner two",
+		},
+		{
+			name: "skipped elements, rule and preformatted text",
+			html: "<head><style>p{}</style></head><p>Hi</p><script>x()</script><hr><pre>a  b\n  c</pre>",
+			want: "Hi\n\n----------------------------------------\n\na  b\n  c",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := htmlToText(tt.html)
+			if err != nil {
+				t.Fatalf("htmlToText() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("htmlToText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
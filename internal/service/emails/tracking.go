@@ -0,0 +1,346 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"html"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	emailsRepositoryAdapterPort "github.com/flash-go/notifications-service/internal/port/adapter/repository/emails"
+	emailsServicePort "github.com/flash-go/notifications-service/internal/port/service/emails"
+)
+
+// Public paths of open tracking pixels and click redirects
+const (
+	openPath  = "/notifications/emails/opens/"
+	clickPath = "/notifications/emails/clicks/"
+)
+
+// Payload prefixes of tracking tokens
+const (
+	openTokenPrefix  = "open:"
+	clickTokenPrefix = "click:"
+)
+
+// Closing body tag of html, the tracking pixel goes right before it
+var bodyCloseRegexp = regexp.MustCompile(`(?i)</body\s*>`)
+
+// Opening tag of a link in html
+var linkRegexp = regexp.MustCompile(`(?i)<a\s[^>]*>`)
+
+// Href attribute of a link tag, quoted with double or single quotes
+var hrefRegexp = regexp.MustCompile(`(?i)(\shref\s*=\s*)(?:"([^"]*)"|'([^']*)')`)
+
+// Attribute excluding a link tag from click tracking
+var notrackRegexp = regexp.MustCompile(`(?i)\sdata-notrack(?:[\s=/>])`)
+
+// Apple Mail Privacy Protection prefetches images of received messages from the Apple network
+var appleNetwork = netip.MustParsePrefix("17.0.0.0/8")
+
+// Tracking enabled for the message
+type emailTracking struct {
+	Opens  bool
+	Clicks bool
+}
+
+func (s *service) TrackOpen(ctx context.Context, data emailsServicePort.TrackOpenData) error {
+	// Verify token
+	logId, err := s.parseOpenToken(data.Token)
+	if err != nil {
+		return err
+	}
+
+	// Record open
+	ip := clientIp(data.RemoteAddr, data.ForwardedFor)
+	if _, err := s.emailsRepository.CreateEmailOpen(
+		ctx,
+		emailsRepositoryAdapterPort.CreateEmailOpenData{
+			LogId:     logId,
+			Machine:   machineOpen(data.UserAgent, ip),
+			UserAgent: data.UserAgent,
+			IpHash:    s.ipHash(ip),
+			Created:   time.Unix(0, time.Now().UnixNano()),
+		},
+	); err != nil {
+		// Log deleted after the message was sent
+		if errors.Is(err, emailsRepositoryAdapterPort.ErrEmailLogNotFound) {
+			return emailsServicePort.ErrTrackingInvalid
+		}
+		if s.logger != nil {
+			s.logger.Log().Warn().Uint("log_id", logId).Err(err).Msg("record email open")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (s *service) TrackClick(ctx context.Context, data emailsServicePort.TrackClickData) (*emailsServicePort.TrackClickResult, error) {
+	// Verify token
+	logId, link, err := s.parseClickToken(data.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	// Record click
+	click, err := s.emailsRepository.CreateEmailClick(
+		ctx,
+		emailsRepositoryAdapterPort.CreateEmailClickData{
+			LogId:     logId,
+			Link:      link,
+			UserAgent: data.UserAgent,
+			IpHash:    s.ipHash(clientIp(data.RemoteAddr, data.ForwardedFor)),
+			Created:   time.Unix(0, time.Now().UnixNano()),
+		},
+	)
+	if errors.Is(err, emailsRepositoryAdapterPort.ErrEmailLogNotFound) || errors.Is(err, emailsRepositoryAdapterPort.ErrEmailLinkNotFound) {
+		return nil, emailsServicePort.ErrTrackingInvalid
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &emailsServicePort.TrackClickResult{
+		Url: click.Url,
+	}, nil
+}
+
+func (s *service) GetClickReport(ctx context.Context, emailId uint) (*[]emailsServicePort.ClickReportResult, error) {
+	// Check email exist
+	if err := s.checkEmailExist(ctx, emailId); err != nil {
+		return nil, err
+	}
+
+	// Get click stats
+	stats, err := s.emailsRepository.GetClickStats(ctx, emailId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Map repository to service results
+	results := make([]emailsServicePort.ClickReportResult, 0, len(*stats))
+	for _, item := range *stats {
+		results = append(
+			results,
+			emailsServicePort.ClickReportResult(item),
+		)
+	}
+
+	return &results, nil
+}
+
+// Check whether tracking links can be built
+func (s *service) trackingEnabled() bool {
+	return s.trackingBaseUrl != "" && len(s.trackingSecret) > 0
+}
+
+// Add tracking of the log to the rendered html, skipped if tracking is not configured
+func (s *service) trackHtml(html string, logId uint, tracking emailTracking) string {
+	if !s.trackingEnabled() {
+		return html
+	}
+	id := strconv.FormatUint(uint64(logId), 10)
+
+	// Point tracked links at the click redirect, indexed in the order of trackedLinks
+	if tracking.Clicks {
+		link := 0
+		html = linkRegexp.ReplaceAllStringFunc(html, func(tag string) string {
+			href := hrefRegexp.FindStringSubmatchIndex(tag)
+			if href == nil || !s.isTrackedLink(tag, linkUrl(tag, href)) {
+				return tag
+			}
+			url := s.trackingBaseUrl + clickPath + s.trackingToken(clickTokenPrefix+id+":"+strconv.Itoa(link))
+			link++
+			return tag[:href[3]] + `"` + url + `"` + tag[href[1]:]
+		})
+	}
+
+	// Put pixel at the end of the body
+	if tracking.Opens {
+		pixel := `<img src="` + s.trackingBaseUrl + openPath + s.trackingToken(openTokenPrefix+id) + `" width="1" height="1" alt="" style="display:block;border:0;width:1px;height:1px">`
+		if matches := bodyCloseRegexp.FindAllStringIndex(html, -1); len(matches) > 0 {
+			i := matches[len(matches)-1][0]
+			return html[:i] + pixel + html[i:]
+		}
+		return html + pixel
+	}
+
+	return html
+}
+
+// Urls of the links of the html to track by link index, none if tracking is not configured
+func (s *service) trackedLinks(html string) []string {
+	if !s.trackingEnabled() {
+		return nil
+	}
+	var links []string
+	for _, tag := range linkRegexp.FindAllString(html, -1) {
+		href := hrefRegexp.FindStringSubmatchIndex(tag)
+		if href == nil {
+			continue
+		}
+		if url := linkUrl(tag, href); s.isTrackedLink(tag, url) {
+			links = append(links, url)
+		}
+	}
+	return links
+}
+
+// Only web links are tracked: mailto: and other schemes are kept, as well as links marked with
+// data-notrack, unsubscribe links of the service that must work without the redirect, and links
+// already pointing at the tracking routes
+func (s *service) isTrackedLink(tag, url string) bool {
+	lower := strings.ToLower(url)
+	if !strings.HasPrefix(lower, "http://") && !strings.HasPrefix(lower, "https://") {
+		return false
+	}
+	if notrackRegexp.MatchString(tag) {
+		return false
+	}
+	if s.unsubscribeBaseUrl != "" && strings.HasPrefix(url, s.unsubscribeBaseUrl+unsubscribePath) {
+		return false
+	}
+	return !strings.HasPrefix(url, s.trackingBaseUrl+clickPath) && !strings.HasPrefix(url, s.trackingBaseUrl+openPath)
+}
+
+// Url of the href matched in the link tag with html entities decoded
+func linkUrl(tag string, href []int) string {
+	start, end := href[4], href[5]
+	if start < 0 {
+		start, end = href[6], href[7]
+	}
+	return strings.TrimSpace(html.UnescapeString(tag[start:end]))
+}
+
+func (s *service) parseOpenToken(token string) (uint, error) {
+	payload, err := s.parseTrackingToken(token)
+	if err != nil {
+		return 0, err
+	}
+	value, ok := strings.CutPrefix(payload, openTokenPrefix)
+	if !ok {
+		return 0, emailsServicePort.ErrTrackingInvalid
+	}
+	logId, err := strconv.ParseUint(value, 10, 0)
+	if err != nil || logId == 0 {
+		return 0, emailsServicePort.ErrTrackingInvalid
+	}
+	return uint(logId), nil
+}
+
+func (s *service) parseClickToken(token string) (uint, int, error) {
+	payload, err := s.parseTrackingToken(token)
+	if err != nil {
+		return 0, 0, err
+	}
+	value, ok := strings.CutPrefix(payload, clickTokenPrefix)
+	if !ok {
+		return 0, 0, emailsServicePort.ErrTrackingInvalid
+	}
+	id, index, ok := strings.Cut(value, ":")
+	if !ok {
+		return 0, 0, emailsServicePort.ErrTrackingInvalid
+	}
+	logId, err := strconv.ParseUint(id, 10, 0)
+	if err != nil || logId == 0 {
+		return 0, 0, emailsServicePort.ErrTrackingInvalid
+	}
+	link, err := strconv.Atoi(index)
+	if err != nil || link < 0 {
+		return 0, 0, emailsServicePort.ErrTrackingInvalid
+	}
+	return uint(logId), link, nil
+}
+
+// Token of the payload signed with HMAC-SHA256
+func (s *service) trackingToken(payload string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." + base64.RawURLEncoding.EncodeToString(s.trackingSignature([]byte(payload)))
+}
+
+func (s *service) parseTrackingToken(token string) (string, error) {
+	if len(s.trackingSecret) == 0 {
+		return "", emailsServicePort.ErrTrackingInvalid
+	}
+	encodedPayload, encodedSignature, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", emailsServicePort.ErrTrackingInvalid
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return "", emailsServicePort.ErrTrackingInvalid
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, s.trackingSignature(payload)) {
+		return "", emailsServicePort.ErrTrackingInvalid
+	}
+	return string(payload), nil
+}
+
+func (s *service) trackingSignature(payload []byte) []byte {
+	mac := hmac.New(sha256.New, s.trackingSecret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// Address of the client: the connection address, or if the connection comes from a proxy on a
+// private network, the last forwarded address not added by such a proxy. Earlier addresses of the
+// forwarded chain are sent by the client and can be spoofed
+func clientIp(remoteAddr, forwardedFor string) string {
+	ip := remoteAddr
+	if addrPort, err := netip.ParseAddrPort(remoteAddr); err == nil {
+		ip = addrPort.Addr().Unmap().String()
+	}
+	if !trustedProxy(ip) {
+		return ip
+	}
+	entries := strings.Split(forwardedFor, ",")
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := strings.TrimSpace(entries[i])
+		if entry == "" {
+			break
+		}
+		if !trustedProxy(entry) {
+			return entry
+		}
+		ip = entry
+	}
+	return ip
+}
+
+// Check whether the address is a proxy on a loopback or private network
+func trustedProxy(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	return addr.IsLoopback() || addr.IsPrivate()
+}
+
+// Keyed hash of the client ip, requests of one client can be told apart without storing its ip
+func (s *service) ipHash(ip string) string {
+	if ip == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, s.trackingSecret)
+	mac.Write([]byte("ip:" + ip))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Check whether the open was made by a machine rather than the recipient: prefetches of Apple Mail
+// Privacy Protection from the Apple network or with its generic user agent, and clients without user agent
+func machineOpen(userAgent, ip string) bool {
+	if addr, err := netip.ParseAddr(ip); err == nil && appleNetwork.Contains(addr.Unmap()) {
+		return true
+	}
+	userAgent = strings.TrimSpace(userAgent)
+	return userAgent == "" || userAgent == "Mozilla/5.0"
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	emailsRepositoryAdapterPort "github.com/flash-go/notifications-service/internal/port/adapter/repository/emails"
+	emailsServicePort "github.com/flash-go/notifications-service/internal/port/service/emails"
+)
+
+func (s *service) FilterTrash(ctx context.Context) (*emailsServicePort.TrashResult, error) {
+	// Get trash
+	trash, err := s.emailsRepository.FilterTrash(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Map repository to service results
+	results := emailsServicePort.TrashResult{
+		Folders: make([]emailsServicePort.TrashFolderResult, 0, len(trash.Folders)),
+		Emails:  make([]emailsServicePort.TrashEmailResult, 0, len(trash.Emails)),
+	}
+	for _, folder := range trash.Folders {
+		results.Folders = append(
+			results.Folders,
+			emailsServicePort.TrashFolderResult{
+				Id:          folder.Id,
+				ParentId:    folder.ParentId,
+				Name:        folder.Name,
+				Description: folder.Description,
+				SystemFlag:  folder.SystemFlag,
+				Deleted:     folder.Deleted,
+				Expires:     folder.Deleted.Add(s.trashRetention),
+				Updated:     folder.Updated,
+				Created:     folder.Created,
+			},
+		)
+	}
+	for _, email := range trash.Emails {
+		results.Emails = append(
+			results.Emails,
+			emailsServicePort.TrashEmailResult{
+				Id:          email.Id,
+				FolderId:    email.FolderId,
+				Key:         email.Key,
+				Subject:     email.Subject,
+				Description: email.Description,
+				SystemFlag:  email.SystemFlag,
+				Deleted:     email.Deleted,
+				Expires:     email.Deleted.Add(s.trashRetention),
+				Updated:     email.Updated,
+				Created:     email.Created,
+			},
+		)
+	}
+
+	return &results, nil
+}
+
+func (s *service) RestoreFolder(ctx context.Context, id uint) error {
+	// Get trash
+	trash, err := s.emailsRepository.FilterTrash(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Find folder in trash
+	var folder *emailsRepositoryAdapterPort.TrashFolderResult
+	deleted := make(map[uint]emailsRepositoryAdapterPort.TrashFolderResult, len(trash.Folders))
+	for _, item := range trash.Folders {
+		deleted[item.Id] = item
+		if item.Id == id {
+			folder = &item
+		}
+	}
+	if folder == nil {
+		return emailsServicePort.ErrFolderNotFound
+	}
+
+	// Check retention window
+	if s.trashExpired(folder.Deleted) {
+		return emailsServicePort.ErrTrashExpired
+	}
+
+	// Check parent folder is not in trash
+	if folder.ParentId != nil {
+		if _, ok := deleted[*folder.ParentId]; ok {
+			return emailsServicePort.ErrTrashParentDeleted
+		}
+	}
+
+	// Check folder name is free in parent
+	if folders, err := s.emailsRepository.FilterFolders(
+		ctx,
+		emailsRepositoryAdapterPort.FilterFoldersData{
+			ParentId: &[]*uint{folder.ParentId},
+			Name:     &[]string{folder.Name},
+		},
+	); err != nil {
+		return err
+	} else if len(*folders) > 0 {
+		return emailsServicePort.ErrFolderExist
+	}
+
+	// Collect subtree deleted together with the folder
+	subtree := map[uint]bool{id: true}
+	for changed := true; changed; {
+		changed = false
+		for _, item := range trash.Folders {
+			if !subtree[item.Id] && item.ParentId != nil && subtree[*item.ParentId] && item.Deleted.Equal(folder.Deleted) {
+				subtree[item.Id] = true
+				changed = true
+			}
+		}
+	}
+
+	// Check keys of restored emails are free
+	var keys []string
+	for _, email := range trash.Emails {
+		if email.FolderId != nil && subtree[*email.FolderId] && email.Deleted.Equal(folder.Deleted) {
+			keys = append(keys, email.Key)
+		}
+	}
+	if len(keys) > 0 {
+		if emails, err := s.emailsRepository.FilterEmails(
+			ctx,
+			emailsRepositoryAdapterPort.FilterEmailsData{
+				Key: &keys,
+			},
+		); err != nil {
+			return err
+		} else if len(*emails) > 0 {
+			return emailsServicePort.ErrEmailExist
+		}
+	}
+
+	// Restore folder
+	return s.emailsRepository.RestoreFolder(ctx, id, folder.Deleted)
+}
+
+func (s *service) RestoreEmail(ctx context.Context, id uint) error {
+	// Get trash
+	trash, err := s.emailsRepository.FilterTrash(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Find email in trash
+	var email *emailsRepositoryAdapterPort.TrashEmailResult
+	for _, item := range trash.Emails {
+		if item.Id == id {
+			email = &item
+			break
+		}
+	}
+	if email == nil {
+		return emailsServicePort.ErrEmailNotFound
+	}
+
+	// Check retention window
+	if s.trashExpired(email.Deleted) {
+		return emailsServicePort.ErrTrashExpired
+	}
+
+	// Check folder is not in trash
+	if email.FolderId != nil {
+		for _, folder := range trash.Folders {
+			if folder.Id == *email.FolderId {
+				return emailsServicePort.ErrTrashParentDeleted
+			}
+		}
+	}
+
+	// Check email key is free
+	if err := s.checkEmailKeyFree(ctx, email.Key, nil); err != nil {
+		return err
+	}
+
+	// Restore email
+	return s.emailsRepository.RestoreEmail(ctx, id)
+}
+
+func (s *service) PurgeTrash(ctx context.Context) error {
+	// Delete folders and emails deleted before the retention window
+	return s.emailsRepository.PurgeTrash(ctx, time.Now().Add(-s.trashRetention))
+}
+
+func (s *service) trashExpired(deleted time.Time) bool {
+	return time.Since(deleted) > s.trashRetention
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"maps"
+	"strings"
+
+	emailsRepositoryAdapterPort "github.com/flash-go/notifications-service/internal/port/adapter/repository/emails"
+	emailsServicePort "github.com/flash-go/notifications-service/internal/port/service/emails"
+)
+
+// Public path of unsubscribe links
+const unsubscribePath = "/notifications/emails/unsubscribe/"
+
+// Reason of messages suppressed for recipients who opted out of the category
+const suppressedUnsubscribed = "unsubscribed"
+
+func (s *service) GetUnsubscribe(ctx context.Context, token string) (*emailsServicePort.UnsubscribeResult, error) {
+	// Verify token
+	email, category, err := s.parseUnsubscribeToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	return &emailsServicePort.UnsubscribeResult{
+		Email:    email,
+		Category: category,
+	}, nil
+}
+
+func (s *service) Unsubscribe(ctx context.Context, token string) (*emailsServicePort.UnsubscribeResult, error) {
+	// Verify token
+	email, category, err := s.parseUnsubscribeToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	// Record opt-out
+	if err := s.emailsRepository.CreateUnsubscribe(
+		ctx,
+		emailsRepositoryAdapterPort.CreateUnsubscribeData{
+			Email:    email,
+			Category: category,
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	return &emailsServicePort.UnsubscribeResult{
+		Email:    email,
+		Category: category,
+	}, nil
+}
+
+// Check whether the recipient opted out of the category
+func (s *service) isUnsubscribed(ctx context.Context, recipient, category string) (bool, error) {
+	unsubscribes, err := s.emailsRepository.FilterUnsubscribes(
+		ctx,
+		emailsRepositoryAdapterPort.FilterUnsubscribesData{
+			Email:    &[]string{recipientAddress(recipient)},
+			Category: &[]string{category},
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+	return len(*unsubscribes) > 0, nil
+}
+
+// Message logged as suppressed for the reason, only the subject is rendered
+func (s *service) suppressEmail(email emailsRepositoryAdapterPort.EmailResult, sender emailsRepositoryAdapterPort.SenderResult, message recipientSet, vars *json.RawMessage, reason string) (*outgoingEmail, error) {
+	// Render subject only
+	subject, err := s.renderTemplate(email.Subject, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	return &outgoingEmail{
+		data: emailsRepositoryAdapterPort.SendData{
+			EmailId:   &email.Id,
+			FromEmail: sender.Email,
+			FromName:  sender.Name,
+			Subject:   *subject,
+			To:        message.To,
+			Cc:        message.Cc,
+			Bcc:       message.Bcc,
+		},
+		reason: reason,
+	}, nil
+}
+
+// Add one-click unsubscribe headers of the recipient (RFC 2369, RFC 8058), skipped if links are not configured
+func (s *service) unsubscribeHeaders(headers map[string]string, recipient, category string) map[string]string {
+	if s.unsubscribeBaseUrl == "" || len(s.unsubscribeSecret) == 0 {
+		return headers
+	}
+	results := maps.Clone(headers)
+	if results == nil {
+		results = make(map[string]string, 2)
+	}
+	results["List-Unsubscribe"] = "<" + s.unsubscribeBaseUrl + unsubscribePath + s.unsubscribeToken(recipientAddress(recipient), category) + ">"
+	results["List-Unsubscribe-Post"] = "List-Unsubscribe=One-Click"
+	return results
+}
+
+// Token of the recipient and category signed with HMAC-SHA256, categories never contain a colon
+func (s *service) unsubscribeToken(email, category string) string {
+	payload := []byte(category + ":" + email)
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(s.unsubscribeSignature(payload))
+}
+
+func (s *service) parseUnsubscribeToken(token string) (string, string, error) {
+	if len(s.unsubscribeSecret) == 0 {
+		return "", "", emailsServicePort.ErrUnsubscribeInvalid
+	}
+	encodedPayload, encodedSignature, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", "", emailsServicePort.ErrUnsubscribeInvalid
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return "", "", emailsServicePort.ErrUnsubscribeInvalid
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, s.unsubscribeSignature(payload)) {
+		return "", "", emailsServicePort.ErrUnsubscribeInvalid
+	}
+	category, email, ok := strings.Cut(string(payload), ":")
+	if !ok || category == "" || email == "" {
+		return "", "", emailsServicePort.ErrUnsubscribeInvalid
+	}
+	return email, category, nil
+}
+
+func (s *service) unsubscribeSignature(payload []byte) []byte {
+	mac := hmac.New(sha256.New, s.unsubscribeSecret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}